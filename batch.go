@@ -58,6 +58,13 @@ func (qq *QueuedQuery) Exec(fn func(ct pgconn.CommandTag) error) {
 // unnecessary network round trips. A Batch must only be sent once.
 type Batch struct {
 	queuedQueries []*QueuedQuery
+
+	// IndependentQueries causes each queued query to be run in its own implicit transaction instead of all queries
+	// being run in a single implicit transaction. When a query fails with a server error its error is returned only for
+	// that query and the results of the following queries can still be read. BatchResults.Close runs all callbacks
+	// and returns the first error. An error preparing a query still fails the entire batch. It is only supported with
+	// QueryExecModeCacheStatement, QueryExecModeCacheDescribe, and QueryExecModeDescribeExec.
+	IndependentQueries bool
 }
 
 // Queue queues a query to batch b. query can be an SQL query or the name of a prepared statement.
@@ -271,6 +278,41 @@ type pipelineBatchResults struct {
 	qqIdx     int
 	closed    bool
 	endTraced bool
+
+	// syncEachQuery is true when a sync was sent after each query. pendingSync is true when the sync for the last query
+	// has not yet been read.
+	syncEachQuery bool
+	pendingSync   bool
+}
+
+// queryError returns true if err only affects a single query. That is only possible when each query is followed by a
+// sync and err is from the server.
+func (br *pipelineBatchResults) queryError(err error) bool {
+	if !br.syncEachQuery {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr)
+}
+
+// readPendingSync reads the sync that follows the previous query when syncEachQuery is true.
+func (br *pipelineBatchResults) readPendingSync() error {
+	if !br.pendingSync {
+		return nil
+	}
+	br.pendingSync = false
+
+	results, err := br.pipeline.GetResults()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := results.(*pgconn.PipelineSync); !ok {
+		return fmt.Errorf("expected sync, got %T", results)
+	}
+
+	return nil
 }
 
 // Exec reads the results from the next query in the batch as if the query has been sent with Exec.
@@ -281,7 +323,12 @@ func (br *pipelineBatchResults) Exec() (pgconn.CommandTag, error) {
 	if br.closed {
 		return pgconn.CommandTag{}, fmt.Errorf("batch already closed")
 	}
-	if br.lastRows != nil && br.lastRows.err != nil {
+	if br.lastRows != nil && br.lastRows.err != nil && !br.queryError(br.lastRows.err) {
+		return pgconn.CommandTag{}, br.err
+	}
+
+	if err := br.readPendingSync(); err != nil {
+		br.err = err
 		return pgconn.CommandTag{}, br.err
 	}
 
@@ -289,27 +336,44 @@ func (br *pipelineBatchResults) Exec() (pgconn.CommandTag, error) {
 
 	results, err := br.pipeline.GetResults()
 	if err != nil {
-		br.err = err
-		return pgconn.CommandTag{}, br.err
+		if !br.queryError(err) {
+			br.err = err
+			return pgconn.CommandTag{}, br.err
+		}
+		br.pendingSync = true
+
+		if br.conn.batchTracer != nil {
+			br.conn.batchTracer.TraceBatchQuery(br.ctx, br.conn, TraceBatchQueryData{
+				SQL:  query,
+				Args: arguments,
+				Err:  err,
+			})
+		}
+
+		return pgconn.CommandTag{}, err
 	}
 	var commandTag pgconn.CommandTag
 	switch results := results.(type) {
 	case *pgconn.ResultReader:
-		commandTag, br.err = results.Close()
+		commandTag, err = results.Close()
+		if err != nil && !br.queryError(err) {
+			br.err = err
+		}
 	default:
 		return pgconn.CommandTag{}, fmt.Errorf("unexpected pipeline result: %T", results)
 	}
+	br.pendingSync = br.syncEachQuery
 
 	if br.conn.batchTracer != nil {
 		br.conn.batchTracer.TraceBatchQuery(br.ctx, br.conn, TraceBatchQueryData{
 			SQL:        query,
 			Args:       arguments,
 			CommandTag: commandTag,
-			Err:        br.err,
+			Err:        err,
 		})
 	}
 
-	return commandTag, br.err
+	return commandTag, err
 }
 
 // Query reads the results from the next query in the batch as if the query has been sent with Query.
@@ -323,11 +387,16 @@ func (br *pipelineBatchResults) Query() (Rows, error) {
 		return &baseRows{err: alreadyClosedErr, closed: true}, alreadyClosedErr
 	}
 
-	if br.lastRows != nil && br.lastRows.err != nil {
+	if br.lastRows != nil && br.lastRows.err != nil && !br.queryError(br.lastRows.err) {
 		br.err = br.lastRows.err
 		return &baseRows{err: br.err, closed: true}, br.err
 	}
 
+	if err := br.readPendingSync(); err != nil {
+		br.err = err
+		return &baseRows{err: br.err, closed: true}, br.err
+	}
+
 	query, arguments, ok := br.nextQueryAndArgs()
 	if !ok {
 		query = "batch query"
@@ -339,7 +408,11 @@ func (br *pipelineBatchResults) Query() (Rows, error) {
 
 	results, err := br.pipeline.GetResults()
 	if err != nil {
-		br.err = err
+		if br.queryError(err) {
+			br.pendingSync = true
+		} else {
+			br.err = err
+		}
 		rows.err = err
 		rows.closed = true
 
@@ -354,6 +427,7 @@ func (br *pipelineBatchResults) Query() (Rows, error) {
 		switch results := results.(type) {
 		case *pgconn.ResultReader:
 			rows.resultReader = results
+			br.pendingSync = br.syncEachQuery
 		default:
 			err = fmt.Errorf("unexpected pipeline result: %T", results)
 			br.err = err
@@ -384,7 +458,7 @@ func (br *pipelineBatchResults) Close() error {
 		}
 	}()
 
-	if br.err == nil && br.lastRows != nil && br.lastRows.err != nil {
+	if br.err == nil && br.lastRows != nil && br.lastRows.err != nil && !br.queryError(br.lastRows.err) {
 		br.err = br.lastRows.err
 		return br.err
 	}
@@ -393,15 +467,29 @@ func (br *pipelineBatchResults) Close() error {
 		return br.err
 	}
 
+	// firstQueryErr is the first error that only affected a single query. It is returned if no error ends the batch.
+	var firstQueryErr error
+	if br.lastRows != nil && br.lastRows.err != nil {
+		firstQueryErr = br.lastRows.err
+	}
+
 	// Read and run fn for all remaining items
 	for br.err == nil && !br.closed && br.b != nil && br.qqIdx < len(br.b.queuedQueries) {
+		var err error
 		if br.b.queuedQueries[br.qqIdx].fn != nil {
-			err := br.b.queuedQueries[br.qqIdx].fn(br)
-			if err != nil {
+			err = br.b.queuedQueries[br.qqIdx].fn(br)
+		} else {
+			_, err = br.Exec()
+		}
+
+		if err != nil {
+			if br.queryError(err) {
+				if firstQueryErr == nil {
+					firstQueryErr = err
+				}
+			} else {
 				br.err = err
 			}
-		} else {
-			br.Exec()
 		}
 	}
 
@@ -411,6 +499,9 @@ func (br *pipelineBatchResults) Close() error {
 	if br.err == nil {
 		br.err = err
 	}
+	if br.err == nil {
+		br.err = firstQueryErr
+	}
 
 	return br.err
 }
@@ -1027,3 +1027,95 @@ func ExampleConn_SendBatch() {
 	// 3
 	// 5
 }
+
+func TestConnSendBatchIndependentQueries(t *testing.T) {
+	t.Parallel()
+
+	modes := []pgx.QueryExecMode{
+		pgx.QueryExecModeCacheStatement,
+		pgx.QueryExecModeCacheDescribe,
+		pgx.QueryExecModeDescribeExec,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, modes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		mustExec(t, conn, `create temporary table ledger(id int primary key)`)
+
+		batch := &pgx.Batch{IndependentQueries: true}
+		batch.Queue("insert into ledger(id) values($1)", 1)
+		batch.Queue("insert into ledger(id) values($1)", 1)
+		batch.Queue("insert into ledger(id) values($1) returning id", 2)
+
+		br := conn.SendBatch(ctx, batch)
+
+		ct, err := br.Exec()
+		require.NoError(t, err)
+		require.EqualValues(t, 1, ct.RowsAffected())
+
+		_, err = br.Exec()
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "23505", pgErr.Code)
+
+		var id int32
+		err = br.QueryRow().Scan(&id)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, id)
+
+		err = br.Close()
+		require.NoError(t, err)
+
+		var n int64
+		err = conn.QueryRow(ctx, "select count(*) from ledger").Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n)
+	})
+}
+
+func TestConnSendBatchIndependentQueriesCloseRunsAllCallbacks(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, []pgx.QueryExecMode{pgx.QueryExecModeCacheStatement}, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		mustExec(t, conn, `create temporary table ledger(id int primary key)`)
+
+		batch := &pgx.Batch{IndependentQueries: true}
+		var results []string
+		for _, id := range []int{1, 1, 2} {
+			batch.Queue("insert into ledger(id) values($1)", id).Exec(func(ct pgconn.CommandTag) error {
+				results = append(results, ct.String())
+				return nil
+			})
+		}
+
+		err := conn.SendBatch(ctx, batch).Close()
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "23505", pgErr.Code)
+		require.Equal(t, []string{"INSERT 0 1", "INSERT 0 1"}, results)
+
+		var n int64
+		err = conn.QueryRow(ctx, "select count(*) from ledger").Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n)
+	})
+}
+
+func TestConnSendBatchIndependentQueriesUnsupportedMode(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	modes := []pgx.QueryExecMode{pgx.QueryExecModeExec, pgx.QueryExecModeSimpleProtocol}
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, modes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		batch := &pgx.Batch{IndependentQueries: true}
+		batch.Queue("select 1")
+
+		err := conn.SendBatch(ctx, batch).Close()
+		require.ErrorContains(t, err, "not supported")
+	})
+}
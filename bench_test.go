@@ -588,6 +588,50 @@ func benchmarkWriteNRowsViaCopy(b *testing.B, n int) {
 	}
 }
 
+type benchmarkInt8CopyFromSrc struct {
+	count int
+	idx   int
+	row   []any
+}
+
+func (s *benchmarkInt8CopyFromSrc) Next() bool {
+	s.idx++
+	return s.idx <= s.count
+}
+
+func (s *benchmarkInt8CopyFromSrc) Values() ([]any, error) {
+	for i := range s.row {
+		s.row[i] = int64(s.idx * (i + 1))
+	}
+	return s.row, nil
+}
+
+func (s *benchmarkInt8CopyFromSrc) Err() error {
+	return nil
+}
+
+// BenchmarkCopyFromInt8Rows measures the throughput of loading integer heavy rows with the binary COPY format.
+func BenchmarkCopyFromInt8Rows(b *testing.B) {
+	conn := mustConnect(b, mustParseConfig(b, os.Getenv("PGX_TEST_DATABASE")))
+	defer closeConn(b, conn)
+
+	mustExec(b, conn, `create temporary table copy_int8 (a int8, b int8, c int8, d int8)`)
+
+	const rowCount = 100_000
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		src := &benchmarkInt8CopyFromSrc{count: rowCount, row: make([]any, 4)}
+		copyCount, err := conn.CopyFrom(context.Background(), pgx.Identifier{"copy_int8"}, []string{"a", "b", "c", "d"}, src)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if copyCount != rowCount {
+			b.Fatalf("expected %d rows, got %d", rowCount, copyCount)
+		}
+	}
+}
+
 func BenchmarkWrite2RowsViaInsert(b *testing.B) {
 	benchmarkWriteNRowsViaInsert(b, 2)
 }
@@ -31,16 +31,59 @@ type ConnConfig struct {
 	// query exec mode.
 	StatementCacheCapacity int
 
+	// StatementCachePrepareThreshold is the number of times a query must be executed with "cache_statement" query exec
+	// mode before it is prepared and added to the statement cache. Until then it is executed with the unnamed prepared
+	// statement as with "cache_describe" query exec mode ("describe_exec" if the description cache is disabled). This
+	// avoids creating server side prepared statements for queries that are only executed once or twice. Values of 1 or
+	// less prepare every query on its first execution. Queries sent in a Batch are always prepared.
+	StatementCachePrepareThreshold int
+
+	// StatementCacheSkipDeallocate stops statements evicted from the statement cache from being closed on the server.
+	// Closing a statement after the transaction that used it has ended is incompatible with transaction pooling proxies
+	// that may route it to a different server connection. The evicted statements remain prepared until the server
+	// connection is reset or closed. A cached statement is always named after a hash of its SQL and may already exist on
+	// the server connection the proxy routes to, so every cached statement is closed in the same round trip as its
+	// prepare.
+	StatementCacheSkipDeallocate bool
+
 	// DescriptionCacheCapacity is the maximum size of the description cache used when executing a query with
 	// "cache_describe" query exec mode.
 	DescriptionCacheCapacity int
 
+	// PrepareStatements are prepared and added to the statement cache when the connection is established so the first
+	// execution of each does not pay the cost of preparing it. If the statement cache is disabled they are added to the
+	// description cache instead. If a statement cannot be prepared the connection fails. Statements beyond the capacity
+	// of the cache evict earlier ones. pgxpool prepares them when a connection is first acquired instead, after
+	// AfterConnect and PrepareConn have run.
+	PrepareStatements []string
+
 	// DefaultQueryExecMode controls the default mode for executing queries. By default pgx uses the extended protocol
 	// and automatically prepares and caches prepared statements. However, this may be incompatible with proxies such as
 	// PGBouncer. In this case it may be preferable to use QueryExecModeExec or QueryExecModeSimpleProtocol. The same
 	// functionality can be controlled on a per query basis by passing a QueryExecMode as the first query argument.
 	DefaultQueryExecMode QueryExecMode
 
+	// DefaultStatementTimeout is the PostgreSQL statement_timeout applied to each query sent with Exec or Query. Unlike
+	// setting statement_timeout in RuntimeParams it can be overridden on a per query basis by passing a
+	// QueryStatementTimeout as the first query argument. It does not apply to batches or CopyFrom. 0 leaves
+	// statement_timeout unchanged. Statements that cannot run inside a transaction block, such as VACUUM, must be sent with
+	// QueryStatementTimeout(0). See QueryStatementTimeout for details.
+	DefaultStatementTimeout time.Duration
+
+	// TextFormatOnly sends every query parameter and requests every result column in the text format instead of the
+	// preferred format of each type. It can help with debugging or with proxies that cannot handle the binary format.
+	// Formats requested explicitly with QueryResultFormats or QueryResultFormatsByOID are still used and CopyFrom still
+	// uses the binary COPY format. Types that only support the binary format cannot be used as parameters.
+	TextFormatOnly bool
+
+	// QueryRewriter rewrites the SQL of every query sent with Exec, ExecMulti, Query, QueryRow, SendBatch,
+	// Pipeline.SendQuery, Prepare, CopyFrom, and CopyFromWithReturning, and of PrepareStatements. It is applied after any
+	// QueryRewriter passed as a query argument such as NamedArgs. It is not applied to the name of a statement prepared
+	// with Prepare, whose SQL was rewritten when it was prepared, or to the queries pgx sends on its own behalf such as
+	// the transaction control statements sent by Begin and Commit and the catalog queries of LoadType. The generated SQL
+	// of CopyFrom and Prepare has no arguments, so the rewriter must not add any.
+	QueryRewriter QueryRewriter
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 }
 
@@ -71,6 +114,12 @@ type Conn struct {
 	statementCache     stmtcache.Cache
 	descriptionCache   stmtcache.Cache
 
+	// internalQueries is set while pgx sends queries on its own behalf. ConnConfig.QueryRewriter is not applied to them.
+	internalQueries bool
+
+	// queryExecCounts counts executions of queries that have not reached StatementCachePrepareThreshold.
+	queryExecCounts map[string]int
+
 	queryTracer    QueryTracer
 	batchTracer    BatchTracer
 	copyFromTracer CopyFromTracer
@@ -83,6 +132,8 @@ type Conn struct {
 
 	typeMap *pgtype.Map
 
+	checkedUnknownOIDs map[uint32]struct{} // OIDs that registerDomainTypes has already looked up
+
 	wbuf []byte
 	eqb  ExtendedQueryBuilder
 }
@@ -101,11 +152,36 @@ func (ident Identifier) Sanitize() string {
 	return strings.Join(parts, ".")
 }
 
+// SanitizeColumns returns columns as a comma separated list of sanitized identifiers safe for SQL interpolation. Each
+// column is qualified by ident. e.g. Identifier{"t"}.SanitizeColumns([]string{"a", "b"}) returns `"t"."a", "t"."b"`. An
+// empty ident returns unqualified columns suitable for a column list or an ON CONFLICT target.
+func (ident Identifier) SanitizeColumns(columns []string) string {
+	idents := make([]Identifier, len(columns))
+	for i, column := range columns {
+		idents[i] = make(Identifier, 0, len(ident)+1)
+		idents[i] = append(idents[i], ident...)
+		idents[i] = append(idents[i], column)
+	}
+	return SanitizeIdentifiers(idents...)
+}
+
+// SanitizeIdentifiers returns idents as a comma separated list of sanitized identifiers safe for SQL interpolation.
+func SanitizeIdentifiers(idents ...Identifier) string {
+	parts := make([]string, len(idents))
+	for i, ident := range idents {
+		parts[i] = ident.Sanitize()
+	}
+	return strings.Join(parts, ", ")
+}
+
 var (
 	// ErrNoRows occurs when rows are expected but none are returned.
 	ErrNoRows = errors.New("no rows in result set")
 	// ErrTooManyRows occurs when more rows than expected are returned.
 	ErrTooManyRows = errors.New("too many rows in result set")
+	// ErrUnexpectedRowsAffected occurs when a statement executed with ExecExpect affects a different number of rows than
+	// expected.
+	ErrUnexpectedRowsAffected = errors.New("unexpected number of rows affected")
 )
 
 var errDisabledStatementCache = fmt.Errorf("cannot use QueryExecModeCacheStatement with disabled statement cache")
@@ -159,6 +235,26 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		statementCacheCapacity = int(n)
 	}
 
+	statementCachePrepareThreshold := 0
+	if s, ok := config.RuntimeParams["statement_cache_prepare_threshold"]; ok {
+		delete(config.RuntimeParams, "statement_cache_prepare_threshold")
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse statement_cache_prepare_threshold: %w", err)
+		}
+		statementCachePrepareThreshold = int(n)
+	}
+
+	statementCacheSkipDeallocate := false
+	if s, ok := config.RuntimeParams["statement_cache_skip_deallocate"]; ok {
+		delete(config.RuntimeParams, "statement_cache_skip_deallocate")
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse statement_cache_skip_deallocate: %w", err)
+		}
+		statementCacheSkipDeallocate = b
+	}
+
 	descriptionCacheCapacity := 512
 	if s, ok := config.RuntimeParams["description_cache_capacity"]; ok {
 		delete(config.RuntimeParams, "description_cache_capacity")
@@ -189,12 +285,14 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 	}
 
 	connConfig := &ConnConfig{
-		Config:                   *config,
-		createdByParseConfig:     true,
-		StatementCacheCapacity:   statementCacheCapacity,
-		DescriptionCacheCapacity: descriptionCacheCapacity,
-		DefaultQueryExecMode:     defaultQueryExecMode,
-		connString:               connString,
+		Config:                         *config,
+		createdByParseConfig:           true,
+		StatementCacheCapacity:         statementCacheCapacity,
+		StatementCachePrepareThreshold: statementCachePrepareThreshold,
+		StatementCacheSkipDeallocate:   statementCacheSkipDeallocate,
+		DescriptionCacheCapacity:       descriptionCacheCapacity,
+		DefaultQueryExecMode:           defaultQueryExecMode,
+		connString:                     connString,
 	}
 
 	return connConfig, nil
@@ -211,6 +309,13 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 //     The maximum size of the statement cache used when executing a query with "cache_statement" query exec mode.
 //     Default: 512.
 //
+//   - statement_cache_prepare_threshold.
+//     The number of executions after which a query is prepared and added to the statement cache. Default: 0 (prepare
+//     on the first execution).
+//
+//   - statement_cache_skip_deallocate.
+//     Whether statements evicted from the statement cache are left prepared on the server. Default: false.
+//
 //   - description_cache_capacity.
 //     The maximum size of the description cache used when executing a query with "cache_describe" query exec mode.
 //     Default: 512.
@@ -238,6 +343,7 @@ func connect(ctx context.Context, config *ConnConfig) (c *Conn, err error) {
 		typeMap:     pgtype.NewMap(),
 		queryTracer: config.Tracer,
 	}
+	c.eqb.textFormatOnly = config.TextFormatOnly
 
 	if t, ok := c.queryTracer.(BatchTracer); ok {
 		c.batchTracer = t
@@ -272,9 +378,46 @@ func connect(ctx context.Context, config *ConnConfig) (c *Conn, err error) {
 		c.descriptionCache = stmtcache.NewLRUCache(c.config.DescriptionCacheCapacity)
 	}
 
+	if c.config.StatementCachePrepareThreshold > 1 {
+		c.queryExecCounts = make(map[string]int)
+	}
+
+	if len(c.config.PrepareStatements) > 0 {
+		err = c.PrepareStatements(ctx, c.config.PrepareStatements)
+		if err != nil {
+			c.pgConn.Close(ctx)
+			return nil, err
+		}
+	}
+
 	return c, nil
 }
 
+// PrepareStatements adds statements to the statement cache or, if it is disabled, the description cache. It is called
+// with ConnConfig.PrepareStatements when the connection is established.
+func (c *Conn) PrepareStatements(ctx context.Context, statements []string) error {
+	mode := QueryExecModeDescribeExec
+	if c.statementCache != nil {
+		mode = QueryExecModeCacheStatement
+	} else if c.descriptionCache != nil {
+		mode = QueryExecModeCacheDescribe
+	}
+
+	for _, sql := range statements {
+		stmtSQL, err := c.rewriteStatementSQL(ctx, sql)
+		if err != nil {
+			return fmt.Errorf("failed to prepare statement %q: %w", sql, err)
+		}
+
+		_, err = c.getStatementDescription(ctx, mode, stmtSQL)
+		if err != nil {
+			return fmt.Errorf("failed to prepare statement %q: %w", sql, err)
+		}
+	}
+
+	return nil
+}
+
 // Close closes a connection. It is safe to call Close on an already closed
 // connection.
 func (c *Conn) Close(ctx context.Context) error {
@@ -295,14 +438,32 @@ func (c *Conn) Close(ctx context.Context) error {
 //
 // Prepare is idempotent; i.e. it is safe to call Prepare multiple times with the same name and sql arguments. This
 // allows a code path to Prepare and Query/Exec without concern for if the statement has already been prepared.
+//
+// sql is rewritten by ConnConfig.QueryRewriter before it is prepared. name, even if it is equal to sql, is not.
 func (c *Conn) Prepare(ctx context.Context, name, sql string) (sd *pgconn.StatementDescription, err error) {
+	return c.prepare(ctx, name, sql, true)
+}
+
+// prepare implements Prepare. rewrite is false when sql has already been rewritten by the caller.
+func (c *Conn) prepare(ctx context.Context, name, sql string, rewrite bool) (sd *pgconn.StatementDescription, err error) {
 	if c.prepareTracer != nil {
 		ctx = c.prepareTracer.TracePrepareStart(ctx, c, TracePrepareStartData{Name: name, SQL: sql})
 	}
 
+	stmtSQL := sql
+	if rewrite {
+		stmtSQL, err = c.rewriteStatementSQL(ctx, sql)
+		if err != nil {
+			if c.prepareTracer != nil {
+				c.prepareTracer.TracePrepareEnd(ctx, c, TracePrepareEndData{Err: err})
+			}
+			return nil, err
+		}
+	}
+
 	if name != "" {
 		var ok bool
-		if sd, ok = c.preparedStatements[name]; ok && sd.SQL == sql {
+		if sd, ok = c.preparedStatements[name]; ok && sd.SQL == stmtSQL {
 			if c.prepareTracer != nil {
 				c.prepareTracer.TracePrepareEnd(ctx, c, TracePrepareEndData{AlreadyPrepared: true})
 			}
@@ -326,18 +487,181 @@ func (c *Conn) Prepare(ctx context.Context, name, sql string) (sd *pgconn.Statem
 		psKey = name
 	}
 
-	sd, err = c.pgConn.Prepare(ctx, psName, sql, nil)
+	sd, err = c.prepareStatement(ctx, psName, stmtSQL)
 	if err != nil {
 		return nil, err
 	}
 
+	// Record the statement before looking up domain types so it is known even if the lookup fails.
 	if psKey != "" {
 		c.preparedStatements[psKey] = sd
 	}
 
+	registered, err := c.registerUnknownDomainTypes(ctx, sd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Looking up the domain types replaced the unnamed statement so it must be prepared again.
+	if registered && psName == "" {
+		sd, err = c.pgConn.Prepare(ctx, psName, stmtSQL, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return sd, nil
 }
 
+// prepareStatement prepares sql as name. If closeBeforePrepare reports that a statement may already exist with name it
+// is closed first in the same round trip.
+func (c *Conn) prepareStatement(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	if !c.closeBeforePrepare(name) {
+		return c.pgConn.Prepare(ctx, name, sql, nil)
+	}
+
+	pipeline := c.pgConn.StartPipeline(ctx)
+	defer pipeline.Close()
+
+	pipeline.SendDeallocate(name)
+	pipeline.SendPrepare(name, sql, nil)
+	err := pipeline.Sync()
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := pipeline.GetResults()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := results.(*pgconn.CloseComplete); !ok {
+		return nil, fmt.Errorf("expected close complete, got %T", results)
+	}
+
+	results, err = pipeline.GetResults()
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := results.(*pgconn.StatementDescription)
+	if !ok {
+		return nil, fmt.Errorf("expected statement description, got %T", results)
+	}
+
+	err = pipeline.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return sd, nil
+}
+
+// closeBeforePrepare returns true if a statement named name must be closed before it is prepared. With
+// StatementCacheSkipDeallocate a statement cache name may already be prepared on the server even if this Conn has never
+// prepared it. e.g. A transaction pooling proxy may route the prepare to a server connection where another client left
+// it. Closing a statement that does not exist is not an error.
+func (c *Conn) closeBeforePrepare(name string) bool {
+	return c.config.StatementCacheSkipDeallocate && stmtcache.IsStatementName(name)
+}
+
+// PreparedStatement returns the description of the statement prepared with name. The description includes the
+// parameter OIDs and result fields the server inferred for the statement. nil is returned if no statement with name
+// has been prepared.
+func (c *Conn) PreparedStatement(name string) *pgconn.StatementDescription {
+	return c.preparedStatements[name]
+}
+
+// registerUnknownDomainTypes registers the domain types used by the parameters and result fields of sd that are not
+// already registered. It returns true if the database was queried.
+func (c *Conn) registerUnknownDomainTypes(ctx context.Context, sd *pgconn.StatementDescription) (bool, error) {
+	unknownOIDs := c.unknownTypeOIDs(sd)
+	if len(unknownOIDs) == 0 {
+		return false, nil
+	}
+
+	result := c.pgConn.ExecParams(ctx, lookupDomainTypesSQL, lookupDomainTypesParams(unknownOIDs), nil, nil, nil).Read()
+	if result.Err != nil {
+		return true, result.Err
+	}
+
+	return true, c.registerDomainTypes(unknownOIDs, result.Rows)
+}
+
+// unknownTypeOIDs returns the OIDs used by the parameters and result fields of sds that are not registered and have
+// not been looked up before.
+func (c *Conn) unknownTypeOIDs(sds ...*pgconn.StatementDescription) []uint32 {
+	var unknownOIDs []uint32
+	seen := make(map[uint32]struct{})
+	checkOID := func(oid uint32) {
+		if _, ok := c.typeMap.TypeForOID(oid); ok {
+			return
+		}
+		if _, ok := c.checkedUnknownOIDs[oid]; ok {
+			return
+		}
+		if _, ok := seen[oid]; ok {
+			return
+		}
+		seen[oid] = struct{}{}
+		unknownOIDs = append(unknownOIDs, oid)
+	}
+	for _, sd := range sds {
+		for _, oid := range sd.ParamOIDs {
+			checkOID(oid)
+		}
+		for _, fd := range sd.Fields {
+			checkOID(fd.DataTypeOID)
+		}
+	}
+
+	return unknownOIDs
+}
+
+// lookupDomainTypesSQL selects the name and base type of each domain in $1. Domains over domains are followed to the
+// underlying base type.
+const lookupDomainTypesSQL = `with recursive d(oid, basetype) as (
+	select oid, typbasetype from pg_type where oid = any($1::oid[]) and typtype = 'd'
+	union all
+	select d.oid, t.typbasetype from d join pg_type t on t.oid = d.basetype where t.typtype = 'd'
+)
+select d.oid, d.oid::regtype::text, d.basetype from d join pg_type t on t.oid = d.basetype where t.typtype <> 'd'`
+
+func lookupDomainTypesParams(oids []uint32) [][]byte {
+	strs := make([]string, len(oids))
+	for i, oid := range oids {
+		strs[i] = strconv.FormatUint(uint64(oid), 10)
+	}
+	return [][]byte{[]byte("{" + strings.Join(strs, ",") + "}")}
+}
+
+// registerDomainTypes registers the domains returned by lookupDomainTypesSQL for oids. Each domain reuses the codec of
+// its base type. Domains whose base type is not registered are skipped. oids are remembered so they are only looked up
+// once. This only happens after a successful lookup so a failed lookup is retried.
+func (c *Conn) registerDomainTypes(oids []uint32, rows [][][]byte) error {
+	for _, row := range rows {
+		oid, err := strconv.ParseUint(string(row[0]), 10, 32)
+		if err != nil {
+			return err
+		}
+		baseOID, err := strconv.ParseUint(string(row[2]), 10, 32)
+		if err != nil {
+			return err
+		}
+
+		if baseType, ok := c.typeMap.TypeForOID(uint32(baseOID)); ok {
+			c.typeMap.RegisterType(&pgtype.Type{Name: string(row[1]), OID: uint32(oid), Codec: baseType.Codec})
+		}
+	}
+
+	if c.checkedUnknownOIDs == nil {
+		c.checkedUnknownOIDs = make(map[uint32]struct{})
+	}
+	for _, oid := range oids {
+		c.checkedUnknownOIDs[oid] = struct{}{}
+	}
+
+	return nil
+}
+
 // Deallocate releases a prepared statement.
 func (c *Conn) Deallocate(ctx context.Context, name string) error {
 	var psName string
@@ -360,6 +684,9 @@ func (c *Conn) DeallocateAll(ctx context.Context) error {
 	if c.config.DescriptionCacheCapacity > 0 {
 		c.descriptionCache = stmtcache.NewLRUCache(c.config.DescriptionCacheCapacity)
 	}
+	if c.queryExecCounts != nil {
+		c.queryExecCounts = make(map[string]int)
+	}
 	_, err := c.pgConn.Exec(ctx, "deallocate all").ReadAll()
 	return err
 }
@@ -419,6 +746,12 @@ func (c *Conn) Ping(ctx context.Context) error {
 // is used and the connection must be returned to the same state before any *pgx.Conn methods are again used.
 func (c *Conn) PgConn() *pgconn.PgConn { return c.pgConn }
 
+// ParameterStatus returns the value of a parameter reported by the server (e.g. server_version or TimeZone). Returns
+// an empty string for unknown parameters. Use ConnConfig.OnParameterStatus to be notified when a parameter changes.
+func (c *Conn) ParameterStatus(key string) string {
+	return c.pgConn.ParameterStatus(key)
+}
+
 // TypeMap returns the connection info used for this connection.
 func (c *Conn) TypeMap() *pgtype.Map { return c.typeMap }
 
@@ -427,6 +760,11 @@ func (c *Conn) Config() *ConnConfig { return c.config.Copy() }
 
 // Exec executes sql. sql can be either a prepared statement name or an SQL string. arguments should be referenced
 // positionally from the sql string as $1, $2, etc.
+//
+// A QueryExecMode or QueryRewriter may be passed as the first element of arguments. For example, passing
+// QueryExecModeDescribeExec or QueryExecModeExec executes a single statement without preparing it or adding it to the
+// statement cache regardless of ConnConfig.DefaultQueryExecMode. A QueryStatementTimeout may also be passed in the same
+// way.
 func (c *Conn) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
 	if c.queryTracer != nil {
 		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: sql, Args: arguments})
@@ -445,8 +783,125 @@ func (c *Conn) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.C
 	return commandTag, err
 }
 
+// ExecMulti executes sql which may contain multiple semicolon separated statements and returns the command tag of each
+// statement in order. sql is always sent with the simple protocol. arguments are sanitized into sql client side and
+// should be referenced positionally from the sql string as $1, $2, etc.
+//
+// A QueryStatementTimeout or QueryRewriter may be passed as the first element of arguments as with Exec. Passing any
+// QueryExecMode other than QueryExecModeSimpleProtocol is an error.
+//
+// If a statement fails the command tags of the statements that completed before it are returned along with the error.
+// Note that PostgreSQL runs a multi-statement simple query in an implicit transaction unless it contains explicit
+// transaction control statements, so the earlier statements may have been rolled back.
+func (c *Conn) ExecMulti(ctx context.Context, sql string, arguments ...any) ([]pgconn.CommandTag, error) {
+	if c.queryTracer != nil {
+		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: sql, Args: arguments})
+	}
+
+	commandTags, err := c.execMulti(ctx, sql, arguments)
+
+	if c.queryTracer != nil {
+		var commandTag pgconn.CommandTag
+		if len(commandTags) > 0 {
+			commandTag = commandTags[len(commandTags)-1]
+		}
+		c.queryTracer.TraceQueryEnd(ctx, c, TraceQueryEndData{CommandTag: commandTag, Err: err})
+	}
+
+	return commandTags, err
+}
+
+// ExecExpect calls Exec on db and returns an error where errors.Is(ErrUnexpectedRowsAffected) is true if the statement
+// did not affect exactly expected rows. e.g. An update by primary key can expect 1 row to catch a missing or wrong
+// WHERE clause. The statement has already been executed when the error is returned so db should usually be a Tx that
+// is rolled back on error.
+func ExecExpect(
+	ctx context.Context,
+	db interface {
+		Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	},
+	expected int64,
+	sql string,
+	arguments ...any,
+) (pgconn.CommandTag, error) {
+	commandTag, err := db.Exec(ctx, sql, arguments...)
+	if err != nil {
+		return commandTag, err
+	}
+
+	if rowsAffected := commandTag.RowsAffected(); rowsAffected != expected {
+		return commandTag, fmt.Errorf("%w: expected %d, got %d", ErrUnexpectedRowsAffected, expected, rowsAffected)
+	}
+
+	return commandTag, nil
+}
+
+func (c *Conn) execMulti(ctx context.Context, sql string, arguments []any) ([]pgconn.CommandTag, error) {
+	statementTimeout := c.config.DefaultStatementTimeout
+	var queryRewriter QueryRewriter
+
+optionLoop:
+	for len(arguments) > 0 {
+		switch arg := arguments[0].(type) {
+		case QueryExecMode:
+			if arg != QueryExecModeSimpleProtocol {
+				return nil, fmt.Errorf("ExecMulti does not support QueryExecMode %v", arg)
+			}
+			arguments = arguments[1:]
+		case QueryStatementTimeout:
+			statementTimeout = time.Duration(arg)
+			arguments = arguments[1:]
+		case QueryRewriter:
+			queryRewriter = arg
+			arguments = arguments[1:]
+		default:
+			break optionLoop
+		}
+	}
+
+	if err := c.deallocateInvalidatedCachedStatements(ctx); err != nil {
+		return nil, err
+	}
+
+	sql, arguments, err := c.rewriteQuery(ctx, queryRewriter, sql, arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(arguments) > 0 {
+		sql, err = c.sanitizeForSimpleQuery(sql, arguments...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The result of the statement that sets statement_timeout is not returned to the caller.
+	skipResults := 0
+	if statementTimeout > 0 {
+		sql = statementTimeoutSimpleProtocolSQL(statementTimeout, sql)
+		skipResults = 1
+	}
+
+	var commandTags []pgconn.CommandTag
+	mrr := c.pgConn.Exec(ctx, sql)
+	for mrr.NextResult() {
+		commandTag, err := mrr.ResultReader().Close()
+		if err != nil {
+			break
+		}
+		if skipResults > 0 {
+			skipResults--
+			continue
+		}
+		commandTags = append(commandTags, commandTag)
+	}
+	err = mrr.Close()
+	return commandTags, err
+}
+
 func (c *Conn) exec(ctx context.Context, sql string, arguments ...any) (commandTag pgconn.CommandTag, err error) {
 	mode := c.config.DefaultQueryExecMode
+	statementTimeout := c.config.DefaultStatementTimeout
 	var queryRewriter QueryRewriter
 
 optionLoop:
@@ -455,6 +910,9 @@ optionLoop:
 		case QueryExecMode:
 			mode = arg
 			arguments = arguments[1:]
+		case QueryStatementTimeout:
+			statementTimeout = time.Duration(arg)
+			arguments = arguments[1:]
 		case QueryRewriter:
 			queryRewriter = arg
 			arguments = arguments[1:]
@@ -463,11 +921,9 @@ optionLoop:
 		}
 	}
 
-	if queryRewriter != nil {
-		sql, arguments, err = queryRewriter.RewriteQuery(ctx, c, sql, arguments)
-		if err != nil {
-			return pgconn.CommandTag{}, fmt.Errorf("rewrite query failed: %v", err)
-		}
+	sql, arguments, err = c.rewriteQuery(ctx, queryRewriter, sql, arguments)
+	if err != nil {
+		return pgconn.CommandTag{}, err
 	}
 
 	// Always use simple protocol when there are no arguments.
@@ -476,7 +932,11 @@ optionLoop:
 	}
 
 	if sd, ok := c.preparedStatements[sql]; ok {
-		return c.execPrepared(ctx, sd, arguments)
+		return c.execPrepared(ctx, sd, arguments, statementTimeout)
+	}
+
+	if mode == QueryExecModeCacheStatement {
+		mode = c.cacheStatementQueryExecMode(sql)
 	}
 
 	switch mode {
@@ -486,43 +946,43 @@ optionLoop:
 		}
 		sd := c.statementCache.Get(sql)
 		if sd == nil {
-			sd, err = c.Prepare(ctx, stmtcache.StatementName(sql), sql)
+			sd, err = c.prepare(ctx, stmtcache.StatementName(sql), sql, false)
 			if err != nil {
 				return pgconn.CommandTag{}, err
 			}
 			c.statementCache.Put(sd)
 		}
 
-		return c.execPrepared(ctx, sd, arguments)
+		return c.execPrepared(ctx, sd, arguments, statementTimeout)
 	case QueryExecModeCacheDescribe:
 		if c.descriptionCache == nil {
 			return pgconn.CommandTag{}, errDisabledDescriptionCache
 		}
 		sd := c.descriptionCache.Get(sql)
 		if sd == nil {
-			sd, err = c.Prepare(ctx, "", sql)
+			sd, err = c.prepare(ctx, "", sql, false)
 			if err != nil {
 				return pgconn.CommandTag{}, err
 			}
 		}
 
-		return c.execParams(ctx, sd, arguments)
+		return c.execParams(ctx, sd, arguments, statementTimeout)
 	case QueryExecModeDescribeExec:
-		sd, err := c.Prepare(ctx, "", sql)
+		sd, err := c.prepare(ctx, "", sql, false)
 		if err != nil {
 			return pgconn.CommandTag{}, err
 		}
-		return c.execPrepared(ctx, sd, arguments)
+		return c.execPrepared(ctx, sd, arguments, statementTimeout)
 	case QueryExecModeExec:
-		return c.execSQLParams(ctx, sql, arguments)
+		return c.execSQLParams(ctx, sql, arguments, statementTimeout)
 	case QueryExecModeSimpleProtocol:
-		return c.execSimpleProtocol(ctx, sql, arguments)
+		return c.execSimpleProtocol(ctx, sql, arguments, statementTimeout)
 	default:
 		return pgconn.CommandTag{}, fmt.Errorf("unknown QueryExecMode: %v", mode)
 	}
 }
 
-func (c *Conn) execSimpleProtocol(ctx context.Context, sql string, arguments []any) (commandTag pgconn.CommandTag, err error) {
+func (c *Conn) execSimpleProtocol(ctx context.Context, sql string, arguments []any, statementTimeout time.Duration) (commandTag pgconn.CommandTag, err error) {
 	if len(arguments) > 0 {
 		sql, err = c.sanitizeForSimpleQuery(sql, arguments...)
 		if err != nil {
@@ -530,6 +990,10 @@ func (c *Conn) execSimpleProtocol(ctx context.Context, sql string, arguments []a
 		}
 	}
 
+	if statementTimeout > 0 {
+		sql = statementTimeoutSimpleProtocolSQL(statementTimeout, sql)
+	}
+
 	mrr := c.pgConn.Exec(ctx, sql)
 	for mrr.NextResult() {
 		commandTag, _ = mrr.ResultReader().Close()
@@ -538,23 +1002,39 @@ func (c *Conn) execSimpleProtocol(ctx context.Context, sql string, arguments []a
 	return commandTag, err
 }
 
-func (c *Conn) execParams(ctx context.Context, sd *pgconn.StatementDescription, arguments []any) (pgconn.CommandTag, error) {
+func (c *Conn) execParams(ctx context.Context, sd *pgconn.StatementDescription, arguments []any, statementTimeout time.Duration) (pgconn.CommandTag, error) {
 	err := c.eqb.Build(c.typeMap, sd, arguments)
 	if err != nil {
 		return pgconn.CommandTag{}, err
 	}
 
+	if statementTimeout > 0 {
+		commandTag, err := c.execWithStatementTimeout(ctx, statementTimeout, func(pipeline *pgconn.Pipeline) {
+			pipeline.SendQueryParams(sd.SQL, c.eqb.ParamValues, sd.ParamOIDs, c.eqb.ParamFormats, c.eqb.ResultFormats)
+		})
+		c.eqb.reset()
+		return commandTag, err
+	}
+
 	result := c.pgConn.ExecParams(ctx, sd.SQL, c.eqb.ParamValues, sd.ParamOIDs, c.eqb.ParamFormats, c.eqb.ResultFormats).Read()
 	c.eqb.reset() // Allow c.eqb internal memory to be GC'ed as soon as possible.
 	return result.CommandTag, result.Err
 }
 
-func (c *Conn) execPrepared(ctx context.Context, sd *pgconn.StatementDescription, arguments []any) (pgconn.CommandTag, error) {
+func (c *Conn) execPrepared(ctx context.Context, sd *pgconn.StatementDescription, arguments []any, statementTimeout time.Duration) (pgconn.CommandTag, error) {
 	err := c.eqb.Build(c.typeMap, sd, arguments)
 	if err != nil {
 		return pgconn.CommandTag{}, err
 	}
 
+	if statementTimeout > 0 {
+		commandTag, err := c.execWithStatementTimeout(ctx, statementTimeout, func(pipeline *pgconn.Pipeline) {
+			pipeline.SendQueryPrepared(sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, c.eqb.ResultFormats)
+		})
+		c.eqb.reset()
+		return commandTag, err
+	}
+
 	result := c.pgConn.ExecPrepared(ctx, sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, c.eqb.ResultFormats).Read()
 	c.eqb.reset() // Allow c.eqb internal memory to be GC'ed as soon as possible.
 	return result.CommandTag, result.Err
@@ -568,12 +1048,20 @@ func (e *unknownArgumentTypeQueryExecModeExecError) Error() string {
 	return fmt.Sprintf("cannot use unregistered type %T as query argument in QueryExecModeExec", e.arg)
 }
 
-func (c *Conn) execSQLParams(ctx context.Context, sql string, args []any) (pgconn.CommandTag, error) {
+func (c *Conn) execSQLParams(ctx context.Context, sql string, args []any, statementTimeout time.Duration) (pgconn.CommandTag, error) {
 	err := c.eqb.Build(c.typeMap, nil, args)
 	if err != nil {
 		return pgconn.CommandTag{}, err
 	}
 
+	if statementTimeout > 0 {
+		commandTag, err := c.execWithStatementTimeout(ctx, statementTimeout, func(pipeline *pgconn.Pipeline) {
+			pipeline.SendQueryParams(sql, c.eqb.ParamValues, nil, c.eqb.ParamFormats, c.eqb.ResultFormats)
+		})
+		c.eqb.reset()
+		return commandTag, err
+	}
+
 	result := c.pgConn.ExecParams(ctx, sql, c.eqb.ParamValues, nil, c.eqb.ParamFormats, c.eqb.ResultFormats).Read()
 	c.eqb.reset() // Allow c.eqb internal memory to be GC'ed as soon as possible.
 	return result.CommandTag, result.Err
@@ -614,7 +1102,9 @@ const (
 	// to execute. It does not use named prepared statements. But it does use the unnamed prepared statement to get the
 	// statement description on the first round trip and then uses it to execute the query on the second round trip. This
 	// may cause problems with connection poolers that switch the underlying connection between round trips. It is safe
-	// even when the the database schema is modified concurrently.
+	// even when the database schema is modified concurrently. Because the parameter types are known, parameters are sent
+	// in binary format when supported. Nothing is added to the statement or description caches so this mode is well
+	// suited for queries that are only executed once.
 	QueryExecModeDescribeExec
 
 	// Assume the PostgreSQL query parameter types based on the Go type of the arguments. This uses the extended protocol
@@ -663,11 +1153,66 @@ type QueryResultFormats []int16
 // QueryResultFormatsByOID controls the result format (text=0, binary=1) of a query by the result column OID.
 type QueryResultFormatsByOID map[uint32]int16
 
-// QueryRewriter rewrites a query when used as the first arguments to a query method.
+// queryArguments is passed by pgx itself as the only argument to Query. Its elements are the query arguments. They are
+// never interpreted as query options even when their types match one, e.g. a QueryExecMode value.
+type queryArguments []any
+
+// QueryRewriter rewrites a query when used as the first arguments to a query method or when set as
+// ConnConfig.QueryRewriter.
 type QueryRewriter interface {
 	RewriteQuery(ctx context.Context, conn *Conn, sql string, args []any) (newSQL string, newArgs []any, err error)
 }
 
+// rewriteQuery applies queryRewriter, if any, and then ConnConfig.QueryRewriter to sql and args. ConnConfig.QueryRewriter
+// is skipped for internal queries and when sql is the name of a prepared statement.
+func (c *Conn) rewriteQuery(ctx context.Context, queryRewriter QueryRewriter, sql string, args []any) (string, []any, error) {
+	for i, qr := range []QueryRewriter{queryRewriter, c.config.QueryRewriter} {
+		if qr == nil {
+			continue
+		}
+
+		if i == 1 {
+			if _, ok := c.preparedStatements[sql]; ok || c.internalQueries {
+				continue
+			}
+		}
+
+		var err error
+		sql, args, err = qr.RewriteQuery(ctx, c, sql, args)
+		if err != nil {
+			return "", nil, fmt.Errorf("rewrite query failed: %v", err)
+		}
+	}
+
+	return sql, args, nil
+}
+
+// rewriteStatementSQL applies ConnConfig.QueryRewriter to sql that takes no arguments, such as the SQL of Prepare and
+// the statements generated by CopyFrom.
+func (c *Conn) rewriteStatementSQL(ctx context.Context, sql string) (string, error) {
+	if c.config.QueryRewriter == nil || c.internalQueries {
+		return sql, nil
+	}
+
+	newSQL, newArgs, err := c.config.QueryRewriter.RewriteQuery(ctx, c, sql, nil)
+	if err != nil {
+		return "", fmt.Errorf("rewrite query failed: %v", err)
+	}
+	if len(newArgs) > 0 {
+		return "", fmt.Errorf("rewrite query failed: QueryRewriter returned %d arguments for a statement without arguments", len(newArgs))
+	}
+
+	return newSQL, nil
+}
+
+// internalQuery disables ConnConfig.QueryRewriter for the queries pgx sends on its own behalf until the returned
+// function is called.
+func (c *Conn) internalQuery() (restore func()) {
+	prev := c.internalQueries
+	c.internalQueries = true
+	return func() { c.internalQueries = prev }
+}
+
 // Query sends a query to the server and returns a Rows to read the results. Only errors encountered sending the query
 // and initializing Rows will be returned. Err() on the returned Rows must be checked after the Rows is closed to
 // determine if the query executed successfully.
@@ -688,10 +1233,19 @@ type QueryRewriter interface {
 //
 // For extra control over how the query is executed, the types QueryExecMode, QueryResultFormats, and
 // QueryResultFormatsByOID may be used as the first args to control exactly how the query is executed. This is rarely
-// needed. See the documentation for those types for details.
+// needed. See the documentation for those types for details. For example, passing QueryExecModeDescribeExec or
+// QueryExecModeExec bypasses the statement cache for a single query.
+//
+// A QueryStatementTimeout may be used as one of the first args to set the statement_timeout of a single query.
 func (c *Conn) Query(ctx context.Context, sql string, args ...any) (Rows, error) {
 	if c.queryTracer != nil {
-		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: sql, Args: args})
+		traceArgs := args
+		if len(args) == 1 {
+			if arg, ok := args[0].(queryArguments); ok {
+				traceArgs = arg
+			}
+		}
+		ctx = c.queryTracer.TraceQueryStart(ctx, c, TraceQueryStartData{SQL: sql, Args: traceArgs})
 	}
 
 	if err := c.deallocateInvalidatedCachedStatements(ctx); err != nil {
@@ -704,6 +1258,7 @@ func (c *Conn) Query(ctx context.Context, sql string, args ...any) (Rows, error)
 	var resultFormats QueryResultFormats
 	var resultFormatsByOID QueryResultFormatsByOID
 	mode := c.config.DefaultQueryExecMode
+	statementTimeout := c.config.DefaultStatementTimeout
 	var queryRewriter QueryRewriter
 
 optionLoop:
@@ -718,22 +1273,27 @@ optionLoop:
 		case QueryExecMode:
 			mode = arg
 			args = args[1:]
+		case QueryStatementTimeout:
+			statementTimeout = time.Duration(arg)
+			args = args[1:]
 		case QueryRewriter:
 			queryRewriter = arg
 			args = args[1:]
+		case queryArguments:
+			args = arg
+			break optionLoop
 		default:
 			break optionLoop
 		}
 	}
 
-	if queryRewriter != nil {
+	if queryRewriter != nil || c.config.QueryRewriter != nil {
 		var err error
 		originalSQL := sql
 		originalArgs := args
-		sql, args, err = queryRewriter.RewriteQuery(ctx, c, sql, args)
+		sql, args, err = c.rewriteQuery(ctx, queryRewriter, sql, args)
 		if err != nil {
 			rows := c.getRows(ctx, originalSQL, originalArgs)
-			err = fmt.Errorf("rewrite query failed: %v", err)
 			rows.fatal(err)
 			return rows, err
 		}
@@ -750,6 +1310,9 @@ optionLoop:
 
 	var err error
 	sd, explicitPreparedStatement := c.preparedStatements[sql]
+	if sd == nil && mode == QueryExecModeCacheStatement {
+		mode = c.cacheStatementQueryExecMode(sql)
+	}
 	if sd != nil || mode == QueryExecModeCacheStatement || mode == QueryExecModeCacheDescribe || mode == QueryExecModeDescribeExec {
 		if sd == nil {
 			sd, err = c.getStatementDescription(ctx, mode, sql)
@@ -783,7 +1346,19 @@ optionLoop:
 			resultFormats = c.eqb.ResultFormats
 		}
 
-		if !explicitPreparedStatement && mode == QueryExecModeCacheDescribe {
+		if statementTimeout > 0 {
+			rows.pipeline, rows.resultReader, err = c.queryWithStatementTimeout(ctx, statementTimeout, func(pipeline *pgconn.Pipeline) {
+				if !explicitPreparedStatement && mode == QueryExecModeCacheDescribe {
+					pipeline.SendQueryParams(sql, c.eqb.ParamValues, sd.ParamOIDs, c.eqb.ParamFormats, resultFormats)
+				} else {
+					pipeline.SendQueryPrepared(sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, resultFormats)
+				}
+			})
+			if err != nil {
+				rows.fatal(err)
+				return rows, err
+			}
+		} else if !explicitPreparedStatement && mode == QueryExecModeCacheDescribe {
 			rows.resultReader = c.pgConn.ExecParams(ctx, sql, c.eqb.ParamValues, sd.ParamOIDs, c.eqb.ParamFormats, resultFormats)
 		} else {
 			rows.resultReader = c.pgConn.ExecPrepared(ctx, sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, resultFormats)
@@ -795,7 +1370,17 @@ optionLoop:
 			return rows, rows.err
 		}
 
-		rows.resultReader = c.pgConn.ExecParams(ctx, sql, c.eqb.ParamValues, nil, c.eqb.ParamFormats, c.eqb.ResultFormats)
+		if statementTimeout > 0 {
+			rows.pipeline, rows.resultReader, err = c.queryWithStatementTimeout(ctx, statementTimeout, func(pipeline *pgconn.Pipeline) {
+				pipeline.SendQueryParams(sql, c.eqb.ParamValues, nil, c.eqb.ParamFormats, c.eqb.ResultFormats)
+			})
+			if err != nil {
+				rows.fatal(err)
+				return rows, err
+			}
+		} else {
+			rows.resultReader = c.pgConn.ExecParams(ctx, sql, c.eqb.ParamValues, nil, c.eqb.ParamFormats, c.eqb.ResultFormats)
+		}
 	} else if mode == QueryExecModeSimpleProtocol {
 		sql, err = c.sanitizeForSimpleQuery(sql, args...)
 		if err != nil {
@@ -803,7 +1388,15 @@ optionLoop:
 			return rows, err
 		}
 
+		if statementTimeout > 0 {
+			sql = statementTimeoutSimpleProtocolSQL(statementTimeout, sql)
+		}
+
 		mrr := c.pgConn.Exec(ctx, sql)
+		// Skip the result of setting statement_timeout.
+		if statementTimeout > 0 && mrr.NextResult() {
+			mrr.ResultReader().Close()
+		}
 		if mrr.NextResult() {
 			rows.resultReader = mrr.ResultReader()
 			rows.multiResultReader = mrr
@@ -825,6 +1418,37 @@ optionLoop:
 	return rows, rows.err
 }
 
+// cacheStatementQueryExecMode returns the mode to execute sql with when QueryExecModeCacheStatement is requested. It
+// counts the executions of sql and returns QueryExecModeCacheStatement once sql is in the statement cache or has been
+// executed StatementCachePrepareThreshold times.
+func (c *Conn) cacheStatementQueryExecMode(sql string) QueryExecMode {
+	if c.queryExecCounts == nil || c.statementCache == nil || c.statementCache.Get(sql) != nil {
+		return QueryExecModeCacheStatement
+	}
+
+	count := c.queryExecCounts[sql] + 1
+	if count >= c.config.StatementCachePrepareThreshold {
+		delete(c.queryExecCounts, sql)
+		return QueryExecModeCacheStatement
+	}
+
+	// Bound the memory used by a long tail of unique queries by evicting an arbitrary entry. Only that query loses its
+	// count, and a query that is executed frequently reaches the threshold and leaves the map before it is likely to be
+	// picked.
+	if count == 1 && len(c.queryExecCounts) >= c.statementCache.Cap() {
+		for evict := range c.queryExecCounts {
+			delete(c.queryExecCounts, evict)
+			break
+		}
+	}
+	c.queryExecCounts[sql] = count
+
+	if c.descriptionCache != nil {
+		return QueryExecModeCacheDescribe
+	}
+	return QueryExecModeDescribeExec
+}
+
 // getStatementDescription returns the statement description of the sql query
 // according to the given mode.
 //
@@ -843,7 +1467,7 @@ func (c *Conn) getStatementDescription(
 		}
 		sd = c.statementCache.Get(sql)
 		if sd == nil {
-			sd, err = c.Prepare(ctx, stmtcache.StatementName(sql), sql)
+			sd, err = c.prepare(ctx, stmtcache.StatementName(sql), sql, false)
 			if err != nil {
 				return nil, err
 			}
@@ -855,14 +1479,14 @@ func (c *Conn) getStatementDescription(
 		}
 		sd = c.descriptionCache.Get(sql)
 		if sd == nil {
-			sd, err = c.Prepare(ctx, "", sql)
+			sd, err = c.prepare(ctx, "", sql, false)
 			if err != nil {
 				return nil, err
 			}
 			c.descriptionCache.Put(sd)
 		}
 	case QueryExecModeDescribeExec:
-		return c.Prepare(ctx, "", sql)
+		return c.prepare(ctx, "", sql, false)
 	}
 	return sd, err
 }
@@ -911,18 +1535,20 @@ func (c *Conn) SendBatch(ctx context.Context, b *Batch) (br BatchResults) {
 			}
 		}
 
-		if queryRewriter != nil {
-			var err error
-			sql, arguments, err = queryRewriter.RewriteQuery(ctx, c, sql, arguments)
-			if err != nil {
-				return &batchResults{ctx: ctx, conn: c, err: fmt.Errorf("rewrite query failed: %v", err)}
-			}
+		var err error
+		sql, arguments, err = c.rewriteQuery(ctx, queryRewriter, sql, arguments)
+		if err != nil {
+			return &batchResults{ctx: ctx, conn: c, err: err}
 		}
 
 		bi.query = sql
 		bi.arguments = arguments
 	}
 
+	if b.IndependentQueries && (mode == QueryExecModeSimpleProtocol || mode == QueryExecModeExec) {
+		return &batchResults{ctx: ctx, conn: c, err: fmt.Errorf("Batch.IndependentQueries is not supported with %v", mode)}
+	}
+
 	if mode == QueryExecModeSimpleProtocol {
 		return c.sendBatchQueryExecModeSimpleProtocol(ctx, b)
 	}
@@ -1100,6 +1726,9 @@ func (c *Conn) sendBatchExtendedWithDescription(ctx context.Context, b *Batch, d
 	// Prepare any needed queries
 	if len(distinctNewQueries) > 0 {
 		for _, sd := range distinctNewQueries {
+			if c.closeBeforePrepare(sd.Name) {
+				pipeline.SendDeallocate(sd.Name)
+			}
 			pipeline.SendPrepare(sd.Name, sd.SQL, nil)
 		}
 
@@ -1109,6 +1738,17 @@ func (c *Conn) sendBatchExtendedWithDescription(ctx context.Context, b *Batch, d
 		}
 
 		for _, sd := range distinctNewQueries {
+			if c.closeBeforePrepare(sd.Name) {
+				results, err := pipeline.GetResults()
+				if err != nil {
+					return &pipelineBatchResults{ctx: ctx, conn: c, err: err, closed: true}
+				}
+
+				if _, ok := results.(*pgconn.CloseComplete); !ok {
+					return &pipelineBatchResults{ctx: ctx, conn: c, err: fmt.Errorf("expected close complete, got %T", results), closed: true}
+				}
+			}
+
 			results, err := pipeline.GetResults()
 			if err != nil {
 				return &pipelineBatchResults{ctx: ctx, conn: c, err: err, closed: true}
@@ -1136,12 +1776,21 @@ func (c *Conn) sendBatchExtendedWithDescription(ctx context.Context, b *Batch, d
 	}
 
 	// Put all statements into the cache. It's fine if it overflows because HandleInvalidated will clean them up later.
+	// This happens before looking up domain types because the statements are already prepared on the server.
 	if sdCache != nil {
 		for _, sd := range distinctNewQueries {
 			sdCache.Put(sd)
 		}
 	}
 
+	// Register the domain types used by the new statements before their arguments are encoded.
+	if unknownOIDs := c.unknownTypeOIDs(distinctNewQueries...); len(unknownOIDs) > 0 {
+		err := c.registerDomainTypesInPipeline(pipeline, unknownOIDs)
+		if err != nil {
+			return &pipelineBatchResults{ctx: ctx, conn: c, err: err, closed: true}
+		}
+	}
+
 	// Queue the queries.
 	for _, bi := range b.queuedQueries {
 		err := c.eqb.Build(c.typeMap, bi.sd, bi.arguments)
@@ -1156,19 +1805,60 @@ func (c *Conn) sendBatchExtendedWithDescription(ctx context.Context, b *Batch, d
 		} else {
 			pipeline.SendQueryPrepared(bi.sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, c.eqb.ResultFormats)
 		}
+
+		// A sync after each query makes each query its own implicit transaction. An error in one query does not cause
+		// the server to skip the following queries.
+		if b.IndependentQueries {
+			err := pipeline.Sync()
+			if err != nil {
+				return &pipelineBatchResults{ctx: ctx, conn: c, err: err, closed: true}
+			}
+		}
 	}
 
+	if !b.IndependentQueries {
+		err := pipeline.Sync()
+		if err != nil {
+			return &pipelineBatchResults{ctx: ctx, conn: c, err: err, closed: true}
+		}
+	}
+
+	return &pipelineBatchResults{
+		ctx:           ctx,
+		conn:          c,
+		pipeline:      pipeline,
+		b:             b,
+		syncEachQuery: b.IndependentQueries,
+	}
+}
+
+// registerDomainTypesInPipeline is the pipeline version of registerUnknownDomainTypes. It must be called when pipeline
+// has no pending results.
+func (c *Conn) registerDomainTypesInPipeline(pipeline *pgconn.Pipeline, oids []uint32) error {
+	pipeline.SendQueryParams(lookupDomainTypesSQL, lookupDomainTypesParams(oids), nil, nil, nil)
 	err := pipeline.Sync()
 	if err != nil {
-		return &pipelineBatchResults{ctx: ctx, conn: c, err: err, closed: true}
+		return err
 	}
 
-	return &pipelineBatchResults{
-		ctx:      ctx,
-		conn:     c,
-		pipeline: pipeline,
-		b:        b,
+	rr, err := nextPipelineResultReader(pipeline)
+	if err != nil {
+		return err
+	}
+	result := rr.Read()
+	if result.Err != nil {
+		return result.Err
 	}
+
+	results, err := pipeline.GetResults()
+	if err != nil {
+		return err
+	}
+	if _, ok := results.(*pgconn.PipelineSync); !ok {
+		return fmt.Errorf("expected sync, got %T", results)
+	}
+
+	return c.registerDomainTypes(oids, result.Rows)
 }
 
 func (c *Conn) sanitizeForSimpleQuery(sql string, args ...any) (string, error) {
@@ -1192,8 +1882,11 @@ func (c *Conn) sanitizeForSimpleQuery(sql string, args ...any) (string, error) {
 	return sanitize.SanitizeSQL(sql, valueArgs...)
 }
 
-// LoadType inspects the database for typeName and produces a pgtype.Type suitable for registration.
+// LoadType inspects the database for typeName and produces a pgtype.Type suitable for registration. If typeName is an
+// array type and its element type is not registered then the element type is also loaded and registered.
 func (c *Conn) LoadType(ctx context.Context, typeName string) (*pgtype.Type, error) {
+	defer c.internalQuery()()
+
 	var oid uint32
 
 	err := c.QueryRow(ctx, "select $1::text::regtype::oid;", typeName).Scan(&oid)
@@ -1211,17 +1904,20 @@ func (c *Conn) LoadType(ctx context.Context, typeName string) (*pgtype.Type, err
 
 	switch typtype {
 	case "b": // array
-		elementOID, err := c.getArrayElementOID(ctx, oid)
+		elementOID, delimiter, err := c.getArrayElement(ctx, oid)
 		if err != nil {
 			return nil, err
 		}
 
 		dt, ok := c.TypeMap().TypeForOID(elementOID)
 		if !ok {
-			return nil, errors.New("array element OID not registered")
+			dt, err = c.loadArrayElementType(ctx, elementOID)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		return &pgtype.Type{Name: typeName, OID: oid, Codec: &pgtype.ArrayCodec{ElementType: dt}}, nil
+		return &pgtype.Type{Name: typeName, OID: oid, Codec: &pgtype.ArrayCodec{ElementType: dt, Delimiter: delimiter}}, nil
 	case "c": // composite
 		fields, err := c.getCompositeFields(ctx, oid)
 		if err != nil {
@@ -1267,15 +1963,257 @@ func (c *Conn) LoadType(ctx context.Context, typeName string) (*pgtype.Type, err
 	}
 }
 
-func (c *Conn) getArrayElementOID(ctx context.Context, oid uint32) (uint32, error) {
+// loadArrayElementType loads and registers the element type of an array type.
+func (c *Conn) loadArrayElementType(ctx context.Context, elementOID uint32) (*pgtype.Type, error) {
+	var elementTypeName string
+	err := c.QueryRow(ctx, "select $1::oid::regtype::text", elementOID).Scan(&elementTypeName)
+	if err != nil {
+		return nil, err
+	}
+
+	dt, err := c.LoadType(ctx, elementTypeName)
+	if err != nil {
+		return nil, fmt.Errorf("array element type %s not registered and could not be loaded: %w", elementTypeName, err)
+	}
+	c.TypeMap().RegisterType(dt)
+
+	return dt, nil
+}
+
+// loadTypesSQL finds the types named by $1, their array types, and recursively the types they depend on. Each type is
+// returned once along with the names it was requested by. Dependencies are returned before the types that depend on
+// them. %s is replaced by the multirange element type expression because pg_range.rngmultitypid only exists in
+// PostgreSQL 14 and later.
+const loadTypesSQL = `with recursive requested as (
+	select n::regtype::oid as oid, n as name
+	from unnest($1::text[]) as n
+), deps(oid, depth) as (
+	select oid, 0 from requested
+	union all
+	select typarray, 0 from pg_type where oid in (select oid from requested) and typarray <> 0
+	union all
+	select dep.oid, deps.depth + 1
+	from deps
+		join pg_type t on t.oid = deps.oid
+		cross join lateral (
+			select t.typelem where t.typtype = 'b' and t.typelem <> 0
+			union all
+			select t.typbasetype where t.typtype = 'd'
+			union all
+			select rngsubtype from pg_range where t.typtype = 'r' and rngtypid = t.oid
+			union all
+			select %[1]s where t.typtype = 'm'
+			union all
+			select atttypid from pg_attribute where t.typtype = 'c' and attrelid = t.typrelid and not attisdropped and attnum > 0
+		) as dep(oid)
+)
+select t.oid,
+	case when t.typcategory = 'A' then t.typname::text else t.oid::regtype::text end,
+	t.typtype::text,
+	t.typbasetype,
+	t.typelem,
+	coalesce((select typdelim from pg_type e where e.oid = t.typelem), ','),
+	coalesce((select rngsubtype from pg_range where rngtypid = t.oid), 0::oid),
+	case when t.typtype = 'm' then %[1]s else 0::oid end,
+	coalesce((select array_agg(attname::text order by attnum) from pg_attribute where attrelid = t.typrelid and not attisdropped and attnum > 0), '{}'),
+	coalesce((select array_agg(atttypid order by attnum) from pg_attribute where attrelid = t.typrelid and not attisdropped and attnum > 0), '{}'),
+	coalesce((select array_agg(name) from requested where requested.oid = t.oid), '{}')
+from (select oid, max(depth) as depth from deps group by oid) as d
+	join pg_type t on t.oid = d.oid
+order by d.depth desc, t.oid`
+
+// LoadTypes inspects the database for typeNames and registers them along with their array types and any types they
+// depend on that are not already registered such as the element type of an array or the field types of a composite.
+// Unlike calling LoadType for each name, all the types are found with a single query. The types registered for
+// typeNames are returned in the same order.
+func (c *Conn) LoadTypes(ctx context.Context, typeNames []string) ([]*pgtype.Type, error) {
+	defer c.internalQuery()()
+
+	if len(typeNames) == 0 {
+		return nil, nil
+	}
+
+	multirangeElementSQL := "0::oid"
+	if serverMajorVersion(c.pgConn) >= 14 {
+		multirangeElementSQL = "(select rngtypid from pg_range where rngmultitypid = t.oid)"
+	}
+
+	var (
+		oid            uint32
+		name           string
+		typtype        string
+		typbasetype    uint32
+		typelem        uint32
+		typdelim       byte
+		rangeElemOID   uint32
+		multirangeOID  uint32
+		fieldNames     []string
+		fieldOIDs      []uint32
+		requestedNames []string
+	)
+
+	m := c.TypeMap()
+	loaded := make(map[string]*pgtype.Type, len(typeNames))
+	rows, _ := c.Query(ctx, fmt.Sprintf(loadTypesSQL, multirangeElementSQL), typeNames)
+	_, err := ForEachRow(rows, []any{&oid, &name, &typtype, &typbasetype, &typelem, &typdelim, &rangeElemOID, &multirangeOID, &fieldNames, &fieldOIDs, &requestedNames}, func() error {
+		if _, ok := m.TypeForOID(oid); ok && len(requestedNames) == 0 {
+			return nil
+		}
+
+		codec, err := loadTypesCodec(m, name, typtype, typbasetype, typelem, typdelim, rangeElemOID, multirangeOID, fieldNames, fieldOIDs)
+		if err != nil {
+			return err
+		}
+
+		if len(requestedNames) == 0 {
+			requestedNames = []string{name}
+		}
+		for _, n := range requestedNames {
+			dt := &pgtype.Type{Name: n, OID: oid, Codec: codec}
+			m.RegisterType(dt)
+			loaded[n] = dt
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	types := make([]*pgtype.Type, len(typeNames))
+	for i, n := range typeNames {
+		types[i] = loaded[n]
+	}
+
+	return types, nil
+}
+
+// loadTypesCodec builds the codec for a type found by LoadTypes. The types it depends on must already be registered
+// in m.
+func loadTypesCodec(m *pgtype.Map, name, typtype string, typbasetype, typelem uint32, typdelim byte, rangeElemOID, multirangeOID uint32, fieldNames []string, fieldOIDs []uint32) (pgtype.Codec, error) {
+	dependency := func(oid uint32, kind string) (*pgtype.Type, error) {
+		dt, ok := m.TypeForOID(oid)
+		if !ok {
+			return nil, fmt.Errorf("%s %s OID %d not registered", name, kind, oid)
+		}
+		return dt, nil
+	}
+
+	switch typtype {
+	case "b": // array
+		if typelem == 0 {
+			return nil, fmt.Errorf("%s is not an array type and is not registered", name)
+		}
+		dt, err := dependency(typelem, "array element")
+		if err != nil {
+			return nil, err
+		}
+		return &pgtype.ArrayCodec{ElementType: dt, Delimiter: typdelim}, nil
+	case "c": // composite
+		fields := make([]pgtype.CompositeCodecField, len(fieldOIDs))
+		for i, fieldOID := range fieldOIDs {
+			dt, err := dependency(fieldOID, "composite field")
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = pgtype.CompositeCodecField{Name: fieldNames[i], Type: dt}
+		}
+		return &pgtype.CompositeCodec{Fields: fields}, nil
+	case "d": // domain
+		dt, err := dependency(typbasetype, "domain base type")
+		if err != nil {
+			return nil, err
+		}
+		return dt.Codec, nil
+	case "e": // enum
+		return &pgtype.EnumCodec{}, nil
+	case "r": // range
+		dt, err := dependency(rangeElemOID, "range element")
+		if err != nil {
+			return nil, err
+		}
+		return &pgtype.RangeCodec{ElementType: dt}, nil
+	case "m": // multirange
+		dt, err := dependency(multirangeOID, "multirange element")
+		if err != nil {
+			return nil, err
+		}
+		return &pgtype.MultirangeCodec{ElementType: dt}, nil
+	default:
+		return nil, fmt.Errorf("%s has unknown typtype %s", name, typtype)
+	}
+}
+
+// serverMajorVersion returns the major version of the PostgreSQL server pgConn is connected to or 0 if it cannot be
+// determined.
+func serverMajorVersion(pgConn *pgconn.PgConn) int {
+	version := pgConn.ParameterStatus("server_version")
+	end := strings.IndexFunc(version, func(r rune) bool { return r < '0' || r > '9' })
+	if end >= 0 {
+		version = version[:end]
+	}
+
+	n, _ := strconv.Atoi(version)
+	return n
+}
+
+// RegisterEnum inspects the database for the enum typeName and registers it and its array type. The OID and labels are
+// read from pg_type and pg_enum so they do not need to be known in advance. Values are checked against the labels when
+// encoding. Each of goTypes is registered as a default Go type for the enum so it can be used as a query argument without
+// an explicit cast. goTypes are typically named string types such as
+//
+//	type Mood string
+//
+//	err := conn.RegisterEnum(ctx, "mood", Mood(""))
+//
+// If labels are later added to the enum RegisterEnum must be called again for the new labels to be accepted.
+func (c *Conn) RegisterEnum(ctx context.Context, typeName string, goTypes ...any) error {
+	var oid, arrayOID uint32
+	var typtype string
+	err := c.QueryRow(ctx, "select oid, typarray, typtype::text from pg_type where oid=$1::text::regtype::oid", typeName).Scan(&oid, &arrayOID, &typtype)
+	if err != nil {
+		return err
+	}
+	if typtype != "e" {
+		return fmt.Errorf("%s is not an enum type", typeName)
+	}
+
+	var labels []string
+	err = c.QueryRow(ctx, "select coalesce(array_agg(enumlabel order by enumsortorder), '{}') from pg_enum where enumtypid=$1", oid).Scan(&labels)
+	if err != nil {
+		return err
+	}
+
+	dt := &pgtype.Type{Name: typeName, OID: oid, Codec: &pgtype.EnumCodec{Labels: labels}}
+	c.TypeMap().RegisterType(dt)
+
+	if arrayOID != 0 {
+		var arrayTypeName string
+		err = c.QueryRow(ctx, "select typname::text from pg_type where oid=$1", arrayOID).Scan(&arrayTypeName)
+		if err != nil {
+			return err
+		}
+		c.TypeMap().RegisterType(&pgtype.Type{Name: arrayTypeName, OID: arrayOID, Codec: &pgtype.ArrayCodec{ElementType: dt}})
+	}
+
+	for _, goType := range goTypes {
+		c.TypeMap().RegisterDefaultPgType(goType, typeName)
+	}
+
+	return nil
+}
+
+// getArrayElement returns the element type OID of the array type oid and the delimiter of the element type.
+func (c *Conn) getArrayElement(ctx context.Context, oid uint32) (uint32, byte, error) {
 	var typelem uint32
+	var typdelim byte
 
-	err := c.QueryRow(ctx, "select typelem from pg_type where oid=$1", oid).Scan(&typelem)
+	err := c.QueryRow(ctx, "select t.typelem, e.typdelim from pg_type t join pg_type e on e.oid = t.typelem where t.oid=$1", oid).Scan(&typelem, &typdelim)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
-	return typelem, nil
+	return typelem, typdelim, nil
 }
 
 func (c *Conn) getRangeElementOID(ctx context.Context, oid uint32) (uint32, error) {
@@ -1352,6 +2290,13 @@ func (c *Conn) deallocateInvalidatedCachedStatements(ctx context.Context) error
 		return nil
 	}
 
+	if c.config.StatementCacheSkipDeallocate {
+		for _, sd := range invalidatedStatements {
+			delete(c.preparedStatements, sd.Name)
+		}
+		return nil
+	}
+
 	pipeline := c.pgConn.StartPipeline(ctx)
 	defer pipeline.Close()
 
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5/internal/stmtcache"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -53,3 +57,148 @@ func TestStmtCacheSizeLimit(t *testing.T) {
 	assert.Len(t, conn.preparedStatements, cacheLimit+1)
 	assert.Equal(t, cacheLimit, conn.statementCache.Len())
 }
+
+// This test examines the internals of *Conn so must be in the same package.
+func TestConnectPrepareStatements(t *testing.T) {
+	statements := []string{"select $1::int4", "select $1::text, $2::int8"}
+
+	connConfig := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	connConfig.PrepareStatements = statements
+	conn := mustConnect(t, connConfig)
+	defer conn.Close(context.Background())
+
+	for _, sql := range statements {
+		require.NotNil(t, conn.statementCache.Get(sql), sql)
+	}
+
+	var n int32
+	err := conn.QueryRow(context.Background(), statements[0], 42).Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, n)
+	assert.Len(t, conn.preparedStatements, len(statements))
+
+	connConfig = mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	connConfig.StatementCacheCapacity = 0
+	connConfig.PrepareStatements = statements
+	conn = mustConnect(t, connConfig)
+	defer conn.Close(context.Background())
+
+	for _, sql := range statements {
+		require.NotNil(t, conn.descriptionCache.Get(sql), sql)
+	}
+	assert.Empty(t, conn.preparedStatements)
+
+	connConfig = mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	connConfig.PrepareStatements = []string{"selec 1"}
+	_, err = ConnectConfig(context.Background(), connConfig)
+	require.ErrorContains(t, err, `failed to prepare statement "selec 1"`)
+}
+
+// Ensures a QueryExecMode passed as the first argument bypasses the statement cache for that query only.
+// This test examines the internals of *Conn so must be in the same package.
+func TestStmtCacheBypassPerQuery(t *testing.T) {
+	connConfig := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	connConfig.DefaultQueryExecMode = QueryExecModeCacheStatement
+	conn := mustConnect(t, connConfig)
+	defer func() {
+		err := conn.Close(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	for _, mode := range []QueryExecMode{QueryExecModeDescribeExec, QueryExecModeExec, QueryExecModeSimpleProtocol} {
+		var n int32
+		err := conn.QueryRow(ctx, "select $1::int4", mode, 42).Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 42, n)
+
+		_, err = conn.Exec(ctx, "select $1::int4", mode, 42)
+		require.NoError(t, err)
+	}
+	assert.Empty(t, conn.preparedStatements)
+	assert.Equal(t, 0, conn.statementCache.Len())
+
+	var n int32
+	err := conn.QueryRow(ctx, "select $1::int4", 42).Scan(&n)
+	require.NoError(t, err)
+	assert.Equal(t, 1, conn.statementCache.Len())
+}
+
+func TestStatementTimeoutMilliseconds(t *testing.T) {
+	assert.Equal(t, "1", statementTimeoutMilliseconds(time.Microsecond))
+	assert.Equal(t, "1", statementTimeoutMilliseconds(time.Millisecond))
+	assert.Equal(t, "1500", statementTimeoutMilliseconds(1500*time.Millisecond))
+	assert.Equal(t, "2000", statementTimeoutMilliseconds(1999500*time.Microsecond))
+}
+
+func TestCacheStatementQueryExecModeEvictsSingleCount(t *testing.T) {
+	c := &Conn{
+		config:          &ConnConfig{StatementCachePrepareThreshold: 3},
+		statementCache:  stmtcache.NewLRUCache(2),
+		queryExecCounts: make(map[string]int),
+	}
+
+	assert.Equal(t, QueryExecModeDescribeExec, c.cacheStatementQueryExecMode("select 1"))
+	assert.Equal(t, QueryExecModeDescribeExec, c.cacheStatementQueryExecMode("select 1"))
+	assert.Equal(t, QueryExecModeDescribeExec, c.cacheStatementQueryExecMode("select 2"))
+
+	// The map is full so a single entry is evicted to make room for the new query.
+	assert.Equal(t, QueryExecModeDescribeExec, c.cacheStatementQueryExecMode("select 3"))
+	assert.Len(t, c.queryExecCounts, 2)
+	assert.Equal(t, 1, c.queryExecCounts["select 3"])
+
+	_, ok1 := c.queryExecCounts["select 1"]
+	_, ok2 := c.queryExecCounts["select 2"]
+	assert.True(t, ok1 != ok2, "exactly one of the earlier counts must remain")
+}
+
+func TestUnknownTypeOIDsRetriedUntilLookedUp(t *testing.T) {
+	c := &Conn{typeMap: pgtype.NewMap()}
+	sd := &pgconn.StatementDescription{
+		ParamOIDs: []uint32{pgtype.Int4OID, 100001},
+		Fields:    []pgconn.FieldDescription{{DataTypeOID: 100001}, {DataTypeOID: 100002}},
+	}
+
+	// A failed lookup does not record the OIDs so they are looked up again.
+	assert.Equal(t, []uint32{100001, 100002}, c.unknownTypeOIDs(sd))
+	assert.Equal(t, []uint32{100001, 100002}, c.unknownTypeOIDs(sd))
+
+	err := c.registerDomainTypes([]uint32{100001, 100002}, [][][]byte{{[]byte("100001"), []byte("mydomain"), []byte("23")}})
+	require.NoError(t, err)
+	assert.Empty(t, c.unknownTypeOIDs(sd))
+
+	dt, ok := c.typeMap.TypeForOID(100001)
+	require.True(t, ok)
+	assert.Equal(t, "mydomain", dt.Name)
+}
+
+func TestExtendedQueryBuilderTextFormatOnly(t *testing.T) {
+	sd := &pgconn.StatementDescription{
+		ParamOIDs: []uint32{pgtype.Int4OID, pgtype.ByteaOID},
+		Fields:    []pgconn.FieldDescription{{DataTypeOID: pgtype.Int4OID}, {DataTypeOID: pgtype.TimestamptzOID}},
+	}
+
+	var eqb ExtendedQueryBuilder
+	err := eqb.Build(pgtype.NewMap(), sd, []any{int32(1), []byte{1}})
+	require.NoError(t, err)
+	assert.Equal(t, []int16{BinaryFormatCode, BinaryFormatCode}, eqb.ParamFormats)
+	assert.Equal(t, []int16{BinaryFormatCode, BinaryFormatCode}, eqb.ResultFormats)
+
+	eqb = ExtendedQueryBuilder{textFormatOnly: true}
+	err = eqb.Build(pgtype.NewMap(), sd, []any{int32(1), []byte{1}})
+	require.NoError(t, err)
+	assert.Equal(t, []int16{TextFormatCode, TextFormatCode}, eqb.ParamFormats)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte(`\x01`)}, eqb.ParamValues)
+	assert.Equal(t, []int16{TextFormatCode, TextFormatCode}, eqb.ResultFormats)
+}
+
+func TestDefaultRetryBackoff(t *testing.T) {
+	for attempt, max := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 8: time.Second, 100: time.Second} {
+		d := defaultRetryBackoff(attempt)
+		assert.GreaterOrEqual(t, d, max/2, "attempt %d", attempt)
+		assert.Less(t, d, max, "attempt %d", attempt)
+	}
+}
@@ -3,6 +3,7 @@ package pgx_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
@@ -105,6 +106,30 @@ func TestConnectConfigRequiresConnConfigFromParseConfig(t *testing.T) {
 	})
 }
 
+func TestConnParameterStatus(t *testing.T) {
+	t.Parallel()
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	var timeZones []string
+	config.OnParameterStatus = func(_ *pgconn.PgConn, name, value string) {
+		if name == "TimeZone" {
+			timeZones = append(timeZones, value)
+		}
+	}
+
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	require.NotEmpty(t, conn.ParameterStatus("server_version"))
+	require.Empty(t, conn.ParameterStatus("no_such_parameter"))
+
+	_, err := conn.Exec(context.Background(), "set time zone 'Europe/Berlin'")
+	require.NoError(t, err)
+	require.Equal(t, "Europe/Berlin", conn.ParameterStatus("TimeZone"))
+	require.NotEmpty(t, timeZones)
+	require.Equal(t, "Europe/Berlin", timeZones[len(timeZones)-1])
+}
+
 func TestConfigContainsConnStr(t *testing.T) {
 	connStr := os.Getenv("PGX_TEST_DATABASE")
 	config, err := pgx.ParseConfig(connStr)
@@ -144,6 +169,17 @@ func TestParseConfigExtractsStatementCacheOptions(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, 42, config.StatementCacheCapacity)
 
+	config, err = pgx.ParseConfig("statement_cache_prepare_threshold=3")
+	require.NoError(t, err)
+	require.EqualValues(t, 3, config.StatementCachePrepareThreshold)
+
+	config, err = pgx.ParseConfig("statement_cache_skip_deallocate=true")
+	require.NoError(t, err)
+	require.True(t, config.StatementCacheSkipDeallocate)
+
+	_, err = pgx.ParseConfig("statement_cache_skip_deallocate=maybe")
+	require.ErrorContains(t, err, "cannot parse statement_cache_skip_deallocate")
+
 	config, err = pgx.ParseConfig("description_cache_capacity=0")
 	require.NoError(t, err)
 	require.EqualValues(t, 0, config.DescriptionCacheCapacity)
@@ -271,6 +307,136 @@ func TestExecWithQueryRewriter(t *testing.T) {
 	})
 }
 
+type schemaQueryRewriter struct {
+	schema string
+}
+
+func (qr schemaQueryRewriter) RewriteQuery(ctx context.Context, conn *pgx.Conn, sql string, args []any) (newSQL string, newArgs []any, err error) {
+	if strings.Contains(sql, "@") {
+		return "", nil, fmt.Errorf("named argument was not rewritten: %s", sql)
+	}
+	return strings.ReplaceAll(sql, "{{schema}}", qr.schema), args, nil
+}
+
+func TestConnConfigTextFormatOnly(t *testing.T) {
+	t.Parallel()
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.TextFormatOnly = true
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	rows, err := conn.Query(ctx, "select $1::int4, $2::timestamptz", int32(42), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+	for _, fd := range rows.FieldDescriptions() {
+		require.Equal(t, int16(pgx.TextFormatCode), fd.Format)
+	}
+
+	var n int32
+	var ts time.Time
+	for rows.Next() {
+		require.NoError(t, rows.Scan(&n, &ts))
+	}
+	require.NoError(t, rows.Err())
+	require.EqualValues(t, 42, n)
+	require.True(t, ts.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	ensureConnValid(t, conn)
+}
+
+func TestConnConfigQueryRewriter(t *testing.T) {
+	t.Parallel()
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.QueryRewriter = schemaQueryRewriter{schema: "pg_catalog"}
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	var n int64
+	err := conn.QueryRow(ctx, "select count(*) from {{schema}}.pg_type where oid = $1", pgtype.Int4OID).Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	// A QueryRewriter passed as an argument is applied first.
+	err = conn.QueryRow(ctx, "select count(*) from {{schema}}.pg_type where oid = @oid", pgx.NamedArgs{"oid": pgtype.Int4OID}).Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	_, err = conn.Exec(ctx, "select count(*) from {{schema}}.pg_type")
+	require.NoError(t, err)
+
+	batch := &pgx.Batch{}
+	batch.Queue("select count(*) from {{schema}}.pg_type where oid = $1", pgtype.Int4OID)
+	err = conn.SendBatch(ctx, batch).QueryRow().Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	ensureConnValid(t, conn)
+}
+
+type recordingQueryRewriter struct {
+	schemaQueryRewriter
+	queries []string
+}
+
+func (qr *recordingQueryRewriter) RewriteQuery(ctx context.Context, conn *pgx.Conn, sql string, args []any) (newSQL string, newArgs []any, err error) {
+	qr.queries = append(qr.queries, sql)
+	return qr.schemaQueryRewriter.RewriteQuery(ctx, conn, sql, args)
+}
+
+func TestConnConfigQueryRewriterOnlyRewritesUserSQL(t *testing.T) {
+	t.Parallel()
+
+	qr := &recordingQueryRewriter{schemaQueryRewriter: schemaQueryRewriter{schema: "pg_catalog"}}
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.QueryRewriter = qr
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	// The SQL of a prepared statement is rewritten but its name is not.
+	_, err := conn.Prepare(ctx, "{{schema}}_types", "select count(*) from {{schema}}.pg_type where oid = $1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"select count(*) from {{schema}}.pg_type where oid = $1"}, qr.queries)
+
+	var n int64
+	err = conn.QueryRow(ctx, "{{schema}}_types", pgtype.Int4OID).Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+	require.Len(t, qr.queries, 1)
+
+	// Transaction control statements and catalog queries are not rewritten.
+	tx, err := conn.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Begin(ctx)
+	require.NoError(t, err)
+	_, err = conn.LoadType(ctx, "int4range")
+	require.NoError(t, err)
+	err = tx.Commit(ctx)
+	require.NoError(t, err)
+	require.Len(t, qr.queries, 1)
+
+	_, err = conn.ExecMulti(ctx, "select count(*) from {{schema}}.pg_type; select 1")
+	require.NoError(t, err)
+	require.Len(t, qr.queries, 2)
+
+	mustExec(t, conn, "create temporary table query_rewriter_copy(n int4)")
+	qr.queries = nil
+	_, err = conn.CopyFrom(ctx, pgx.Identifier{"query_rewriter_copy"}, []string{"n"}, pgx.CopyFromRows([][]any{{1}}))
+	require.NoError(t, err)
+	require.Len(t, qr.queries, 2)
+
+	ensureConnValid(t, conn)
+}
+
 func TestExecFailure(t *testing.T) {
 	t.Parallel()
 
@@ -383,6 +549,107 @@ func TestExecFailureCloseBefore(t *testing.T) {
 	assert.True(t, pgconn.SafeToRetry(err))
 }
 
+func TestExecMulti(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	defaultConnTestRunner.RunTest(ctx, t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		commandTags, err := conn.ExecMulti(ctx, `create temporary table exec_multi(id int primary key);
+insert into exec_multi select n from generate_series(1, 3) n;
+update exec_multi set id = id + 10 where id > $1;
+delete from exec_multi`, 1)
+		require.NoError(t, err)
+		require.Len(t, commandTags, 4)
+		assert.Equal(t, "CREATE TABLE", commandTags[0].String())
+		assert.True(t, commandTags[1].Insert())
+		assert.EqualValues(t, 3, commandTags[1].RowsAffected())
+		assert.True(t, commandTags[2].Update())
+		assert.EqualValues(t, 2, commandTags[2].RowsAffected())
+		assert.True(t, commandTags[3].Delete())
+		assert.EqualValues(t, 3, commandTags[3].RowsAffected())
+
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestExecMultiFailure(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	defaultConnTestRunner.RunTest(ctx, t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		commandTags, err := conn.ExecMulti(ctx, "select 1; select 1/0; select 3")
+		require.Error(t, err)
+		require.Len(t, commandTags, 1)
+		assert.Equal(t, "SELECT 1", commandTags[0].String())
+
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestExecMultiQueryOptions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	defaultConnTestRunner.RunTest(ctx, t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support statement_timeout")
+
+		commandTags, err := conn.ExecMulti(ctx, "select 1; select 2", pgx.QueryStatementTimeout(time.Second))
+		require.NoError(t, err)
+		require.Len(t, commandTags, 2)
+
+		_, err = conn.ExecMulti(ctx, "select 1; select pg_sleep(5)", pgx.QueryStatementTimeout(50*time.Millisecond))
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "57014", pgErr.Code)
+
+		qr := testQueryRewriter{sql: "select $1::int; select $2::text", args: []any{1, "a"}}
+		commandTags, err = conn.ExecMulti(ctx, "something to be replaced", &qr)
+		require.NoError(t, err)
+		require.Len(t, commandTags, 2)
+
+		_, err = conn.ExecMulti(ctx, "select 1", pgx.QueryExecModeExec)
+		require.ErrorContains(t, err, "does not support QueryExecMode")
+
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestExecExpect(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	defaultConnTestRunner.RunTest(ctx, t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		mustExec(t, conn, "create temporary table foo(id int primary key, name text)")
+		mustExec(t, conn, "insert into foo(id, name) values (1, 'a'), (2, 'b')")
+
+		commandTag, err := pgx.ExecExpect(ctx, conn, 1, "update foo set name = $1 where id = $2", "c", 1)
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATE 1", commandTag.String())
+
+		commandTag, err = pgx.ExecExpect(ctx, conn, 1, "update foo set name = $1", "d")
+		require.ErrorIs(t, err, pgx.ErrUnexpectedRowsAffected)
+		assert.EqualError(t, err, "unexpected number of rows affected: expected 1, got 2")
+		assert.EqualValues(t, 2, commandTag.RowsAffected())
+
+		_, err = pgx.ExecExpect(ctx, conn, 1, "update foo set name = $1 where id = $2", "e", 3)
+		require.ErrorIs(t, err, pgx.ErrUnexpectedRowsAffected)
+
+		_, err = pgx.ExecExpect(ctx, conn, 1, "update missing_table set name = 'f'")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, pgx.ErrUnexpectedRowsAffected)
+
+		ensureConnValid(t, conn)
+	})
+}
+
 func TestExecPerQuerySimpleProtocol(t *testing.T) {
 	t.Parallel()
 
@@ -479,6 +746,33 @@ func TestPrepareBadSQLFailure(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+func TestPreparedStatement(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	require.Nil(t, conn.PreparedStatement("test"))
+
+	_, err := conn.Prepare(context.Background(), "test", "select $1::int4 as n, $2::text as s")
+	require.NoError(t, err)
+
+	sd := conn.PreparedStatement("test")
+	require.NotNil(t, sd)
+	require.Equal(t, []uint32{pgtype.Int4OID, pgtype.TextOID}, sd.ParamOIDs)
+	require.Len(t, sd.Fields, 2)
+	require.Equal(t, "n", sd.Fields[0].Name)
+	require.EqualValues(t, pgtype.Int4OID, sd.Fields[0].DataTypeOID)
+	require.Equal(t, "s", sd.Fields[1].Name)
+	require.EqualValues(t, pgtype.TextOID, sd.Fields[1].DataTypeOID)
+
+	err = conn.Deallocate(context.Background(), "test")
+	require.NoError(t, err)
+	require.Nil(t, conn.PreparedStatement("test"))
+
+	ensureConnValid(t, conn)
+}
+
 func TestPrepareIdempotency(t *testing.T) {
 	t.Parallel()
 
@@ -852,6 +1146,24 @@ func TestIdentifierSanitize(t *testing.T) {
 	}
 }
 
+func TestIdentifierSanitizeColumns(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `"foo", "bar"`, pgx.Identifier{}.SanitizeColumns([]string{"foo", "bar"}))
+	require.Equal(t, `"t"."foo", "t"."bar"`, pgx.Identifier{"t"}.SanitizeColumns([]string{"foo", "bar"}))
+	require.Equal(t, `"s"."t"."foo"`, pgx.Identifier{"s", "t"}.SanitizeColumns([]string{"foo"}))
+	require.Equal(t, `"you should "" not do this"`, pgx.Identifier(nil).SanitizeColumns([]string{`you should " not do this`}))
+	require.Equal(t, ``, pgx.Identifier{}.SanitizeColumns(nil))
+}
+
+func TestSanitizeIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, `"foo"."bar", "baz"`, pgx.SanitizeIdentifiers(pgx.Identifier{"foo", "bar"}, pgx.Identifier{"baz"}))
+	require.Equal(t, `"a""b"`, pgx.SanitizeIdentifiers(pgx.Identifier{`a"b`}))
+	require.Equal(t, ``, pgx.SanitizeIdentifiers())
+}
+
 func TestConnInitTypeMap(t *testing.T) {
 	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
 	defer closeConn(t, conn)
@@ -936,6 +1248,89 @@ func TestDomainType(t *testing.T) {
 	})
 }
 
+func TestDomainTypeRegisteredAutomatically(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does support domain types (https://github.com/cockroachdb/cockroach/issues/27796)")
+
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, `create domain pgx_positive_int as int check (value > 0);
+create domain pgx_small_positive_int as pgx_positive_int check (value < 100);
+create temporary table pgx_domain_test (a pgx_positive_int, b pgx_small_positive_int);`)
+		require.NoError(t, err)
+
+		_, err = tx.Exec(ctx, `insert into pgx_domain_test (a, b) values ($1, $2)`, int32(1000), int32(42))
+		require.NoError(t, err)
+
+		var a, b int32
+		err = tx.QueryRow(ctx, `select a, b from pgx_domain_test where a = $1::pgx_positive_int`, int32(1000)).Scan(&a, &b)
+		require.NoError(t, err)
+		require.EqualValues(t, 1000, a)
+		require.EqualValues(t, 42, b)
+
+		// Modes that describe statements register the domains with the codec of the base type.
+		switch conn.Config().DefaultQueryExecMode {
+		case pgx.QueryExecModeCacheStatement, pgx.QueryExecModeCacheDescribe, pgx.QueryExecModeDescribeExec:
+			for _, typeName := range []string{"pgx_positive_int", "pgx_small_positive_int"} {
+				var oid uint32
+				err = tx.QueryRow(ctx, `select $1::text::regtype::oid`, typeName).Scan(&oid)
+				require.NoError(t, err)
+
+				dt, ok := conn.TypeMap().TypeForOID(oid)
+				require.Truef(t, ok, "%s not registered", typeName)
+				require.Equal(t, typeName, dt.Name)
+				require.IsType(t, pgtype.Int4Codec{}, dt.Codec)
+			}
+		}
+	})
+}
+
+func TestDomainTypeRegisteredAutomaticallyInBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	modes := []pgx.QueryExecMode{pgx.QueryExecModeCacheStatement, pgx.QueryExecModeCacheDescribe, pgx.QueryExecModeDescribeExec}
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, modes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does support domain types (https://github.com/cockroachdb/cockroach/issues/27796)")
+
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, `create domain pgx_batch_positive_int as int check (value > 0)`)
+		require.NoError(t, err)
+
+		batch := &pgx.Batch{}
+		batch.Queue(`select $1::pgx_batch_positive_int`, int32(7))
+		batch.Queue(`select $1::pgx_batch_positive_int + 1`, int32(7))
+		br := tx.SendBatch(ctx, batch)
+
+		var n int32
+		err = br.QueryRow().Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 7, n)
+
+		err = br.QueryRow().Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 8, n)
+
+		err = br.Close()
+		require.NoError(t, err)
+
+		var oid uint32
+		err = tx.QueryRow(ctx, `select 'pgx_batch_positive_int'::regtype::oid`).Scan(&oid)
+		require.NoError(t, err)
+		dt, ok := conn.TypeMap().TypeForOID(oid)
+		require.True(t, ok)
+		require.IsType(t, pgtype.Int4Codec{}, dt.Codec)
+	})
+}
+
 func TestLoadTypeSameNameInDifferentSchemas(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
@@ -1003,6 +1398,140 @@ func TestLoadCompositeType(t *testing.T) {
 	})
 }
 
+func TestLoadCompositeArrayType(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does support composite types (https://github.com/cockroachdb/cockroach/issues/27792)")
+
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, "create type address as (street text, city text)")
+		require.NoError(t, err)
+
+		// The address element type is loaded and registered automatically.
+		arrayType, err := conn.LoadType(ctx, "_address")
+		require.NoError(t, err)
+		conn.TypeMap().RegisterType(arrayType)
+
+		_, ok := conn.TypeMap().TypeForName("address")
+		require.True(t, ok)
+
+		type Address struct {
+			Street string
+			City   string
+		}
+
+		var addresses []Address
+		err = tx.QueryRow(ctx, "select array[row('1 Main St', 'Springfield'), row('2 Elm St', 'Shelbyville')]::address[]").Scan(&addresses)
+		require.NoError(t, err)
+		require.Equal(t, []Address{{"1 Main St", "Springfield"}, {"2 Elm St", "Shelbyville"}}, addresses)
+
+		var addressPtrs []*Address
+		err = tx.QueryRow(ctx, "select array[row('1 Main St', 'Springfield'), null]::address[]").Scan(&addressPtrs)
+		require.NoError(t, err)
+		require.Equal(t, []*Address{{"1 Main St", "Springfield"}, nil}, addressPtrs)
+
+		err = tx.QueryRow(ctx, "select $1::address[]", addresses).Scan(&addresses)
+		require.NoError(t, err)
+		require.Equal(t, []Address{{"1 Main St", "Springfield"}, {"2 Elm St", "Shelbyville"}}, addresses)
+	})
+}
+
+func TestLoadTypes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does support composite types (https://github.com/cockroachdb/cockroach/issues/27792)")
+
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, `create type load_types_mood as enum ('sad', 'happy');
+create domain load_types_score as int4 check (value between 0 and 10);
+create type load_types_review as (mood load_types_mood, scores load_types_score[]);`)
+		require.NoError(t, err)
+
+		types, err := conn.LoadTypes(ctx, []string{"load_types_review", "load_types_mood"})
+		require.NoError(t, err)
+		require.Len(t, types, 2)
+		require.Equal(t, "load_types_review", types[0].Name)
+		require.Equal(t, "load_types_mood", types[1].Name)
+
+		// Dependencies and array types are registered too.
+		for _, name := range []string{"load_types_review", "_load_types_review", "load_types_mood", "_load_types_mood", "load_types_score", "_load_types_score"} {
+			_, ok := conn.TypeMap().TypeForName(name)
+			require.Truef(t, ok, "%s not registered", name)
+		}
+
+		type Review struct {
+			Mood   string
+			Scores []int32
+		}
+
+		var reviews []Review
+		err = tx.QueryRow(ctx, "select array[row('happy', array[7, 9])]::load_types_review[]").Scan(&reviews)
+		require.NoError(t, err)
+		require.Equal(t, []Review{{"happy", []int32{7, 9}}}, reviews)
+
+		err = tx.QueryRow(ctx, "select $1::load_types_review[]", reviews).Scan(&reviews)
+		require.NoError(t, err)
+		require.Equal(t, []Review{{"happy", []int32{7, 9}}}, reviews)
+
+		_, err = conn.LoadTypes(ctx, []string{"load_types_missing"})
+		require.Error(t, err)
+	})
+}
+
+func TestLoadTypeArrayDelimiter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support box type")
+
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		// A domain has the delimiter of its base type. The delimiter of box is ';'.
+		_, err = tx.Exec(ctx, "create domain load_type_area as box")
+		require.NoError(t, err)
+
+		for _, load := range []func() (*pgtype.Type, error){
+			func() (*pgtype.Type, error) { return conn.LoadType(ctx, "_load_type_area") },
+			func() (*pgtype.Type, error) {
+				types, err := conn.LoadTypes(ctx, []string{"_load_type_area"})
+				if err != nil {
+					return nil, err
+				}
+				return types[0], nil
+			},
+		} {
+			dt, err := load()
+			require.NoError(t, err)
+			require.Equal(t, byte(';'), dt.Codec.(*pgtype.ArrayCodec).Delimiter)
+			conn.TypeMap().RegisterType(dt)
+
+			areas := []pgtype.Box{{P: [2]pgtype.Vec2{{X: 3, Y: 4}, {X: 1, Y: 2}}, Valid: true}, {P: [2]pgtype.Vec2{{X: 7, Y: 8}, {X: 5, Y: 6}}, Valid: true}}
+			var actual []pgtype.Box
+			err = tx.QueryRow(ctx, "select $1::_load_type_area", areas).Scan(&actual)
+			require.NoError(t, err)
+			require.Equal(t, areas, actual)
+
+			var text string
+			err = tx.QueryRow(ctx, "select $1::_load_type_area::text", areas).Scan(&text)
+			require.NoError(t, err)
+			require.Equal(t, "{(3,4),(1,2);(7,8),(5,6)}", text)
+		}
+	})
+}
+
 func TestLoadRangeType(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
@@ -1273,3 +1802,275 @@ func TestRawValuesUnderlyingMemoryReused(t *testing.T) {
 		t.Fatal("expected buffer from RawValues to be overwritten by subsequent queries but it was not")
 	})
 }
+
+func TestRegisterEnum(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	defaultConnTestRunner.RunTest(ctx, t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		tx, err := conn.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, `create type register_enum_mood as enum ('sad', 'ok', 'happy')`)
+		require.NoError(t, err)
+
+		type mood string
+
+		err = conn.RegisterEnum(ctx, "register_enum_mood", mood(""))
+		require.NoError(t, err)
+
+		var m mood
+		err = tx.QueryRow(ctx, `select $1::register_enum_mood`, mood("happy")).Scan(&m)
+		require.NoError(t, err)
+		require.Equal(t, mood("happy"), m)
+
+		var moods []mood
+		err = tx.QueryRow(ctx, `select array['sad', 'ok']::register_enum_mood[]`).Scan(&moods)
+		require.NoError(t, err)
+		require.Equal(t, []mood{"sad", "ok"}, moods)
+
+		err = tx.QueryRow(ctx, `select $1::register_enum_mood`, mood("angry")).Scan(&m)
+		require.ErrorContains(t, err, `invalid enum value: "angry"`)
+
+		err = conn.RegisterEnum(ctx, "text")
+		require.ErrorContains(t, err, "text is not an enum type")
+	})
+}
+
+func TestStatementCachePrepareThreshold(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	for _, descriptionCacheCapacity := range []int{512, 0} {
+		config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+		config.StatementCachePrepareThreshold = 3
+		config.DescriptionCacheCapacity = descriptionCacheCapacity
+		conn := mustConnect(t, config)
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support pg_prepared_statements")
+
+		preparedCount := func() int {
+			var n int
+			err := conn.QueryRow(ctx, "select count(*) from pg_prepared_statements", pgx.QueryExecModeSimpleProtocol).Scan(&n)
+			require.NoError(t, err)
+			return n
+		}
+
+		for i := 0; i < 2; i++ {
+			var n int64
+			err := conn.QueryRow(ctx, "select $1::int8", int64(i)).Scan(&n)
+			require.NoError(t, err)
+			require.EqualValues(t, i, n)
+
+			_, err = conn.Exec(ctx, "select $1::int8 + 0", int64(i))
+			require.NoError(t, err)
+		}
+		require.Equal(t, 0, preparedCount())
+
+		var n int64
+		err := conn.QueryRow(ctx, "select $1::int8", int64(2)).Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n)
+		require.Equal(t, 1, preparedCount())
+
+		_, err = conn.Exec(ctx, "select $1::int8 + 0", int64(2))
+		require.NoError(t, err)
+		require.Equal(t, 2, preparedCount())
+
+		// Queries already in the statement cache stay prepared.
+		err = conn.QueryRow(ctx, "select $1::int8", int64(3)).Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, n)
+		require.Equal(t, 2, preparedCount())
+
+		closeConn(t, conn)
+	}
+}
+
+func TestStatementCacheSkipDeallocate(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.StatementCacheCapacity = 1
+	config.StatementCacheSkipDeallocate = true
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+	pgxtest.SkipCockroachDB(t, conn, "Server does not support pg_prepared_statements")
+
+	preparedCount := func() int {
+		var n int
+		err := conn.QueryRow(ctx, "select count(*) from pg_prepared_statements", pgx.QueryExecModeSimpleProtocol).Scan(&n)
+		require.NoError(t, err)
+		return n
+	}
+
+	queries := []string{"select $1::int8", "select $1::int8 + 0", "select $1::int8 + 0 + 0"}
+	for i := 0; i < 2; i++ {
+		for _, sql := range queries {
+			var n int64
+			err := conn.QueryRow(ctx, sql, int64(42)).Scan(&n)
+			require.NoError(t, err)
+			require.EqualValues(t, 42, n)
+		}
+	}
+
+	// Evicted statements stay prepared. Preparing one of them again replaces it rather than failing.
+	require.Equal(t, len(queries), preparedCount())
+
+	batch := &pgx.Batch{}
+	for _, sql := range queries {
+		batch.Queue(sql, int64(7))
+	}
+	err := conn.SendBatch(ctx, batch).Close()
+	require.NoError(t, err)
+	require.Equal(t, len(queries), preparedCount())
+
+	ensureConnValid(t, conn)
+}
+
+func TestQueryExecModeDescribeExecDoesNotCacheStatements(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	defaultConnTestRunner.RunTest(ctx, t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support pg_prepared_statements")
+
+		for i := 0; i < 3; i++ {
+			var n int64
+			var b []byte
+			err := conn.QueryRow(ctx, fmt.Sprintf("select $1::int8 + %d, $2::bytea", i), pgx.QueryExecModeDescribeExec, int64(40), []byte{0, 1, 2}).Scan(&n, &b)
+			require.NoError(t, err)
+			require.EqualValues(t, 40+i, n)
+			require.Equal(t, []byte{0, 1, 2}, b)
+		}
+
+		var preparedCount int
+		err := conn.QueryRow(ctx, "select count(*) from pg_prepared_statements", pgx.QueryExecModeSimpleProtocol).Scan(&preparedCount)
+		require.NoError(t, err)
+		require.Equal(t, 0, preparedCount)
+	})
+}
+
+func TestQueryStatementTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support set_config")
+
+		var s string
+		err := conn.QueryRow(ctx, "select current_setting('statement_timeout')", pgx.QueryStatementTimeout(1500*time.Millisecond)).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "1500ms", s)
+
+		// The timeout must not leak to later queries.
+		err = conn.QueryRow(ctx, "select current_setting('statement_timeout')").Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "0", s)
+
+		rows, _ := conn.Query(ctx, "select pg_sleep(5)", pgx.QueryStatementTimeout(50*time.Millisecond))
+		rows.Close()
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, rows.Err(), &pgErr)
+		require.Equal(t, "57014", pgErr.Code)
+
+		_, err = conn.Exec(ctx, "select pg_sleep($1)", pgx.QueryStatementTimeout(50*time.Millisecond), 5.0)
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "57014", pgErr.Code)
+
+		var n int32
+		err = conn.QueryRow(ctx, "select $1::int4", pgx.QueryStatementTimeout(time.Second), int32(42)).Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 42, n)
+
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestQueryStatementTimeoutIsTransactionLocal(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	modes := []pgx.QueryExecMode{
+		pgx.QueryExecModeCacheStatement,
+		pgx.QueryExecModeCacheDescribe,
+		pgx.QueryExecModeDescribeExec,
+		pgx.QueryExecModeExec,
+	}
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, modes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support set_config")
+
+		_, err := conn.Exec(ctx, "create temporary table statement_timeout_vacuum(n int4)")
+		require.NoError(t, err)
+
+		// The query runs in the same implicit transaction as the set_config.
+		_, err = conn.Exec(ctx, "vacuum statement_timeout_vacuum", pgx.QueryStatementTimeout(time.Minute))
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "25001", pgErr.Code)
+
+		// A session level statement_timeout is unchanged afterwards, even when the query fails.
+		_, err = conn.Exec(ctx, "set statement_timeout = '5min'")
+		require.NoError(t, err)
+
+		var s string
+		err = conn.QueryRow(ctx, "select current_setting('statement_timeout')", pgx.QueryStatementTimeout(1500*time.Millisecond)).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "1500ms", s)
+
+		_, err = conn.Exec(ctx, "select 1/0", pgx.QueryStatementTimeout(1500*time.Millisecond))
+		require.Error(t, err)
+
+		err = conn.QueryRow(ctx, "select current_setting('statement_timeout')").Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "5min", s)
+
+		_, err = conn.Exec(ctx, "reset statement_timeout")
+		require.NoError(t, err)
+
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestConnConfigDefaultStatementTimeout(t *testing.T) {
+	t.Parallel()
+
+	config := mustParseConfig(t, os.Getenv("PGX_TEST_DATABASE"))
+	config.DefaultStatementTimeout = 50 * time.Millisecond
+	conn := mustConnect(t, config)
+	defer closeConn(t, conn)
+	pgxtest.SkipCockroachDB(t, conn, "Server does not support set_config")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := conn.Exec(ctx, "select pg_sleep(5)")
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "57014", pgErr.Code)
+
+	// A QueryStatementTimeout of 0 overrides the default.
+	_, err = conn.Exec(ctx, "select pg_sleep(0.2)", pgx.QueryStatementTimeout(0))
+	require.NoError(t, err)
+
+	var s string
+	err = conn.QueryRow(ctx, "select current_setting('statement_timeout')", pgx.QueryStatementTimeout(0)).Scan(&s)
+	require.NoError(t, err)
+	require.Equal(t, "0", s)
+
+	ensureConnValid(t, conn)
+}
@@ -3,8 +3,11 @@ package pgx
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
+	"strconv"
 
 	"github.com/jackc/pgx/v5/internal/pgio"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -64,6 +67,157 @@ func (cts *copyFromSlice) Err() error {
 	return cts.err
 }
 
+// CopyFromReader returns a CopyFromSource interface over CSV records read from r making it usable by *Conn.CopyFrom.
+// Records are parsed lazily as CopyFrom consumes them so the input is never fully materialized in memory. comma is the
+// field delimiter. Any field equal to null is copied as NULL. As encoding/csv does not report whether a field was
+// quoted, when null is the empty string all empty fields are NULL.
+//
+// Every non-NULL value is a string. CopyFrom converts strings to the column type through the text format.
+func CopyFromReader(r io.Reader, comma rune, null string) CopyFromSource {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.ReuseRecord = true
+	return &copyFromReader{r: cr, null: null}
+}
+
+type copyFromReader struct {
+	r      *csv.Reader
+	null   string
+	values []any
+	err    error
+}
+
+func (cfr *copyFromReader) Next() bool {
+	if cfr.err != nil {
+		return false
+	}
+
+	record, err := cfr.r.Read()
+	if err != nil {
+		if err != io.EOF {
+			cfr.err = err
+		}
+		return false
+	}
+
+	cfr.values = cfr.values[:0]
+	for _, field := range record {
+		if field == cfr.null {
+			cfr.values = append(cfr.values, nil)
+		} else {
+			cfr.values = append(cfr.values, field)
+		}
+	}
+
+	return true
+}
+
+func (cfr *copyFromReader) Values() ([]any, error) {
+	return cfr.values, nil
+}
+
+func (cfr *copyFromReader) Err() error {
+	return cfr.err
+}
+
+// CopyFromChan returns a CopyFromSource interface over the rows received from ch making it usable by *Conn.CopyFrom.
+// Rows are copied until ch is closed. A producer reports a failure by sending a non-nil error on errCh, which stops
+// the copy and is returned by CopyFrom. errCh may be nil if the producer cannot fail. ch must be closed or an error
+// sent even if the producer fails, otherwise CopyFrom blocks until ctx is canceled. ctx is usually the context passed
+// to CopyFrom. If it is canceled while waiting for a row the copy stops with ctx.Err(). The producer must not modify a
+// row after sending it.
+func CopyFromChan(ctx context.Context, ch <-chan []any, errCh <-chan error) CopyFromSource {
+	return &copyFromChan{ctx: ctx, ch: ch, errCh: errCh}
+}
+
+type copyFromChan struct {
+	ctx    context.Context
+	ch     <-chan []any
+	errCh  <-chan error
+	values []any
+	err    error
+}
+
+func (cfc *copyFromChan) Next() bool {
+	for cfc.err == nil {
+		select {
+		case values, ok := <-cfc.ch:
+			if !ok {
+				// An error sent before ch was closed must not be lost if both were ready at the same time.
+				select {
+				case cfc.err = <-cfc.errCh:
+				default:
+				}
+				return false
+			}
+			cfc.values = values
+			return true
+		case err, ok := <-cfc.errCh:
+			if ok && err != nil {
+				cfc.err = err
+				return false
+			}
+			if !ok {
+				cfc.errCh = nil // A closed errCh would always be ready.
+			}
+		case <-cfc.ctx.Done():
+			cfc.err = cfc.ctx.Err()
+			return false
+		}
+	}
+
+	return false
+}
+
+func (cfc *copyFromChan) Values() ([]any, error) {
+	return cfc.values, nil
+}
+
+func (cfc *copyFromChan) Err() error {
+	return cfc.err
+}
+
+// CopyFromProgress is called by CopyFrom with the number of rows copied so far.
+type CopyFromProgress func(rowsCopied int64)
+
+// CopyFromWithProgress returns a CopyFromSource that copies the rows from rowSrc and reports the progress of CopyFrom
+// to progress. progress is called after the buffered rows are sent to the server once at least every rows have been sent
+// since the last call. If every is less than 1 then progress is called each time rows are sent. When the copy succeeds,
+// progress is called a final time with the number of rows the server reports as copied.
+//
+// CopyFrom reads rowSrc on a goroutine of its own, and the calls made while rows are sent run on that goroutine, not
+// the one that called CopyFrom. The final call is made on the calling goroutine. Calls never overlap and none is made
+// after CopyFrom returns, but progress must synchronize access to any state it shares with other goroutines. It must
+// not use the connection performing the copy.
+func CopyFromWithProgress(rowSrc CopyFromSource, every int64, progress CopyFromProgress) CopyFromSource {
+	return &copyFromProgressSource{CopyFromSource: rowSrc, every: every, progress: progress}
+}
+
+type copyFromProgressSource struct {
+	CopyFromSource
+	every    int64
+	progress CopyFromProgress
+
+	rowsSent     int64
+	lastReported int64
+}
+
+func (cps *copyFromProgressSource) Next() bool {
+	if cps.CopyFromSource.Next() {
+		cps.rowsSent++
+		return true
+	}
+	return false
+}
+
+// sent is called after buffered rows are written to the server.
+func (cps *copyFromProgressSource) sent() {
+	if cps.rowsSent-cps.lastReported >= cps.every && cps.rowsSent > cps.lastReported {
+		cps.lastReported = cps.rowsSent
+		cps.progress(cps.rowsSent)
+	}
+}
+
 // CopyFromSource is the interface used by *Conn.CopyFrom as the source for copy data.
 type CopyFromSource interface {
 	// Next returns true if there is another row and makes the next row data
@@ -86,6 +240,9 @@ type copyFrom struct {
 	rowSrc        CopyFromSource
 	readerErrChan chan error
 	mode          QueryExecMode
+
+	rowsRead  int64 // number of rows read from rowSrc
+	clientErr error // error that caused the copy to be aborted by the client
 }
 
 func (ct *copyFrom) run(ctx context.Context) (int64, error) {
@@ -106,6 +263,15 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 	}
 	quotedColumnNames := cbuf.String()
 
+	describeSQL, err := ct.conn.rewriteStatementSQL(ctx, fmt.Sprintf("select %s from %s", quotedColumnNames, quotedTableName))
+	if err != nil {
+		return 0, err
+	}
+	copySQL, err := ct.conn.rewriteStatementSQL(ctx, fmt.Sprintf("copy %s ( %s ) from stdin binary;", quotedTableName, quotedColumnNames))
+	if err != nil {
+		return 0, err
+	}
+
 	var sd *pgconn.StatementDescription
 	switch ct.mode {
 	case QueryExecModeExec, QueryExecModeSimpleProtocol:
@@ -118,12 +284,7 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 		ct.mode = QueryExecModeDescribeExec
 		fallthrough
 	case QueryExecModeCacheStatement, QueryExecModeCacheDescribe, QueryExecModeDescribeExec:
-		var err error
-		sd, err = ct.conn.getStatementDescription(
-			ctx,
-			ct.mode,
-			fmt.Sprintf("select %s from %s", quotedColumnNames, quotedTableName),
-		)
+		sd, err = ct.conn.getStatementDescription(ctx, ct.mode, describeSQL)
 		if err != nil {
 			return 0, fmt.Errorf("statement description failed: %w", err)
 		}
@@ -131,6 +292,8 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 		return 0, fmt.Errorf("unknown QueryExecMode: %v", ct.mode)
 	}
 
+	progressSrc, _ := ct.rowSrc.(*copyFromProgressSource)
+
 	r, w := io.Pipe()
 	doneChan := make(chan struct{})
 
@@ -147,14 +310,20 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 		moreRows := true
 		for moreRows {
 			var err error
-			moreRows, buf, err = ct.buildCopyBuf(buf, sd)
+			moreRows, buf, err = ct.buildCopyBuf(ctx, buf, sd)
 			if err != nil {
-				w.CloseWithError(err)
+				if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+					ct.clientErr = ctxErr
+				} else {
+					ct.clientErr = fmt.Errorf("copy failed at row %d: %w", ct.rowsRead-1, err)
+				}
+				w.CloseWithError(ct.clientErr)
 				return
 			}
 
 			if ct.rowSrc.Err() != nil {
-				w.CloseWithError(ct.rowSrc.Err())
+				ct.clientErr = ct.rowSrc.Err()
+				w.CloseWithError(ct.clientErr)
 				return
 			}
 
@@ -164,6 +333,9 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 					w.Close()
 					return
 				}
+				if progressSrc != nil {
+					progressSrc.sent()
+				}
 			}
 
 			buf = buf[:0]
@@ -172,11 +344,23 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 		w.Close()
 	}()
 
-	commandTag, err := ct.conn.pgConn.CopyFrom(ctx, r, fmt.Sprintf("copy %s ( %s ) from stdin binary;", quotedTableName, quotedColumnNames))
+	commandTag, err := ct.conn.pgConn.CopyFrom(ctx, r, copySQL)
 
 	r.Close()
 	<-doneChan
 
+	// When the client aborts the copy the server responds with a query_canceled error that only includes the message of
+	// the client error. Return the client error itself instead so it can be inspected with errors.Is and errors.As.
+	if ct.clientErr != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "57014" {
+			err = ct.clientErr
+		} else if err != nil && ct.clientErr == ctx.Err() {
+			// The cancellation of ctx may also have interrupted sending CopyFail.
+			err = ct.clientErr
+		}
+	}
+
 	if ct.conn.copyFromTracer != nil {
 		ct.conn.copyFromTracer.TraceCopyFromEnd(ctx, ct.conn, TraceCopyFromEndData{
 			CommandTag: commandTag,
@@ -184,15 +368,24 @@ func (ct *copyFrom) run(ctx context.Context) (int64, error) {
 		})
 	}
 
+	if progressSrc != nil && err == nil {
+		progressSrc.progress(commandTag.RowsAffected())
+	}
+
 	return commandTag.RowsAffected(), err
 }
 
-func (ct *copyFrom) buildCopyBuf(buf []byte, sd *pgconn.StatementDescription) (bool, []byte, error) {
+func (ct *copyFrom) buildCopyBuf(ctx context.Context, buf []byte, sd *pgconn.StatementDescription) (bool, []byte, error) {
 	const sendBufSize = 65536 - 5 // The packet has a 5-byte header
 	lastBufLen := 0
 	largestRowLen := 0
 
+	if err := ctx.Err(); err != nil {
+		return false, nil, err
+	}
+
 	for ct.rowSrc.Next() {
+		ct.rowsRead++
 		lastBufLen = len(buf)
 
 		values, err := ct.rowSrc.Values()
@@ -207,7 +400,7 @@ func (ct *copyFrom) buildCopyBuf(buf []byte, sd *pgconn.StatementDescription) (b
 		for i, val := range values {
 			buf, err = encodeCopyValue(ct.conn.typeMap, buf, sd.Fields[i].DataTypeOID, val)
 			if err != nil {
-				return false, nil, err
+				return false, nil, fmt.Errorf("column %s: %w", ct.columnNames[i], err)
 			}
 		}
 
@@ -230,11 +423,22 @@ func (ct *copyFrom) buildCopyBuf(buf []byte, sd *pgconn.StatementDescription) (b
 // CopyFrom uses the PostgreSQL copy protocol to perform bulk data insertion. It returns the number of rows copied and
 // an error.
 //
-// CopyFrom requires all values use the binary format. A pgtype.Type that supports the binary format must be registered
-// for the type of each column. Almost all types implemented by pgx support the binary format.
+// CopyFrom always uses the binary COPY format. Each value is encoded with the binary encoder of its column's type, so
+// values are not converted to text and parsed again by the server. A pgtype.Type that supports the binary format must
+// be registered for the type of each column. Almost all types implemented by pgx support the binary format.
 //
 // Even though enum types appear to be strings they still must be registered to use with CopyFrom. This can be done with
 // Conn.LoadType and pgtype.Map.RegisterType.
+//
+// If the server rejects a row, e.g. because of a constraint violation, the *pgconn.PgError is returned unwrapped with
+// all of the details the server sent. Its Where field identifies the row by its 1-based line number, e.g. "COPY foo,
+// line 3, column b". The row cannot be determined on the client because rows are streamed ahead of the server. If the
+// copy is aborted because rowSrc returns an error or a value cannot be encoded then that error is returned. If the
+// error belongs to a row it is wrapped with the 0-based index of the row in rowSrc.
+//
+// ctx is checked before each chunk of rows is read from rowSrc. If ctx is canceled the copy is aborted without reading
+// any more rows and ctx.Err() is returned. rowSrc itself is not interrupted, so a source that blocks in Next should
+// watch ctx on its own.
 func (c *Conn) CopyFrom(ctx context.Context, tableName Identifier, columnNames []string, rowSrc CopyFromSource) (int64, error) {
 	ct := &copyFrom{
 		conn:          c,
@@ -247,3 +451,288 @@ func (c *Conn) CopyFrom(ctx context.Context, tableName Identifier, columnNames [
 
 	return ct.run(ctx)
 }
+
+// CopyFromChunkedOptions configures CopyFromChunked.
+type CopyFromChunkedOptions struct {
+	// ChunkSize is the maximum number of rows copied by each COPY. It must be greater than 0.
+	ChunkSize int
+
+	// ContinueOnError skips the rest of a chunk that fails to copy and continues with the next chunk instead of aborting.
+	// Errors from the CopyFromSource itself always abort.
+	ContinueOnError bool
+}
+
+// CopyFromFailedChunk describes a chunk that CopyFromChunked failed to copy.
+type CopyFromFailedChunk struct {
+	Index    int   // Index of the chunk starting at 0.
+	FirstRow int64 // Index of the first row of the chunk in the source starting at 0.
+	Rows     int64 // Number of rows in the chunk.
+	Err      error
+}
+
+// CopyFromChunkedError is returned by CopyFromChunked when any chunk failed to copy.
+type CopyFromChunkedError struct {
+	RowsCopied   int64
+	FailedChunks []CopyFromFailedChunk
+}
+
+func (e *CopyFromChunkedError) Error() string {
+	first := e.FailedChunks[0]
+	return fmt.Sprintf("copy failed for %d chunk(s) with %d rows copied: chunk %d starting at row %d: %v", len(e.FailedChunks), e.RowsCopied, first.Index, first.FirstRow, first.Err)
+}
+
+// Unwrap returns the error of the first failed chunk.
+func (e *CopyFromChunkedError) Unwrap() error {
+	return e.FailedChunks[0].Err
+}
+
+// CopyFromChunked is like CopyFrom, but it copies the rows from rowSrc with a separate COPY for every
+// options.ChunkSize rows. Each chunk is committed independently unless CopyFromChunked is called in a transaction. It
+// returns the number of rows copied. If any chunk fails the error is a *CopyFromChunkedError that reports how many rows
+// were copied and which chunks failed.
+//
+// In a transaction a failed chunk aborts the transaction so ContinueOnError is only useful outside of a transaction.
+func (c *Conn) CopyFromChunked(ctx context.Context, tableName Identifier, columnNames []string, rowSrc CopyFromSource, options CopyFromChunkedOptions) (int64, error) {
+	if options.ChunkSize < 1 {
+		return 0, errors.New("chunk size must be greater than 0")
+	}
+
+	var rowsCopied, firstRow int64
+	var chunkedErr *CopyFromChunkedError
+
+	for index := 0; ; index++ {
+		// Check for another row before starting a chunk so no empty COPY is sent.
+		if !rowSrc.Next() {
+			break
+		}
+
+		chunk := &copyFromChunkSource{CopyFromSource: rowSrc, remaining: options.ChunkSize, primed: true}
+		n, err := c.CopyFrom(ctx, tableName, columnNames, chunk)
+		if err != nil {
+			for chunk.Next() {
+				// Skip the rest of the failed chunk.
+			}
+
+			if chunkedErr == nil {
+				chunkedErr = &CopyFromChunkedError{}
+			}
+			chunkedErr.FailedChunks = append(chunkedErr.FailedChunks, CopyFromFailedChunk{Index: index, FirstRow: firstRow, Rows: chunk.rows, Err: err})
+
+			if !options.ContinueOnError || chunk.valuesErr != nil || rowSrc.Err() != nil || ctx.Err() != nil || c.IsClosed() {
+				break
+			}
+		} else {
+			rowsCopied += n
+		}
+
+		firstRow += chunk.rows
+	}
+
+	if chunkedErr != nil {
+		chunkedErr.RowsCopied = rowsCopied
+		return rowsCopied, chunkedErr
+	}
+
+	if err := rowSrc.Err(); err != nil {
+		return rowsCopied, err
+	}
+
+	return rowsCopied, nil
+}
+
+// copyFromChunkSource limits a CopyFromSource to a chunk of rows. If primed is true then the source is already
+// positioned at the first row of the chunk.
+type copyFromChunkSource struct {
+	CopyFromSource
+	remaining int
+	primed    bool
+	rows      int64
+	valuesErr error
+}
+
+func (cfcs *copyFromChunkSource) Next() bool {
+	if cfcs.remaining == 0 {
+		return false
+	}
+
+	if cfcs.primed {
+		cfcs.primed = false
+	} else if !cfcs.CopyFromSource.Next() {
+		cfcs.remaining = 0
+		return false
+	}
+
+	cfcs.remaining--
+	cfcs.rows++
+	return true
+}
+
+// Values records any error returned by the underlying source so CopyFromChunked can tell it apart from an error
+// copying the chunk.
+func (cfcs *copyFromChunkSource) Values() ([]any, error) {
+	values, err := cfcs.CopyFromSource.Values()
+	if err != nil {
+		cfcs.valuesErr = err
+	}
+	return values, err
+}
+
+// CopyQueryToTable executes query with args on src and uses CopyFrom to copy the resulting rows into tableName on dst.
+// It returns the number of rows copied. The rows are streamed, so the query result is never fully materialized in
+// memory. The result columns are copied into the columns of tableName with the same names, so query should alias its
+// columns to match the destination table.
+//
+// Each value is decoded with the type map of src and encoded with the type map of dst. If a value cannot be encoded
+// for its destination column the copy is aborted and the error identifies the row and the column. src and dst must be
+// different connections because src is busy reading the query result until the copy completes.
+func CopyQueryToTable(ctx context.Context, src, dst *Conn, query string, tableName Identifier, args ...any) (int64, error) {
+	if src == dst {
+		return 0, errors.New("src and dst must be different connections")
+	}
+
+	rows, err := src.Query(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fields := rows.FieldDescriptions()
+	if len(fields) == 0 {
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, errors.New("query returned no columns")
+	}
+
+	columnNames := make([]string, len(fields))
+	for i, fd := range fields {
+		columnNames[i] = fd.Name
+	}
+
+	copyCount, err := dst.CopyFrom(ctx, tableName, columnNames, rows)
+	if err != nil {
+		return copyCount, err
+	}
+
+	rows.Close()
+	return copyCount, rows.Err()
+}
+
+// copyFromWithReturningMaxParams is the maximum number of parameters PostgreSQL allows in a single statement.
+const copyFromWithReturningMaxParams = 65535
+
+// copyFromWithReturningMaxRows limits the number of rows inserted by each statement.
+const copyFromWithReturningMaxRows = 1000
+
+// CopyFromWithReturning inserts the rows from rowSrc like CopyFrom, but it also returns the returning columns of each
+// inserted row. This is useful for retrieving serial or identity values. fn is called for each returned row. The rows
+// are returned in the order PostgreSQL returns them, which is not guaranteed to be the order of rowSrc. Include a column
+// that identifies the source row in returning if the results must be matched to the rows of rowSrc.
+//
+// PostgreSQL does not support RETURNING for COPY, so CopyFromWithReturning does not use the copy protocol. Instead, it
+// sends batched multi-row INSERT ... RETURNING statements. This trades some of the speed of CopyFrom for the returned
+// values. The statements are not atomic as a whole. Call CopyFromWithReturning inside a transaction if all or none of
+// the rows must be inserted.
+func CopyFromWithReturning[T any](ctx context.Context, conn *Conn, tableName Identifier, columnNames []string, rowSrc CopyFromSource, returning []string, fn RowToFunc[T]) ([]T, error) {
+	if len(columnNames) == 0 {
+		return nil, errors.New("at least one column is required")
+	}
+	if len(returning) == 0 {
+		return nil, errors.New("at least one returning column is required")
+	}
+
+	if len(columnNames) > copyFromWithReturningMaxParams {
+		return nil, fmt.Errorf("too many columns: %d, the maximum is %d", len(columnNames), copyFromWithReturningMaxParams)
+	}
+
+	rowsPerInsert := copyFromWithReturningMaxParams / len(columnNames)
+	if rowsPerInsert > copyFromWithReturningMaxRows {
+		rowsPerInsert = copyFromWithReturningMaxRows
+	}
+
+	cbuf := &bytes.Buffer{}
+	cbuf.WriteString("insert into ")
+	cbuf.WriteString(tableName.Sanitize())
+	cbuf.WriteString(" (")
+	for i, cn := range columnNames {
+		if i != 0 {
+			cbuf.WriteString(", ")
+		}
+		cbuf.WriteString(quoteIdentifier(cn))
+	}
+	cbuf.WriteString(") values ")
+	insertPrefix := cbuf.String()
+
+	cbuf.Reset()
+	cbuf.WriteString(" returning ")
+	for i, cn := range returning {
+		if i != 0 {
+			cbuf.WriteString(", ")
+		}
+		cbuf.WriteString(quoteIdentifier(cn))
+	}
+	returningSuffix := cbuf.String()
+
+	var results []T
+	args := make([]any, 0, rowsPerInsert*len(columnNames))
+
+	insert := func() error {
+		sql := &bytes.Buffer{}
+		sql.WriteString(insertPrefix)
+		for i := 0; i < len(args); i++ {
+			if i%len(columnNames) == 0 {
+				if i != 0 {
+					sql.WriteString("), ")
+				}
+				sql.WriteByte('(')
+			} else {
+				sql.WriteString(", ")
+			}
+			sql.WriteByte('$')
+			sql.WriteString(strconv.Itoa(i + 1))
+		}
+		sql.WriteByte(')')
+		sql.WriteString(returningSuffix)
+
+		rows, _ := conn.Query(ctx, sql.String(), queryArguments(args))
+		batchResults, err := CollectRows(rows, fn)
+		if err != nil {
+			return err
+		}
+		results = append(results, batchResults...)
+		args = args[:0]
+		return nil
+	}
+
+	for rowSrc.Next() {
+		values, err := rowSrc.Values()
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != len(columnNames) {
+			return nil, fmt.Errorf("expected %d values, got %d values", len(columnNames), len(values))
+		}
+
+		args = append(args, values...)
+		if len(args) == rowsPerInsert*len(columnNames) {
+			err := insert()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := rowSrc.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(args) > 0 {
+		err := insert()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
@@ -2,9 +2,12 @@ package pgx_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -453,6 +456,31 @@ func TestConnCopyFromJSON(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+var errValuesFailSource = errors.New("values failed")
+
+// valuesFailSource fails in Values at failRow without reporting the error from Err.
+type valuesFailSource struct {
+	rows    int
+	failRow int
+	row     int
+}
+
+func (vfs *valuesFailSource) Next() bool {
+	vfs.row++
+	return vfs.row <= vfs.rows
+}
+
+func (vfs *valuesFailSource) Values() ([]any, error) {
+	if vfs.row-1 == vfs.failRow {
+		return nil, errValuesFailSource
+	}
+	return []any{int64(vfs.row - 1)}, nil
+}
+
+func (vfs *valuesFailSource) Err() error {
+	return nil
+}
+
 type clientFailSource struct {
 	count int
 	err   error
@@ -533,6 +561,48 @@ func TestConnCopyFromFailServerSideMidway(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+type cancelSource struct {
+	count  int
+	cancel context.CancelFunc
+}
+
+func (cs *cancelSource) Next() bool {
+	cs.count++
+	if cs.count == 3 {
+		cs.cancel()
+	}
+	// Never ends on its own. CopyFrom must stop reading once the context is canceled.
+	return true
+}
+
+func (cs *cancelSource) Values() ([]any, error) {
+	return []any{make([]byte, 100000)}, nil
+}
+
+func (cs *cancelSource) Err() error {
+	return nil
+}
+
+func TestConnCopyFromContextCanceledMidway(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a bytea
+	)`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	src := &cancelSource{cancel: cancel}
+	copyCount, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a"}, src)
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualValues(t, 0, copyCount)
+	require.Less(t, src.count, 10)
+}
+
 type failSource struct {
 	count int
 }
@@ -708,6 +778,66 @@ func TestConnCopyFromCopyFromSourceErrorMidway(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+func TestConnCopyFromClientErrorIsReturned(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int4
+	)`)
+
+	errSource := errors.New("source error")
+	copyCount, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromSlice(5, func(i int) ([]any, error) {
+		if i == 2 {
+			return nil, errSource
+		}
+		return []any{int32(i)}, nil
+	}))
+	require.ErrorIs(t, err, errSource)
+	require.EqualError(t, err, "copy failed at row 2: source error")
+	require.EqualValues(t, 0, copyCount)
+
+	copyCount, err = conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromRows([][]any{{int32(1)}, {struct{}{}}}))
+	require.ErrorContains(t, err, "copy failed at row 1: ")
+	var pgErr *pgconn.PgError
+	require.False(t, errors.As(err, &pgErr))
+	require.EqualValues(t, 0, copyCount)
+
+	ensureConnValid(t, conn)
+}
+
+func TestConnCopyFromServerErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	pgxtest.SkipCockroachDB(t, conn, "Server does not report the COPY line")
+
+	mustExec(t, conn, `create temporary table foo(
+		a int4,
+		b text check (b <> 'bad')
+	)`)
+
+	_, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a", "b"}, pgx.CopyFromRows([][]any{{int32(1), "good"}, {int32(2), "bad"}, {int32(3), "good"}}))
+	pgErr, ok := err.(*pgconn.PgError)
+	require.True(t, ok, "%#v", err)
+	require.Equal(t, "23514", pgErr.Code)
+	require.Equal(t, "foo", pgErr.TableName)
+	require.Contains(t, pgErr.Where, "line 2")
+	require.NotEmpty(t, pgErr.Detail)
+
+	ensureConnValid(t, conn)
+}
+
 type clientFinalErrSource struct {
 	count int
 }
@@ -802,3 +932,425 @@ func TestConnCopyFromAutomaticStringConversion(t *testing.T) {
 
 	ensureConnValid(t, conn)
 }
+
+func TestCopyFromReader(t *testing.T) {
+	t.Parallel()
+
+	src := pgx.CopyFromReader(strings.NewReader("1|abc|\\N\n2|\\N|\"x|y\"\n"), '|', `\N`)
+
+	require.True(t, src.Next())
+	values, err := src.Values()
+	require.NoError(t, err)
+	require.Equal(t, []any{"1", "abc", nil}, values)
+
+	require.True(t, src.Next())
+	values, err = src.Values()
+	require.NoError(t, err)
+	require.Equal(t, []any{"2", nil, "x|y"}, values)
+
+	require.False(t, src.Next())
+	require.NoError(t, src.Err())
+
+	src = pgx.CopyFromReader(strings.NewReader("1,2\n3\n4,5\n"), ',', "")
+	require.True(t, src.Next())
+	require.False(t, src.Next())
+	require.Error(t, src.Err())
+	require.False(t, src.Next())
+}
+
+func TestConnCopyFromReader(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int8,
+		b text,
+		c timestamptz
+	)`)
+
+	input := "1,foo,2020-01-02 03:04:05Z\n2,,\n3,\"bar, baz\",2021-01-01 00:00:00Z\n"
+
+	copyCount, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a", "b", "c"}, pgx.CopyFromReader(strings.NewReader(input), ',', ""))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, copyCount)
+
+	var n int64
+	err = conn.QueryRow(ctx, "select count(*) from foo where b is null and c is null").Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	var b string
+	err = conn.QueryRow(ctx, "select b from foo where a = 3").Scan(&b)
+	require.NoError(t, err)
+	require.Equal(t, "bar, baz", b)
+
+	ensureConnValid(t, conn)
+}
+
+func TestCopyFromChan(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan []any, 2)
+	ch <- []any{1, "a"}
+	ch <- []any{2, nil}
+	close(ch)
+
+	src := pgx.CopyFromChan(context.Background(), ch, nil)
+	require.True(t, src.Next())
+	values, err := src.Values()
+	require.NoError(t, err)
+	require.Equal(t, []any{1, "a"}, values)
+	require.True(t, src.Next())
+	values, err = src.Values()
+	require.NoError(t, err)
+	require.Equal(t, []any{2, nil}, values)
+	require.False(t, src.Next())
+	require.NoError(t, src.Err())
+
+	ch = make(chan []any)
+	errCh := make(chan error, 1)
+	errCh <- errors.New("producer failed")
+	close(ch)
+
+	src = pgx.CopyFromChan(context.Background(), ch, errCh)
+	require.False(t, src.Next())
+	require.EqualError(t, src.Err(), "producer failed")
+	require.False(t, src.Next())
+
+	ch = make(chan []any, 1)
+	ch <- []any{1}
+	close(ch)
+	errCh = make(chan error)
+	close(errCh)
+
+	src = pgx.CopyFromChan(context.Background(), ch, errCh)
+	require.True(t, src.Next())
+	require.False(t, src.Next())
+	require.NoError(t, src.Err())
+
+	// A producer that never sends or closes ch does not block past the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	src = pgx.CopyFromChan(ctx, make(chan []any), nil)
+	require.False(t, src.Next())
+	require.ErrorIs(t, src.Err(), context.Canceled)
+}
+
+func TestConnCopyFromChan(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int8,
+		b text
+	)`)
+
+	ch := make(chan []any)
+	go func() {
+		defer close(ch)
+		for i := 0; i < 1000; i++ {
+			ch <- []any{int64(i), fmt.Sprint(i)}
+		}
+	}()
+
+	copyCount, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a", "b"}, pgx.CopyFromChan(ctx, ch, nil))
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, copyCount)
+
+	ch = make(chan []any)
+	errCh := make(chan error)
+	go func() {
+		ch <- []any{int64(1), "a"}
+		errCh <- errors.New("producer failed")
+	}()
+
+	copyCount, err = conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a", "b"}, pgx.CopyFromChan(ctx, ch, errCh))
+	require.ErrorContains(t, err, "producer failed")
+	require.EqualValues(t, 0, copyCount)
+
+	var n int64
+	err = conn.QueryRow(ctx, "select count(*) from foo").Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, n)
+
+	// A stalled producer does not block CopyFrom past its context.
+	copyCtx, copyCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer copyCancel()
+	_, err = conn.CopyFrom(copyCtx, pgx.Identifier{"foo"}, []string{"a", "b"}, pgx.CopyFromChan(copyCtx, make(chan []any), nil))
+	require.Error(t, err)
+}
+
+func TestConnCopyFromReaderParseError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int8
+	)`)
+
+	copyCount, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromReader(strings.NewReader("1\n\"2\n"), ',', ""))
+	require.Error(t, err)
+	require.EqualValues(t, 0, copyCount)
+
+	var n int64
+	err = conn.QueryRow(ctx, "select count(*) from foo").Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, n)
+
+	ensureConnValid(t, conn)
+}
+
+func TestCopyFromWithReturning(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		id int8 generated by default as identity primary key,
+		name text not null
+	)`)
+
+	// More rows than fit in a single insert statement.
+	inputRows := make([][]any, 2500)
+	for i := range inputRows {
+		inputRows[i] = []any{fmt.Sprintf("name%d", i)}
+	}
+
+	type result struct {
+		ID   int64
+		Name string
+	}
+
+	results, err := pgx.CopyFromWithReturning(ctx, conn, pgx.Identifier{"foo"}, []string{"name"}, pgx.CopyFromRows(inputRows), []string{"id", "name"}, pgx.RowToStructByPos[result])
+	require.NoError(t, err)
+	require.Len(t, results, len(inputRows))
+	// The order of the returned rows is not guaranteed.
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
+	for i := range results {
+		require.EqualValues(t, i+1, results[i].ID)
+		require.Equal(t, inputRows[i][0], results[i].Name)
+	}
+
+	var count int64
+	err = conn.QueryRow(ctx, "select count(*) from foo").Scan(&count)
+	require.NoError(t, err)
+	require.EqualValues(t, len(inputRows), count)
+
+	ids, err := pgx.CopyFromWithReturning(ctx, conn, pgx.Identifier{"foo"}, []string{"name"}, pgx.CopyFromRows([][]any{{nil}}), []string{"id"}, pgx.RowTo[int64])
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Nil(t, ids)
+
+	_, err = pgx.CopyFromWithReturning(ctx, conn, pgx.Identifier{"foo"}, []string{"name"}, pgx.CopyFromRows([][]any{{"a", "b"}}), []string{"id"}, pgx.RowTo[int64])
+	require.ErrorContains(t, err, "expected 1 values, got 2 values")
+
+	// Values are never mistaken for query options.
+	mustExec(t, conn, `create temporary table modes(mode int4 not null)`)
+	modes, err := pgx.CopyFromWithReturning(ctx, conn, pgx.Identifier{"modes"}, []string{"mode"}, pgx.CopyFromRows([][]any{{pgx.QueryExecModeSimpleProtocol}}), []string{"mode"}, pgx.RowTo[int32])
+	require.NoError(t, err)
+	require.Equal(t, []int32{int32(pgx.QueryExecModeSimpleProtocol)}, modes)
+
+	ensureConnValid(t, conn)
+}
+
+func TestCopyFromWithReturningTooManyColumns(t *testing.T) {
+	t.Parallel()
+
+	columnNames := make([]string, 65536)
+	for i := range columnNames {
+		columnNames[i] = fmt.Sprintf("c%d", i)
+	}
+
+	_, err := pgx.CopyFromWithReturning(context.Background(), nil, pgx.Identifier{"foo"}, columnNames, pgx.CopyFromRows(nil), []string{"id"}, pgx.RowTo[int64])
+	require.ErrorContains(t, err, "too many columns")
+}
+
+func TestConnCopyFromWithProgress(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int8,
+		b text
+	)`)
+
+	inputRows := make([][]any, 100000)
+	for i := range inputRows {
+		inputRows[i] = []any{int64(i), strings.Repeat("x", 20)}
+	}
+
+	var progress []int64
+	rowSrc := pgx.CopyFromWithProgress(pgx.CopyFromRows(inputRows), 10000, func(rowsCopied int64) {
+		progress = append(progress, rowsCopied)
+	})
+
+	copyCount, err := conn.CopyFrom(ctx, pgx.Identifier{"foo"}, []string{"a", "b"}, rowSrc)
+	require.NoError(t, err)
+	require.EqualValues(t, len(inputRows), copyCount)
+
+	require.Greater(t, len(progress), 2)
+	for i := 1; i < len(progress)-1; i++ {
+		require.GreaterOrEqual(t, progress[i]-progress[i-1], int64(10000))
+	}
+	require.EqualValues(t, len(inputRows), progress[len(progress)-1])
+
+	ensureConnValid(t, conn)
+}
+
+func TestCopyQueryToTable(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	src := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, src)
+
+	dst := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, dst)
+
+	mustExec(t, dst, `create temporary table foo(
+		a int8,
+		b text,
+		c int4[]
+	)`)
+
+	copyCount, err := pgx.CopyQueryToTable(ctx, src, dst,
+		"select n as a, repeat('x', n::int) as b, array[n, -n]::int4[] as c from generate_series(1, $1::int8) n",
+		pgx.Identifier{"foo"}, 1000,
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, copyCount)
+
+	var count, sum int64
+	var lastB string
+	var lastC []int32
+	err = dst.QueryRow(ctx, "select count(*), sum(a), max(b), (select c from foo where a = 1000) from foo").Scan(&count, &sum, &lastB, &lastC)
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, count)
+	require.EqualValues(t, 500500, sum)
+	require.Len(t, lastB, 1000)
+	require.Equal(t, []int32{1000, -1000}, lastC)
+
+	_, err = pgx.CopyQueryToTable(ctx, src, dst, "select 1::int8 as a, 'x' as b, 'tuesday'::text as c", pgx.Identifier{"foo"})
+	require.ErrorContains(t, err, "copy failed at row 0: column c: ")
+
+	_, err = pgx.CopyQueryToTable(ctx, src, dst, "select 1 as missing", pgx.Identifier{"foo"})
+	require.Error(t, err)
+
+	_, err = pgx.CopyQueryToTable(ctx, src, dst, "select 1/0 as a", pgx.Identifier{"foo"})
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "22012", pgErr.Code)
+
+	_, err = pgx.CopyQueryToTable(ctx, src, src, "select 1 as a", pgx.Identifier{"foo"})
+	require.EqualError(t, err, "src and dst must be different connections")
+
+	ensureConnValid(t, src)
+	ensureConnValid(t, dst)
+}
+
+func TestConnCopyFromChunked(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	mustExec(t, conn, `create temporary table foo(
+		a int8 check (a >= 0)
+	)`)
+
+	inputRows := make([][]any, 10)
+	for i := range inputRows {
+		inputRows[i] = []any{int64(i)}
+	}
+
+	copyCount, err := conn.CopyFromChunked(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromRows(inputRows), pgx.CopyFromChunkedOptions{ChunkSize: 3})
+	require.NoError(t, err)
+	require.EqualValues(t, 10, copyCount)
+
+	var n int64
+	err = conn.QueryRow(ctx, "select count(*) from foo").Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, n)
+
+	mustExec(t, conn, "truncate foo")
+
+	// Rows 4 and 8 violate the check constraint so the second and third chunks fail.
+	inputRows[4] = []any{int64(-4)}
+	inputRows[8] = []any{int64(-8)}
+
+	copyCount, err = conn.CopyFromChunked(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromRows(inputRows), pgx.CopyFromChunkedOptions{ChunkSize: 3})
+	require.EqualValues(t, 3, copyCount)
+	var chunkedErr *pgx.CopyFromChunkedError
+	require.ErrorAs(t, err, &chunkedErr)
+	require.EqualValues(t, 3, chunkedErr.RowsCopied)
+	require.Len(t, chunkedErr.FailedChunks, 1)
+	require.Equal(t, 1, chunkedErr.FailedChunks[0].Index)
+	require.EqualValues(t, 3, chunkedErr.FailedChunks[0].FirstRow)
+	require.EqualValues(t, 3, chunkedErr.FailedChunks[0].Rows)
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "23514", pgErr.Code)
+
+	mustExec(t, conn, "truncate foo")
+
+	copyCount, err = conn.CopyFromChunked(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromRows(inputRows), pgx.CopyFromChunkedOptions{ChunkSize: 3, ContinueOnError: true})
+	require.EqualValues(t, 4, copyCount)
+	require.ErrorAs(t, err, &chunkedErr)
+	require.EqualValues(t, 4, chunkedErr.RowsCopied)
+	require.Len(t, chunkedErr.FailedChunks, 2)
+	require.Equal(t, 1, chunkedErr.FailedChunks[0].Index)
+	require.EqualValues(t, 3, chunkedErr.FailedChunks[0].FirstRow)
+	require.Equal(t, 2, chunkedErr.FailedChunks[1].Index)
+	require.EqualValues(t, 6, chunkedErr.FailedChunks[1].FirstRow)
+
+	rows, _ := conn.Query(ctx, "select a from foo order by a")
+	values, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 1, 2, 9}, values)
+
+	_, err = conn.CopyFromChunked(ctx, pgx.Identifier{"foo"}, []string{"a"}, pgx.CopyFromRows(inputRows), pgx.CopyFromChunkedOptions{})
+	require.EqualError(t, err, "chunk size must be greater than 0")
+
+	mustExec(t, conn, "truncate foo")
+
+	// An error from the source aborts the copy even with ContinueOnError.
+	src := &valuesFailSource{rows: 10, failRow: 4}
+	copyCount, err = conn.CopyFromChunked(ctx, pgx.Identifier{"foo"}, []string{"a"}, src, pgx.CopyFromChunkedOptions{ChunkSize: 3, ContinueOnError: true})
+	require.EqualValues(t, 3, copyCount)
+	require.ErrorAs(t, err, &chunkedErr)
+	require.Len(t, chunkedErr.FailedChunks, 1)
+	require.Equal(t, 1, chunkedErr.FailedChunks[0].Index)
+	require.ErrorIs(t, err, errValuesFailSource)
+	require.Less(t, src.row, 10)
+
+	ensureConnValid(t, conn)
+}
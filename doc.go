@@ -171,6 +171,18 @@ notification is received or the context is canceled.
     }
     // do something with notification
 
+Listener builds on WaitForNotification. It delivers notifications on a channel and reconnects and listens again when the
+connection is lost.
+
+    listener := pgx.NewListener(func(ctx context.Context) (*pgx.Conn, error) {
+        return pgx.Connect(ctx, os.Getenv("DATABASE_URL"))
+    }, "channelname")
+    go listener.Listen(ctx)
+
+    for notification := range listener.Notifications() {
+        // do something with notification
+    }
+
 
 Tracing and Logging
 
@@ -16,6 +16,9 @@ type ExtendedQueryBuilder struct {
 	paramValueBytes []byte
 	ParamFormats    []int16
 	ResultFormats   []int16
+
+	// textFormatOnly uses the text format for all parameters and results. It is set from ConnConfig.TextFormatOnly.
+	textFormatOnly bool
 }
 
 // Build sets ParamValues, ParamFormats, and ResultFormats for use with *PgConn.ExecParams or *PgConn.ExecPrepared. If
@@ -33,8 +36,13 @@ func (eqb *ExtendedQueryBuilder) Build(m *pgtype.Map, sd *pgconn.StatementDescri
 		return fmt.Errorf("mismatched param and argument count")
 	}
 
+	paramFormat := int16(-1)
+	if eqb.textFormatOnly {
+		paramFormat = TextFormatCode
+	}
+
 	for i := range args {
-		err := eqb.appendParam(m, sd.ParamOIDs[i], -1, args[i])
+		err := eqb.appendParam(m, sd.ParamOIDs[i], paramFormat, args[i])
 		if err != nil {
 			err = fmt.Errorf("failed to encode args[%d]: %v", i, err)
 			return err
@@ -42,7 +50,11 @@ func (eqb *ExtendedQueryBuilder) Build(m *pgtype.Map, sd *pgconn.StatementDescri
 	}
 
 	for i := range sd.Fields {
-		eqb.appendResultFormat(m.FormatCodeForOID(sd.Fields[i].DataTypeOID))
+		if eqb.textFormatOnly {
+			eqb.appendResultFormat(TextFormatCode)
+		} else {
+			eqb.appendResultFormat(m.FormatCodeForOID(sd.Fields[i].DataTypeOID))
+		}
 	}
 
 	return nil
@@ -161,6 +173,15 @@ func (eqb *ExtendedQueryBuilder) chooseParameterFormatCode(m *pgtype.Map, oid ui
 // no way to safely use binary or to specify the parameter OIDs.
 func (eqb *ExtendedQueryBuilder) appendParamsForQueryExecModeExec(m *pgtype.Map, args []any) error {
 	for _, arg := range args {
+		// Unwrap values such as Null so the type is found from the wrapped value.
+		if nullable, ok := arg.(pgtype.NullableValuer); ok {
+			if v, valid := nullable.NullableValue(); valid {
+				arg = v
+			} else {
+				arg = nil
+			}
+		}
+
 		if arg == nil {
 			err := eqb.appendParam(m, 0, TextFormatCode, arg)
 			if err != nil {
@@ -4,6 +4,7 @@ package stmtcache
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgconn"
 )
@@ -12,7 +13,14 @@ import (
 // executions.
 func StatementName(sql string) string {
 	digest := sha256.Sum256([]byte(sql))
-	return "stmtcache_" + hex.EncodeToString(digest[0:24])
+	return statementNamePrefix + hex.EncodeToString(digest[0:24])
+}
+
+const statementNamePrefix = "stmtcache_"
+
+// IsStatementName returns true if name could have been returned by StatementName.
+func IsStatementName(name string) bool {
+	return strings.HasPrefix(name, statementNamePrefix)
 }
 
 // Cache caches statement descriptions.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
 )
 
 // LargeObjects is a structure used to access the large objects API. It is only valid within the transaction where it
@@ -60,14 +61,27 @@ func (o *LargeObjects) Unlink(ctx context.Context, oid uint32) error {
 //	io.Reader
 //	io.Seeker
 //	io.Closer
+//	io.ReaderAt
+//	io.WriterAt
+//
+// The methods of a LargeObject may be called concurrently. They are serialized because they share the current location
+// pointer of the descriptor.
 type LargeObject struct {
 	ctx context.Context
 	tx  Tx
 	fd  int32
+
+	mu sync.Mutex
 }
 
 // Write writes p to the large object and returns the number of bytes written and an error if not all of p was written.
 func (o *LargeObject) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.write(p)
+}
+
+func (o *LargeObject) write(p []byte) (int, error) {
 	var n int
 	err := o.tx.QueryRow(o.ctx, "select lowrite($1, $2)", o.fd, p).Scan(&n)
 	if err != nil {
@@ -83,6 +97,12 @@ func (o *LargeObject) Write(p []byte) (int, error) {
 
 // Read reads up to len(p) bytes into p returning the number of bytes read.
 func (o *LargeObject) Read(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.read(p)
+}
+
+func (o *LargeObject) read(p []byte) (int, error) {
 	var res []byte
 	err := o.tx.QueryRow(o.ctx, "select loread($1, $2)", o.fd, len(p)).Scan(&res)
 	copy(p, res)
@@ -98,24 +118,102 @@ func (o *LargeObject) Read(p []byte) (int, error) {
 
 // Seek moves the current location pointer to the new location specified by offset.
 func (o *LargeObject) Seek(offset int64, whence int) (n int64, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.seek(offset, whence)
+}
+
+func (o *LargeObject) seek(offset int64, whence int) (n int64, err error) {
 	err = o.tx.QueryRow(o.ctx, "select lo_lseek64($1, $2, $3)", o.fd, offset, whence).Scan(&n)
 	return n, err
 }
 
+// ReadAt reads len(p) bytes into p starting at offset off in the large object. It returns io.EOF if fewer than len(p)
+// bytes were read because the end of the large object was reached. ReadAt does not change the current location pointer.
+func (o *LargeObject) ReadAt(p []byte, off int64) (int, error) {
+	var n int
+	err := o.at(off, func() error {
+		var err error
+		n, err = io.ReadFull(readerFunc(o.read), p)
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return err
+	})
+	return n, err
+}
+
+// WriteAt writes p to the large object starting at offset off. WriteAt does not change the current location pointer.
+func (o *LargeObject) WriteAt(p []byte, off int64) (int, error) {
+	var n int
+	err := o.at(off, func() error {
+		var err error
+		n, err = o.write(p)
+		return err
+	})
+	return n, err
+}
+
+// at moves the current location pointer to off, calls fn, and then restores the current location pointer. No other
+// method can run on o until at returns.
+func (o *LargeObject) at(off int64, fn func() error) error {
+	if off < 0 {
+		return errors.New("negative offset")
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	pos, err := o.tell()
+	if err != nil {
+		return err
+	}
+
+	_, err = o.seek(off, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	fnErr := fn()
+
+	_, err = o.seek(pos, io.SeekStart)
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
 // Tell returns the current read or write location of the large object descriptor.
 func (o *LargeObject) Tell() (n int64, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.tell()
+}
+
+func (o *LargeObject) tell() (n int64, err error) {
 	err = o.tx.QueryRow(o.ctx, "select lo_tell64($1)", o.fd).Scan(&n)
 	return n, err
 }
 
 // Truncate the large object to size.
 func (o *LargeObject) Truncate(size int64) (err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	_, err = o.tx.Exec(o.ctx, "select lo_truncate64($1, $2)", o.fd, size)
 	return err
 }
 
 // Close the large object descriptor.
 func (o *LargeObject) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 	_, err := o.tx.Exec(o.ctx, "select lo_close($1)", o.fd)
 	return err
 }
+
+// readerFunc adapts a read function to io.Reader.
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
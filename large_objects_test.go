@@ -1,9 +1,11 @@
 package pgx_test
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -143,6 +145,70 @@ func testLargeObjects(t *testing.T, ctx context.Context, tx pgx.Tx) {
 		t.Errorf("Expected res[0] to be 't', got %v", res[0])
 	}
 
+	n, err = obj.WriteAt([]byte("esting"), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 6 {
+		t.Errorf("Expected n to be 6, got %d", n)
+	}
+
+	res = make([]byte, 4)
+	n, err = obj.ReadAt(res, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(res) != "stin" {
+		t.Errorf(`Expected res to be "stin", got %q`, res)
+	}
+
+	n, err = obj.ReadAt(res, 5)
+	if err != io.EOF {
+		t.Errorf("Expected err to be io.EOF, got %v", err)
+	}
+	if n != 2 || string(res[:n]) != "ng" {
+		t.Errorf(`Expected to read "ng", got %q`, res[:n])
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(off int64) {
+			defer wg.Done()
+			buf := make([]byte, 2)
+			_, err := obj.ReadAt(buf, off)
+			if err != nil {
+				t.Errorf("ReadAt(%d) failed: %v", off, err)
+				return
+			}
+			if want := "testing"[off : off+2]; string(buf) != want {
+				t.Errorf("Expected ReadAt(%d) to read %q, got %q", off, want, buf)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	pos, err = obj.Tell()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos != 1 {
+		t.Errorf("Expected pos to be 1, got %d", pos)
+	}
+
+	_, err = obj.Seek(0, io.SeekStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "testing" {
+		t.Errorf(`Expected io.Copy to copy "testing", got %q`, buf.String())
+	}
+
 	err = obj.Close()
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,125 @@
+package pgx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Listener listens for notifications on one or more channels. If the connection is lost it reconnects and listens on
+// the channels again. Notifications sent while the Listener is not connected are lost.
+type Listener struct {
+	// Connect establishes the connection used for listening. It is required.
+	Connect func(ctx context.Context) (*Conn, error)
+
+	// Channels are the names of the channels to listen on.
+	Channels []string
+
+	// MinReconnectDelay is how long to wait before the first reconnection attempt after an error. Each consecutive
+	// failure doubles the delay up to MaxReconnectDelay. The defaults are 1 second and 1 minute.
+	MinReconnectDelay time.Duration
+	MaxReconnectDelay time.Duration
+
+	// OnError is called with each error that causes a reconnection. It is optional.
+	OnError func(error)
+
+	notificationsOnce sync.Once
+	notifications     chan *pgconn.Notification
+}
+
+// NewListener returns a Listener that listens on channels using connections established by connect.
+func NewListener(connect func(ctx context.Context) (*Conn, error), channels ...string) *Listener {
+	return &Listener{
+		Connect:  connect,
+		Channels: channels,
+	}
+}
+
+// Notifications returns the channel notifications are delivered on. It is closed when Listen returns.
+func (l *Listener) Notifications() <-chan *pgconn.Notification {
+	return l.notificationsChan()
+}
+
+// notificationsChan returns l.notifications, creating it on first use so a Listener created without NewListener works.
+func (l *Listener) notificationsChan() chan *pgconn.Notification {
+	l.notificationsOnce.Do(func() {
+		l.notifications = make(chan *pgconn.Notification)
+	})
+	return l.notifications
+}
+
+// Listen connects, listens on l.Channels, and delivers notifications to l.Notifications until ctx is canceled. Errors
+// do not stop Listen. Instead, it waits and reconnects. Listen always returns ctx.Err(). It must only be called once.
+func (l *Listener) Listen(ctx context.Context) error {
+	notifications := l.notificationsChan()
+	defer close(notifications)
+
+	minDelay := l.MinReconnectDelay
+	if minDelay <= 0 {
+		minDelay = time.Second
+	}
+	maxDelay := l.MaxReconnectDelay
+	if maxDelay <= 0 {
+		maxDelay = time.Minute
+	}
+
+	delay := minDelay
+	for {
+		listening, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if l.OnError != nil {
+			l.OnError(err)
+		}
+
+		if listening {
+			delay = minDelay
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// listen runs a single connection until it fails. listening reports whether all LISTEN commands succeeded.
+func (l *Listener) listen(ctx context.Context) (listening bool, err error) {
+	conn, err := l.Connect(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(ctx)
+
+	for _, channel := range l.Channels {
+		_, err := conn.Exec(ctx, "listen "+Identifier{channel}.Sanitize())
+		if err != nil {
+			return false, err
+		}
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		select {
+		case l.notificationsChan() <- notification:
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+	}
+}
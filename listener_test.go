@@ -0,0 +1,138 @@
+package pgx_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListener(t *testing.T) {
+	t.Parallel()
+
+	func() {
+		conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+		defer closeConn(t, conn)
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support LISTEN / NOTIFY (https://github.com/cockroachdb/cockroach/issues/41522)")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	connected := make(chan *pgx.Conn, 2)
+	listener := pgx.NewListener(func(ctx context.Context) (*pgx.Conn, error) {
+		conn, err := pgx.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+		if err == nil {
+			connected <- conn
+		}
+		return conn, err
+	}, "listener_a", "listener_b")
+	listener.MinReconnectDelay = 10 * time.Millisecond
+
+	listenErr := make(chan error)
+	go func() {
+		listenErr <- listener.Listen(ctx)
+	}()
+
+	notifier := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, notifier)
+
+	// Wait until the listener is connected and listening. The notification may be sent more than once before it is
+	// received so notifications left over from earlier calls are skipped.
+	waitForNotification := func(channel string) {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			mustExec(t, notifier, "select pg_notify($1, 'payload')", channel)
+			select {
+			case n := <-listener.Notifications():
+				if n.Channel == channel {
+					require.Equal(t, "payload", n.Payload)
+					return
+				}
+			case <-ticker.C:
+			case <-ctx.Done():
+				t.Fatal(ctx.Err())
+			}
+		}
+	}
+
+	waitForNotification("listener_a")
+	waitForNotification("listener_b")
+
+	// Kill the listening connection and ensure the listener reconnects and listens again.
+	conn := <-connected
+	mustExec(t, notifier, "select pg_terminate_backend($1)", conn.PgConn().PID())
+	<-connected
+
+	waitForNotification("listener_a")
+
+	cancel()
+	for range listener.Notifications() {
+	}
+	require.ErrorIs(t, <-listenErr, context.Canceled)
+}
+
+func TestListenerReconnectsAfterConnectError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	connectErr := errors.New("connect failed")
+	attempts := 0
+	listener := pgx.NewListener(func(ctx context.Context) (*pgx.Conn, error) {
+		attempts++
+		return nil, connectErr
+	}, "foo")
+	listener.MinReconnectDelay = time.Millisecond
+	listener.MaxReconnectDelay = 2 * time.Millisecond
+
+	var errs []error
+	listener.OnError = func(err error) {
+		errs = append(errs, err)
+		if len(errs) == 3 {
+			cancel()
+		}
+	}
+
+	err := listener.Listen(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, []error{connectErr, connectErr, connectErr}, errs)
+
+	_, ok := <-listener.Notifications()
+	require.False(t, ok)
+}
+
+func TestListenerZeroValue(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	connectErr := errors.New("connect failed")
+	listener := &pgx.Listener{
+		Connect: func(ctx context.Context) (*pgx.Conn, error) {
+			return nil, connectErr
+		},
+		MinReconnectDelay: time.Millisecond,
+		OnError: func(err error) {
+			cancel()
+		},
+	}
+
+	notifications := listener.Notifications()
+	require.NotNil(t, notifications)
+
+	err := listener.Listen(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, ok := <-notifications
+	require.False(t, ok)
+}
@@ -14,6 +14,11 @@ import (
 //
 //	conn.Query(ctx, "select * from widgets where foo = @foo and bar = @bar", pgx.NamedArgs{"foo": 1, "bar": 2})
 //	conn.Query(ctx, "select * from widgets where foo = $1 and bar = $2", 1, 2)
+//
+// A name used more than once is bound to a single argument. Placeholders are not recognized inside quoted strings,
+// quoted identifiers, dollar-quoted strings, or comments. "@@" followed by a name is an escape for a literal "@" and a
+// name, e.g. "@@foo" is sent as "@foo". Any other "@@", such as the text search operator in "a @@ b", is sent
+// unchanged.
 type NamedArgs map[string]any
 
 // RewriteQuery implements the QueryRewriter interface.
@@ -57,6 +62,8 @@ type sqlLexer struct {
 	stateFn stateFn
 	parts   []any
 
+	dollarTag string // delimiter of the current dollar-quoted string, e.g. "$body$".
+
 	nameToOrdinal map[namedArg]int
 }
 
@@ -78,9 +85,23 @@ func rawState(l *sqlLexer) stateFn {
 			return singleQuoteState
 		case '"':
 			return doubleQuoteState
+		case '$':
+			if tag, ok := l.dollarQuoteTag(width); ok {
+				l.dollarTag = tag
+				l.pos += len(tag) - width
+				return dollarQuoteState
+			}
 		case '@':
-			nextRune, _ := utf8.DecodeRuneInString(l.src[l.pos:])
-			if isLetter(nextRune) {
+			nextRune, nextWidth := utf8.DecodeRuneInString(l.src[l.pos:])
+			if nextRune == '@' {
+				if afterRune, _ := utf8.DecodeRuneInString(l.src[l.pos+nextWidth:]); isLetter(afterRune) {
+					// "@@" followed by a name is an escaped "@". Keep the first and drop the second.
+					l.parts = append(l.parts, l.src[l.start:l.pos])
+					l.start = l.pos + nextWidth
+				}
+				// Otherwise "@@" is an operator such as the text search operator and is kept as is.
+				l.pos += nextWidth
+			} else if isLetter(nextRune) {
 				if l.pos-l.start > 0 {
 					l.parts = append(l.parts, l.src[l.start:l.pos-width])
 				}
@@ -109,6 +130,29 @@ func rawState(l *sqlLexer) stateFn {
 	}
 }
 
+// dollarQuoteTag returns the delimiter of a dollar-quoted string such as "$$" or "$tag$" if one starts at the '$' just
+// read by rawState. width is the width of that '$'.
+func (l *sqlLexer) dollarQuoteTag(width int) (string, bool) {
+	start := l.pos - width
+
+	// A '$' inside an identifier or after a digit does not start a dollar-quoted string. e.g. foo$1$ or $1.
+	if prevRune, _ := utf8.DecodeLastRuneInString(l.src[:start]); isLetter(prevRune) || (prevRune >= '0' && prevRune <= '9') || prevRune == '_' || prevRune == '$' {
+		return "", false
+	}
+
+	for i, r := range l.src[l.pos:] {
+		switch {
+		case r == '$':
+			return l.src[start : l.pos+i+1], true
+		case isLetter(r) || r == '_' || (i > 0 && r >= '0' && r <= '9'):
+		default:
+			return "", false
+		}
+	}
+
+	return "", false
+}
+
 func isLetter(r rune) bool {
 	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
 }
@@ -185,6 +229,20 @@ func doubleQuoteState(l *sqlLexer) stateFn {
 	}
 }
 
+func dollarQuoteState(l *sqlLexer) stateFn {
+	if i := strings.Index(l.src[l.pos:], l.dollarTag); i >= 0 {
+		l.pos += i + len(l.dollarTag)
+		return rawState
+	}
+
+	l.pos = len(l.src)
+	if l.pos-l.start > 0 {
+		l.parts = append(l.parts, l.src[l.start:l.pos])
+		l.start = l.pos
+	}
+	return nil
+}
+
 func escapeStringState(l *sqlLexer) stateFn {
 	for {
 		r, width := utf8.DecodeRuneInString(l.src[l.pos:])
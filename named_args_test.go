@@ -93,6 +93,42 @@ func TestNamedArgsRewriteQuery(t *testing.T) {
 			where id = $1;`,
 			expectedArgs: []any{int32(42)},
 		},
+		{
+			sql:          "select @a, @b, @a, @a::text from t where x = @b",
+			namedArgs:    pgx.NamedArgs{"a": int32(1), "b": int32(2)},
+			expectedSQL:  "select $1, $2, $1, $1::text from t where x = $2",
+			expectedArgs: []any{int32(1), int32(2)},
+		},
+		{
+			sql:          "select '@@' as literal, @id, 'user@@example.com', 'a@@b' @@ to_tsquery(@q), v @@(@q)::tsquery",
+			namedArgs:    pgx.NamedArgs{"id": int32(42), "q": "x"},
+			expectedSQL:  "select '@@' as literal, $1, 'user@@example.com', 'a@@b' @@ to_tsquery($2), v @@($2)::tsquery",
+			expectedArgs: []any{int32(42), "x"},
+		},
+		{
+			sql:          "select @@foo, @foo, @@, @@@foo",
+			namedArgs:    pgx.NamedArgs{"foo": int32(42)},
+			expectedSQL:  "select @foo, $1, @@, @@$1",
+			expectedArgs: []any{int32(42)},
+		},
+		{
+			sql:          "select $$ @foo $$, $tag$ it's @bar $$ $tag$, @id, $1",
+			namedArgs:    pgx.NamedArgs{"id": int32(42)},
+			expectedSQL:  "select $$ @foo $$, $tag$ it's @bar $$ $tag$, $1, $1",
+			expectedArgs: []any{int32(42)},
+		},
+		{
+			sql:          "select foo$bar$, @id",
+			namedArgs:    pgx.NamedArgs{"id": int32(42)},
+			expectedSQL:  "select foo$bar$, $1",
+			expectedArgs: []any{int32(42)},
+		},
+		{
+			sql:          "select $$ unterminated @foo",
+			namedArgs:    pgx.NamedArgs{"foo": int32(42)},
+			expectedSQL:  "select $$ unterminated @foo",
+			expectedArgs: []any{},
+		},
 
 		// test comments and quotes
 	} {
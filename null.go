@@ -0,0 +1,89 @@
+package pgx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// nullScanMap is used by Null.Scan for values that must be converted. It is shared because building a pgtype.Map for
+// every Scan is expensive, and guarded by nullScanMapMux because a pgtype.Map is not safe for concurrent use.
+var (
+	nullScanMapMux sync.Mutex
+	nullScanMap    = pgtype.NewMap()
+)
+
+// Null represents a value of type T that may be NULL. It is similar to sql.Null but non-NULL values are scanned and
+// encoded with the type map of the connection, so any type that can be scanned into or encoded from a T can be used.
+// e.g. A numeric column can be scanned into a Null[float64] and a uuid column into a Null[[16]byte].
+//
+// As with sql.Null the value is in the field V because the Value method implements driver.Valuer.
+type Null[T any] struct {
+	V     T
+	Valid bool // Valid is true if V is not NULL
+}
+
+// NullableScanTarget implements the pgtype.NullableScanner interface.
+func (n *Null[T]) NullableScanTarget() any {
+	return &n.V
+}
+
+// SetNullableValid implements the pgtype.NullableScanner interface.
+func (n *Null[T]) SetNullableValid(valid bool) {
+	if !valid {
+		var zero T
+		n.V = zero
+	}
+	n.Valid = valid
+}
+
+// NullableValue implements the pgtype.NullableValuer interface.
+func (n Null[T]) NullableValue() (any, bool) {
+	return n.V, n.Valid
+}
+
+// Scan implements the database/sql Scanner interface. src is assigned directly if it is a T. Otherwise, it is scanned
+// with the type registered for T by pgtype.Map.RegisterDefaultPgType.
+func (n *Null[T]) Scan(src any) error {
+	if src == nil {
+		*n = Null[T]{}
+		return nil
+	}
+
+	if v, ok := src.(T); ok {
+		*n = Null[T]{V: v, Valid: true}
+		return nil
+	}
+
+	var v T
+	var err error
+	if scanner, ok := any(&v).(sql.Scanner); ok {
+		err = scanner.Scan(src)
+	} else {
+		nullScanMapMux.Lock()
+		err = nullScanMap.SQLScanner(&v).Scan(src)
+		nullScanMapMux.Unlock()
+	}
+	if err != nil {
+		return fmt.Errorf("cannot scan %T into Null[%T]: %w", src, v, err)
+	}
+
+	*n = Null[T]{V: v, Valid: true}
+	return nil
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	if valuer, ok := any(n.V).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+
+	return n.V, nil
+}
@@ -0,0 +1,135 @@
+package pgx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNullScan(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var n pgx.Null[int32]
+		err := conn.QueryRow(ctx, "select 42::int4").Scan(&n)
+		require.NoError(t, err)
+		assert.Equal(t, pgx.Null[int32]{V: 42, Valid: true}, n)
+
+		err = conn.QueryRow(ctx, "select null::int4").Scan(&n)
+		require.NoError(t, err)
+		assert.Equal(t, pgx.Null[int32]{}, n)
+
+		var f pgx.Null[float64]
+		err = conn.QueryRow(ctx, "select 1.5::numeric").Scan(&f)
+		require.NoError(t, err)
+		assert.Equal(t, pgx.Null[float64]{V: 1.5, Valid: true}, f)
+
+		var u pgx.Null[[16]byte]
+		err = conn.QueryRow(ctx, "select '00010203-0405-0607-0809-0a0b0c0d0e0f'::uuid").Scan(&u)
+		require.NoError(t, err)
+		assert.Equal(t, pgx.Null[[16]byte]{V: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}, u)
+
+		var j pgx.Null[map[string]any]
+		err = conn.QueryRow(ctx, `select '{"a": 1}'::json`).Scan(&j)
+		require.NoError(t, err)
+		assert.Equal(t, pgx.Null[map[string]any]{V: map[string]any{"a": float64(1)}, Valid: true}, j)
+
+		err = conn.QueryRow(ctx, "select null::json").Scan(&j)
+		require.NoError(t, err)
+		assert.False(t, j.Valid)
+	})
+}
+
+func TestNullEncode(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var s string
+		err := conn.QueryRow(ctx, "select $1::int8::text", pgx.Null[int64]{V: 7, Valid: true}).Scan(&s)
+		require.NoError(t, err)
+		assert.Equal(t, "7", s)
+
+		var isNull bool
+		err = conn.QueryRow(ctx, "select $1::int8 is null", pgx.Null[int64]{V: 7}).Scan(&isNull)
+		require.NoError(t, err)
+		assert.True(t, isNull)
+
+		var tm pgx.Null[time.Time]
+		want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		err = conn.QueryRow(ctx, "select $1::timestamptz", pgx.Null[time.Time]{V: want, Valid: true}).Scan(&tm)
+		require.NoError(t, err)
+		assert.True(t, tm.Valid)
+		assert.True(t, want.Equal(tm.V))
+	})
+}
+
+func TestNullWithTypeMap(t *testing.T) {
+	t.Parallel()
+
+	m := pgtype.NewMap()
+
+	n := pgx.Null[int32]{V: 1, Valid: true}
+	err := m.Scan(pgtype.Int4OID, pgx.TextFormatCode, []byte("123"), &n)
+	require.NoError(t, err)
+	assert.Equal(t, pgx.Null[int32]{V: 123, Valid: true}, n)
+
+	err = m.Scan(pgtype.Int4OID, pgx.TextFormatCode, nil, &n)
+	require.NoError(t, err)
+	assert.Equal(t, pgx.Null[int32]{}, n)
+
+	var j pgx.Null[map[string]any]
+	err = m.Scan(pgtype.JSONBOID, pgx.TextFormatCode, []byte(`{"a": "b"}`), &j)
+	require.NoError(t, err)
+	assert.Equal(t, pgx.Null[map[string]any]{V: map[string]any{"a": "b"}, Valid: true}, j)
+
+	buf, err := m.Encode(pgtype.Int4OID, pgx.BinaryFormatCode, pgx.Null[int32]{V: 1, Valid: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 1}, buf)
+
+	buf, err = m.Encode(pgtype.Int4OID, pgx.BinaryFormatCode, pgx.Null[int32]{V: 1}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+
+	buf, err = m.Encode(pgtype.JSONOID, pgx.TextFormatCode, pgx.Null[[]int]{V: []int{1, 2}, Valid: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("[1,2]"), buf)
+}
+
+func TestNullDatabaseSQL(t *testing.T) {
+	t.Parallel()
+
+	var n pgx.Null[int32]
+	require.NoError(t, n.Scan(int64(12)))
+	assert.Equal(t, pgx.Null[int32]{V: 12, Valid: true}, n)
+
+	require.NoError(t, n.Scan(nil))
+	assert.Equal(t, pgx.Null[int32]{}, n)
+
+	var s pgx.Null[string]
+	require.NoError(t, s.Scan("foo"))
+	assert.Equal(t, pgx.Null[string]{V: "foo", Valid: true}, s)
+
+	v, err := s.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "foo", v)
+
+	v, err = pgx.Null[pgtype.Int4]{V: pgtype.Int4{Int32: 3, Valid: true}, Valid: true}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), v)
+
+	v, err = pgx.Null[string]{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
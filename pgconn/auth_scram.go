@@ -2,6 +2,7 @@
 //
 // Resources:
 //   https://tools.ietf.org/html/rfc5802
+//   https://tools.ietf.org/html/rfc5929
 //   https://tools.ietf.org/html/rfc8265
 //   https://www.postgresql.org/docs/current/sasl-authentication.html
 //
@@ -17,9 +18,13 @@ import (
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"hash"
 	"strconv"
 
 	"github.com/jackc/pgx/v5/pgproto3"
@@ -29,32 +34,39 @@ import (
 
 const clientNonceLen = 18
 
-// Perform SCRAM authentication.
-func (c *PgConn) scramAuth(serverAuthMechanisms []string) error {
-	sc, err := newScramClient(serverAuthMechanisms, c.config.Password)
+// Perform SCRAM authentication. channelBound reports whether SCRAM-SHA-256-PLUS channel binding was used.
+func (c *PgConn) scramAuth(serverAuthMechanisms []string) (channelBound bool, err error) {
+	var channelBindingData []byte
+	if c.config.ChannelBinding != "disable" {
+		if tlsConn, ok := c.conn.(*tls.Conn); ok {
+			channelBindingData = tlsServerEndPoint(tlsConn.ConnectionState())
+		}
+	}
+
+	sc, err := newScramClient(serverAuthMechanisms, c.config.Password, channelBindingData, c.config.ChannelBinding == "require")
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Send client-first-message in a SASLInitialResponse
 	saslInitialResponse := &pgproto3.SASLInitialResponse{
-		AuthMechanism: "SCRAM-SHA-256",
+		AuthMechanism: sc.authMechanism,
 		Data:          sc.clientFirstMessage(),
 	}
 	c.frontend.Send(saslInitialResponse)
 	err = c.flushWithPotentialWriteReadDeadlock()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Receive server-first-message payload in an AuthenticationSASLContinue.
 	saslContinue, err := c.rxSASLContinue()
 	if err != nil {
-		return err
+		return false, err
 	}
 	err = sc.recvServerFirstMessage(saslContinue.Data)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Send client-final-message in a SASLResponse
@@ -64,15 +76,20 @@ func (c *PgConn) scramAuth(serverAuthMechanisms []string) error {
 	c.frontend.Send(saslResponse)
 	err = c.flushWithPotentialWriteReadDeadlock()
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Receive server-final-message payload in an AuthenticationSASLFinal.
 	saslFinal, err := c.rxSASLFinal()
 	if err != nil {
-		return err
+		return false, err
 	}
-	return sc.recvServerFinalMessage(saslFinal.Data)
+	err = sc.recvServerFinalMessage(saslFinal.Data)
+	if err != nil {
+		return false, err
+	}
+
+	return sc.channelBindingData != nil, nil
 }
 
 func (c *PgConn) rxSASLContinue() (*pgproto3.AuthenticationSASLContinue, error) {
@@ -110,6 +127,10 @@ type scramClient struct {
 	password             []byte
 	clientNonce          []byte
 
+	authMechanism      string
+	gs2Header          []byte
+	channelBindingData []byte
+
 	clientFirstMessageBare []byte
 
 	serverFirstMessage   []byte
@@ -121,21 +142,44 @@ type scramClient struct {
 	authMessage    []byte
 }
 
-func newScramClient(serverAuthMechanisms []string, password string) (*scramClient, error) {
+// newScramClient returns a client for serverAuthMechanisms. channelBindingData is the tls-server-end-point channel
+// binding data of the connection or nil if channel binding is not possible. If channelBindingData is not nil and the
+// server supports SCRAM-SHA-256-PLUS then channel binding is used. If requireChannelBinding is true and channel binding
+// cannot be used then an error is returned.
+func newScramClient(serverAuthMechanisms []string, password string, channelBindingData []byte, requireChannelBinding bool) (*scramClient, error) {
 	sc := &scramClient{
 		serverAuthMechanisms: serverAuthMechanisms,
 	}
 
-	// Ensure server supports SCRAM-SHA-256
 	hasScramSHA256 := false
+	hasScramSHA256Plus := false
 	for _, mech := range sc.serverAuthMechanisms {
-		if mech == "SCRAM-SHA-256" {
+		switch mech {
+		case "SCRAM-SHA-256":
 			hasScramSHA256 = true
-			break
+		case "SCRAM-SHA-256-PLUS":
+			hasScramSHA256Plus = true
 		}
 	}
-	if !hasScramSHA256 {
+
+	switch {
+	case channelBindingData != nil && hasScramSHA256Plus:
+		sc.authMechanism = "SCRAM-SHA-256-PLUS"
+		sc.gs2Header = []byte("p=tls-server-end-point,,")
+		sc.channelBindingData = channelBindingData
+	case requireChannelBinding && channelBindingData == nil:
+		return nil, errors.New("channel binding required but not available on this connection")
+	case requireChannelBinding:
+		return nil, errors.New("channel binding required but server does not support SCRAM-SHA-256-PLUS")
+	case !hasScramSHA256:
 		return nil, errors.New("server does not support SCRAM-SHA-256")
+	case channelBindingData != nil:
+		// The client supports channel binding but the server does not.
+		sc.authMechanism = "SCRAM-SHA-256"
+		sc.gs2Header = []byte("y,,")
+	default:
+		sc.authMechanism = "SCRAM-SHA-256"
+		sc.gs2Header = []byte("n,,")
 	}
 
 	// precis.OpaqueString is equivalent to SASLprep for password.
@@ -159,7 +203,7 @@ func newScramClient(serverAuthMechanisms []string, password string) (*scramClien
 
 func (sc *scramClient) clientFirstMessage() []byte {
 	sc.clientFirstMessageBare = []byte(fmt.Sprintf("n=,r=%s", sc.clientNonce))
-	return []byte(fmt.Sprintf("n,,%s", sc.clientFirstMessageBare))
+	return []byte(fmt.Sprintf("%s%s", sc.gs2Header, sc.clientFirstMessageBare))
 }
 
 func (sc *scramClient) recvServerFirstMessage(serverFirstMessage []byte) error {
@@ -218,7 +262,8 @@ func (sc *scramClient) recvServerFirstMessage(serverFirstMessage []byte) error {
 }
 
 func (sc *scramClient) clientFinalMessage() string {
-	clientFinalMessageWithoutProof := []byte(fmt.Sprintf("c=biws,r=%s", sc.clientAndServerNonce))
+	channelBinding := base64.StdEncoding.EncodeToString(append(append([]byte{}, sc.gs2Header...), sc.channelBindingData...))
+	clientFinalMessageWithoutProof := []byte(fmt.Sprintf("c=%s,r=%s", channelBinding, sc.clientAndServerNonce))
 
 	sc.saltedPassword = pbkdf2.Key([]byte(sc.password), sc.salt, sc.iterations, 32, sha256.New)
 	sc.authMessage = bytes.Join([][]byte{sc.clientFirstMessageBare, sc.serverFirstMessage, clientFinalMessageWithoutProof}, []byte(","))
@@ -270,3 +315,30 @@ func computeServerSignature(saltedPassword []byte, authMessage []byte) []byte {
 	base64.StdEncoding.Encode(buf, serverSignature)
 	return buf
 }
+
+// tlsServerEndPoint returns the tls-server-end-point channel binding data for the server certificate of a TLS
+// connection. It is the hash of the certificate using the hash function of the certificate's signature algorithm, or
+// SHA-256 if that is MD5 or SHA-1. It returns nil if the binding data cannot be computed such as for certificates
+// signed with Ed25519.
+func tlsServerEndPoint(connState tls.ConnectionState) []byte {
+	if len(connState.PeerCertificates) == 0 {
+		return nil
+	}
+	cert := connState.PeerCertificates[0]
+
+	var h hash.Hash
+	switch cert.SignatureAlgorithm {
+	case x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1,
+		x509.SHA256WithRSA, x509.SHA256WithRSAPSS, x509.DSAWithSHA256, x509.ECDSAWithSHA256:
+		h = sha256.New()
+	case x509.SHA384WithRSA, x509.SHA384WithRSAPSS, x509.ECDSAWithSHA384:
+		h = sha512.New384()
+	case x509.SHA512WithRSA, x509.SHA512WithRSAPSS, x509.ECDSAWithSHA512:
+		h = sha512.New()
+	default:
+		return nil
+	}
+
+	h.Write(cert.Raw)
+	return h.Sum(nil)
+}
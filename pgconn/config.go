@@ -34,10 +34,10 @@ type Config struct {
 	Database       string
 	User           string
 	Password       string
-	TLSConfig      *tls.Config // nil disables TLS
-	ConnectTimeout time.Duration
-	DialFunc       DialFunc   // e.g. net.Dialer.DialContext
-	LookupFunc     LookupFunc // e.g. net.Resolver.LookupHost
+	TLSConfig      *tls.Config   // nil disables TLS
+	ConnectTimeout time.Duration // limit on each connection attempt, see ConnectConfig; 0 means no limit
+	DialFunc       DialFunc      // e.g. net.Dialer.DialContext
+	LookupFunc     LookupFunc    // e.g. net.Resolver.LookupHost
 	BuildFrontend  BuildFrontendFunc
 	RuntimeParams  map[string]string // Run-time parameters to set on connection as session default values (e.g. search_path or application_name)
 
@@ -45,6 +45,17 @@ type Config struct {
 	KerberosSpn     string
 	Fallbacks       []*FallbackConfig
 
+	// ChannelBinding controls the use of SCRAM-SHA-256-PLUS channel binding over TLS. "prefer" (the default) uses
+	// channel binding when the server supports it. "require" fails the connection unless it authenticates with channel
+	// binding. "disable" never uses channel binding. An empty string is the same as "prefer".
+	ChannelBinding string
+
+	// GSSEncMode controls the use of GSSAPI transport encryption. "prefer" (the default) requests GSSAPI encryption
+	// before TLS when the registered GSS provider implements GSSEncrypter and falls back to the unencrypted or TLS path
+	// when it is unavailable or refused by the server. "require" fails the connection unless GSSAPI encryption is
+	// established. "disable" never requests it. TLS is not negotiated on a GSSAPI encrypted connection.
+	GSSEncMode string
+
 	// ValidateConnect is called during a connection attempt after a successful authentication with the PostgreSQL server.
 	// It can be used to validate that the server is acceptable. If this returns an error the connection is closed and the next
 	// fallback config is tried. This allows implementing high availability behavior such as libpq does with target_session_attrs.
@@ -60,6 +71,21 @@ type Config struct {
 	// OnNotification is a callback function called when a notification from the LISTEN/NOTIFY system is received.
 	OnNotification NotificationHandler
 
+	// OnParameterStatus is a callback function called when the server reports the value of a run-time parameter during
+	// connection startup or after the parameter changes.
+	OnParameterStatus ParameterStatusHandler
+
+	// CancelRequestOnContextCancel changes how canceling the context of an in-progress operation interrupts it. By
+	// default, the network connection is interrupted immediately and the connection is closed. If
+	// CancelRequestOnContextCancel is true then a cancel request is sent to the server instead. If the server aborts the
+	// operation the connection remains usable. If the operation has not been interrupted CancelRequestDeadlineDelay after
+	// the context was canceled then the network connection is interrupted as usual. CancelRequestDeadlineDelay defaults
+	// to 1 second.
+	//
+	// As with PgConn.CancelRequest, there is no guarantee the server acts on the cancel request.
+	CancelRequestOnContextCancel bool
+	CancelRequestDeadlineDelay   time.Duration
+
 	createdByParseConfig bool // Used to enforce created by ParseConfig rule.
 }
 
@@ -182,6 +208,8 @@ func NetworkAddress(host string, port uint16) (network, address string) {
 //	PGAPPNAME
 //	PGCONNECT_TIMEOUT
 //	PGTARGETSESSIONATTRS
+//	PGCHANNELBINDING
+//	PGGSSENCMODE
 //
 // See http://www.postgresql.org/docs/11/static/libpq-envars.html for details on the meaning of environment variables.
 //
@@ -294,6 +322,8 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		"krbspn":               {},
 		"krbsrvname":           {},
 		"target_session_attrs": {},
+		"channel_binding":      {},
+		"gssencmode":           {},
 		"service":              {},
 		"servicefile":          {},
 	}
@@ -387,6 +417,24 @@ func ParseConfigWithOptions(connString string, options ParseConfigOptions) (*Con
 		return nil, &parseConfigError{connString: connString, msg: fmt.Sprintf("unknown target_session_attrs value: %v", tsa)}
 	}
 
+	switch cb := settings["channel_binding"]; cb {
+	case "", "prefer":
+		config.ChannelBinding = "prefer"
+	case "require", "disable":
+		config.ChannelBinding = cb
+	default:
+		return nil, &parseConfigError{connString: connString, msg: fmt.Sprintf("unknown channel_binding value: %v", cb)}
+	}
+
+	switch mode := settings["gssencmode"]; mode {
+	case "", "prefer":
+		config.GSSEncMode = "prefer"
+	case "require", "disable":
+		config.GSSEncMode = mode
+	default:
+		return nil, &parseConfigError{connString: connString, msg: fmt.Sprintf("unknown gssencmode value: %v", mode)}
+	}
+
 	return config, nil
 }
 
@@ -421,6 +469,8 @@ func parseEnvSettings() map[string]string {
 		"PGSSLROOTCERT":        "sslrootcert",
 		"PGSSLPASSWORD":        "sslpassword",
 		"PGTARGETSESSIONATTRS": "target_session_attrs",
+		"PGCHANNELBINDING":     "channel_binding",
+		"PGGSSENCMODE":         "gssencmode",
 		"PGSERVICE":            "service",
 		"PGSERVICEFILE":        "servicefile",
 	}
@@ -0,0 +1,270 @@
+package pgconn
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+// GSSEncrypter is implemented by GSS providers that support GSSAPI transport encryption (gssencmode). Wrap and Unwrap
+// use the security context established by GetInitToken or GetInitTokenFromSPN and Continue.
+type GSSEncrypter interface {
+	GSS
+
+	// Wrap encrypts and signs msg for sending to the server.
+	Wrap(msg []byte) ([]byte, error)
+
+	// Unwrap verifies and decrypts a token received from the server.
+	Unwrap(token []byte) ([]byte, error)
+}
+
+const (
+	// gssEncMaxPacketSize is the maximum size of a GSSAPI encrypted packet including its 4 byte length prefix. It
+	// matches the buffer size used by the PostgreSQL server.
+	gssEncMaxPacketSize = 16384
+
+	// gssEncMaxWriteSize is the most plaintext wrapped into a single packet. It leaves room for the overhead added by
+	// Wrap.
+	gssEncMaxWriteSize = gssEncMaxPacketSize - 4 - 1024
+)
+
+var (
+	// errGSSEncUnavailable is returned by startGSSEnc when GSSAPI encryption cannot be attempted by the client.
+	errGSSEncUnavailable = errors.New("GSSAPI encryption unavailable")
+
+	// errGSSEncRejected is returned by startGSSEnc when the server declines GSSAPI encryption. The connection can still
+	// be used without it.
+	errGSSEncRejected = errors.New("server refused GSSAPI encryption")
+
+	// errGSSEncUnsupported is returned by startGSSEnc when the server does not understand the GSSAPI encryption request.
+	// The server closes the connection in this case.
+	errGSSEncUnsupported = errors.New("server does not support GSSAPI encryption")
+)
+
+// gssEncHandshakeError is returned by startGSSEnc when the security context handshake fails after the server accepted
+// GSSAPI encryption. The connection cannot be used any further.
+type gssEncHandshakeError struct {
+	err error
+}
+
+func (e *gssEncHandshakeError) Error() string {
+	return "GSSAPI encryption handshake failed: " + e.err.Error()
+}
+
+func (e *gssEncHandshakeError) Unwrap() error {
+	return e.err
+}
+
+func isGSSEncHandshakeError(err error) bool {
+	var handshakeErr *gssEncHandshakeError
+	return errors.As(err, &handshakeErr)
+}
+
+// startGSSEnc requests GSSAPI encryption on conn and performs the security context handshake. On success it returns a
+// net.Conn that encrypts all further traffic.
+func startGSSEnc(conn net.Conn, config *Config, network string) (net.Conn, error) {
+	if network == "unix" {
+		return nil, fmt.Errorf("%w: not supported over unix domain sockets", errGSSEncUnavailable)
+	}
+	if newGSS == nil {
+		return nil, fmt.Errorf("%w: no GSSAPI provider registered", errGSSEncUnavailable)
+	}
+	cli, err := newGSS()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errGSSEncUnavailable, err)
+	}
+	enc, ok := cli.(GSSEncrypter)
+	if !ok {
+		return nil, fmt.Errorf("%w: GSSAPI provider does not implement GSSEncrypter", errGSSEncUnavailable)
+	}
+
+	// Get the first token before asking the server so missing credentials do not waste the connection.
+	token, err := gssInitToken(cli, config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errGSSEncUnavailable, err)
+	}
+
+	err = binary.Write(conn, binary.BigEndian, []int32{8, 80877104})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]byte, 1)
+	if _, err = io.ReadFull(conn, response); err != nil {
+		return nil, err
+	}
+
+	switch response[0] {
+	case 'G':
+	case 'N':
+		return nil, errGSSEncRejected
+	case 'E':
+		return nil, errGSSEncUnsupported
+	default:
+		return nil, fmt.Errorf("unexpected response to GSSAPI encryption request: %q", response[0])
+	}
+
+	for {
+		if len(token) > 0 {
+			if err := writeGSSEncPacket(conn, token); err != nil {
+				return nil, &gssEncHandshakeError{err: err}
+			}
+		}
+
+		in, err := readGSSEncHandshakePacket(conn)
+		if err != nil {
+			return nil, &gssEncHandshakeError{err: err}
+		}
+
+		var done bool
+		done, token, err = enc.Continue(in)
+		if err != nil {
+			return nil, &gssEncHandshakeError{err: err}
+		}
+		if done {
+			if len(token) > 0 {
+				if err := writeGSSEncPacket(conn, token); err != nil {
+					return nil, &gssEncHandshakeError{err: err}
+				}
+			}
+			break
+		}
+	}
+
+	return &gssEncConn{Conn: conn, gss: enc}, nil
+}
+
+func writeGSSEncPacket(w io.Writer, token []byte) error {
+	if len(token)+4 > gssEncMaxPacketSize {
+		return fmt.Errorf("GSSAPI token too large: %d bytes", len(token))
+	}
+
+	buf := make([]byte, 4, 4+len(token))
+	binary.BigEndian.PutUint32(buf, uint32(len(token)))
+	buf = append(buf, token...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// readGSSEncHandshakePacket reads a length prefixed token sent by the server during the GSSAPI encryption handshake.
+func readGSSEncHandshakePacket(r io.Reader) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	// The server reports handshake failures as an 'E' followed by a null terminated message and then closes the
+	// connection.
+	if header[0] == 'E' {
+		rest, _ := io.ReadAll(io.LimitReader(r, gssEncMaxPacketSize))
+		msg := append(header[1:], rest...)
+		if i := bytes.IndexByte(msg, 0); i >= 0 {
+			msg = msg[:i]
+		}
+		return nil, fmt.Errorf("server error during GSSAPI encryption handshake: %s", msg)
+	}
+
+	size := binary.BigEndian.Uint32(header)
+	if size > gssEncMaxPacketSize-4 {
+		return nil, fmt.Errorf("GSSAPI token too large: %d bytes", size)
+	}
+
+	token := make([]byte, size)
+	if _, err := io.ReadFull(r, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// gssEncConn is a net.Conn that sends and receives GSSAPI encrypted packets over the underlying net.Conn. Partially
+// read packets are kept between calls to Read so a read interrupted by a deadline can be resumed.
+type gssEncConn struct {
+	net.Conn
+	gss GSSEncrypter
+
+	raw   []byte // partially read encrypted packet including the length prefix
+	plain []byte // decrypted data not yet returned by Read
+}
+
+func (c *gssEncConn) Read(b []byte) (int, error) {
+	for len(c.plain) == 0 {
+		if err := c.readPacket(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(b, c.plain)
+	c.plain = c.plain[n:]
+	return n, nil
+}
+
+func (c *gssEncConn) readPacket() error {
+	if err := c.fill(4); err != nil {
+		return err
+	}
+
+	size := int(binary.BigEndian.Uint32(c.raw[:4]))
+	if size > gssEncMaxPacketSize-4 {
+		return fmt.Errorf("GSSAPI encrypted packet too large: %d bytes", size)
+	}
+
+	if err := c.fill(4 + size); err != nil {
+		return err
+	}
+
+	plain, err := c.gss.Unwrap(c.raw[4:])
+	if err != nil {
+		return err
+	}
+
+	c.raw = c.raw[:0]
+	c.plain = plain
+	return nil
+}
+
+// fill reads from the underlying net.Conn until c.raw holds n bytes.
+func (c *gssEncConn) fill(n int) error {
+	if cap(c.raw) < n {
+		raw := make([]byte, len(c.raw), n)
+		copy(raw, c.raw)
+		c.raw = raw
+	}
+
+	for len(c.raw) < n {
+		m, err := c.Conn.Read(c.raw[len(c.raw):n])
+		c.raw = c.raw[:len(c.raw)+m]
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *gssEncConn) Write(b []byte) (int, error) {
+	var n int
+	for len(b) > 0 {
+		chunk := b
+		if len(chunk) > gssEncMaxWriteSize {
+			chunk = chunk[:gssEncMaxWriteSize]
+		}
+
+		token, err := c.gss.Wrap(chunk)
+		if err != nil {
+			return n, err
+		}
+
+		if err := writeGSSEncPacket(c.Conn, token); err != nil {
+			return n, err
+		}
+
+		n += len(chunk)
+		b = b[len(chunk):]
+	}
+
+	return n, nil
+}
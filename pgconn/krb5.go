@@ -42,18 +42,7 @@ func (c *PgConn) gssAuth() error {
 		return err
 	}
 
-	var nextData []byte
-	if c.config.KerberosSpn != "" {
-		// Use the supplied SPN if provided.
-		nextData, err = cli.GetInitTokenFromSPN(c.config.KerberosSpn)
-	} else {
-		// Allow the kerberos service name to be overridden
-		service := "postgres"
-		if c.config.KerberosSrvName != "" {
-			service = c.config.KerberosSrvName
-		}
-		nextData, err = cli.GetInitToken(c.config.Host, service)
-	}
+	nextData, err := gssInitToken(cli, c.config)
 	if err != nil {
 		return err
 	}
@@ -83,6 +72,21 @@ func (c *PgConn) gssAuth() error {
 	return nil
 }
 
+// gssInitToken gets the first token of the GSSAPI handshake for the server described by config.
+func gssInitToken(cli GSS, config *Config) ([]byte, error) {
+	if config.KerberosSpn != "" {
+		// Use the supplied SPN if provided.
+		return cli.GetInitTokenFromSPN(config.KerberosSpn)
+	}
+
+	// Allow the kerberos service name to be overridden
+	service := "postgres"
+	if config.KerberosSrvName != "" {
+		service = config.KerberosSrvName
+	}
+	return cli.GetInitToken(config.Host, service)
+}
+
 func (c *PgConn) rxGSSContinue() (*pgproto3.AuthenticationGSSContinue, error) {
 	msg, err := c.receiveMessage()
 	if err != nil {
@@ -64,6 +64,12 @@ type NoticeHandler func(*PgConn, *Notice)
 // notice event.
 type NotificationHandler func(*PgConn, *Notification)
 
+// ParameterStatusHandler is a function that is called when the server reports the value of a run-time parameter such
+// as TimeZone or server_version. The server reports parameters during connection startup and again whenever one of
+// them changes (e.g. after SET TimeZone). The *PgConn is provided so the handler is aware of the origin of the
+// parameter status, but it must not invoke any query method.
+type ParameterStatusHandler func(pgConn *PgConn, name, value string)
+
 // PgConn is a low-level PostgreSQL connection handle. It is not safe for concurrent usage.
 type PgConn struct {
 	conn              net.Conn
@@ -127,6 +133,10 @@ func ConnectWithOptions(ctx context.Context, connString string, parseConfigOptio
 // authentication error will terminate the chain of attempts (like libpq:
 // https://www.postgresql.org/docs/11/libpq-connect.html#LIBPQ-MULTIPLE-HOSTS) and be returned as the error. Otherwise,
 // if all attempts fail the last error is returned.
+//
+// If config.ConnectTimeout is not 0 it limits each host attempt independently of ctx. The limit covers resolving the
+// host, dialing, TLS and GSSAPI negotiation, authentication, the startup exchange and ValidateConnect, so a connection
+// attempt that stalls at any point, e.g. during SCRAM authentication, fails with a timeout error.
 func ConnectConfig(octx context.Context, config *Config) (pgConn *PgConn, err error) {
 	// Default values are set in ParseConfig. Enforce initial creation by ParseConfig rather than setting defaults from
 	// zero values.
@@ -144,6 +154,12 @@ func ConnectConfig(octx context.Context, config *Config) (pgConn *PgConn, err er
 	}
 	fallbackConfigs = append(fallbackConfigs, config.Fallbacks...)
 	ctx := octx
+	// ConnectTimeout also restricts resolving the hosts so a stuck DNS server cannot hang the connection process.
+	if config.ConnectTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(octx, config.ConnectTimeout)
+		defer cancel()
+	}
 	fallbackConfigs, err = expandWithIPs(ctx, config.LookupFunc, fallbackConfigs)
 	if err != nil {
 		return nil, &connectError{config: config, msg: "hostname resolving error", err: err}
@@ -191,6 +207,13 @@ func ConnectConfig(octx context.Context, config *Config) (pgConn *PgConn, err er
 	}
 
 	if !foundBestServer && fallbackConfig != nil {
+		// The attempt gets its own ConnectTimeout as the time from the earlier attempts may have been used up by the hosts
+		// tried after fallbackConfig.
+		if config.ConnectTimeout != 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(octx, config.ConnectTimeout)
+			defer cancel()
+		}
 		pgConn, err = connect(ctx, config, fallbackConfig, true)
 		if pgerr, ok := err.(*PgError); ok {
 			err = &connectError{config: config, msg: "server error", err: pgerr}
@@ -284,7 +307,36 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 	pgConn.contextWatcher = newContextWatcher(netConn)
 	pgConn.contextWatcher.Watch(ctx)
 
-	if fallbackConfig.TLSConfig != nil {
+	gssEncrypted := false
+	if config.GSSEncMode != "disable" {
+		gssConn, err := startGSSEnc(netConn, config, network)
+		switch {
+		case err == nil:
+			pgConn.conn = gssConn
+			gssEncrypted = true
+		case config.GSSEncMode == "prefer" && (errors.Is(err, errGSSEncUnavailable) || errors.Is(err, errGSSEncRejected)):
+			// Continue on the same connection without GSSAPI encryption.
+		case config.GSSEncMode == "prefer" && (errors.Is(err, errGSSEncUnsupported) || isGSSEncHandshakeError(err)):
+			// The server closes the connection after rejecting the request and a failed handshake leaves the connection
+			// unusable so start over without GSSAPI encryption.
+			pgConn.contextWatcher.Unwatch()
+			netConn.Close()
+			netConn, err = config.DialFunc(ctx, network, address)
+			if err != nil {
+				return nil, &connectError{config: config, msg: "dial error", err: normalizeTimeoutError(ctx, err)}
+			}
+
+			pgConn.conn = netConn
+			pgConn.contextWatcher = newContextWatcher(netConn)
+			pgConn.contextWatcher.Watch(ctx)
+		default:
+			pgConn.contextWatcher.Unwatch()
+			netConn.Close()
+			return nil, &connectError{config: config, msg: "gssenc error", err: normalizeTimeoutError(ctx, err)}
+		}
+	}
+
+	if fallbackConfig.TLSConfig != nil && !gssEncrypted {
 		nbTLSConn, err := startTLS(netConn, fallbackConfig.TLSConfig)
 		pgConn.contextWatcher.Unwatch() // Always unwatch `netConn` after TLS.
 		if err != nil {
@@ -333,6 +385,7 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 		return nil, &connectError{config: config, msg: "failed to write startup message", err: normalizeTimeoutError(ctx, err)}
 	}
 
+	channelBound := false
 	for {
 		msg, err := pgConn.receiveMessage()
 		if err != nil {
@@ -349,33 +402,44 @@ func connect(ctx context.Context, config *Config, fallbackConfig *FallbackConfig
 			pgConn.secretKey = msg.SecretKey
 
 		case *pgproto3.AuthenticationOk:
+			if config.ChannelBinding == "require" && !channelBound {
+				pgConn.conn.Close()
+				return nil, &connectError{config: config, msg: "channel binding required", err: errors.New("server authenticated without channel binding")}
+			}
 		case *pgproto3.AuthenticationCleartextPassword:
 			err = pgConn.txPasswordMessage(pgConn.config.Password)
 			if err != nil {
 				pgConn.conn.Close()
-				return nil, &connectError{config: config, msg: "failed to write password message", err: err}
+				return nil, &connectError{config: config, msg: "failed to write password message", err: normalizeTimeoutError(ctx, err)}
 			}
 		case *pgproto3.AuthenticationMD5Password:
 			digestedPassword := "md5" + hexMD5(hexMD5(pgConn.config.Password+pgConn.config.User)+string(msg.Salt[:]))
 			err = pgConn.txPasswordMessage(digestedPassword)
 			if err != nil {
 				pgConn.conn.Close()
-				return nil, &connectError{config: config, msg: "failed to write password message", err: err}
+				return nil, &connectError{config: config, msg: "failed to write password message", err: normalizeTimeoutError(ctx, err)}
 			}
 		case *pgproto3.AuthenticationSASL:
-			err = pgConn.scramAuth(msg.AuthMechanisms)
+			channelBound, err = pgConn.scramAuth(msg.AuthMechanisms)
 			if err != nil {
 				pgConn.conn.Close()
-				return nil, &connectError{config: config, msg: "failed SASL auth", err: err}
+				return nil, &connectError{config: config, msg: "failed SASL auth", err: normalizeTimeoutError(ctx, err)}
 			}
 		case *pgproto3.AuthenticationGSS:
 			err = pgConn.gssAuth()
 			if err != nil {
 				pgConn.conn.Close()
-				return nil, &connectError{config: config, msg: "failed GSS auth", err: err}
+				return nil, &connectError{config: config, msg: "failed GSS auth", err: normalizeTimeoutError(ctx, err)}
 			}
 		case *pgproto3.ReadyForQuery:
 			pgConn.status = connStatusIdle
+			if config.CancelRequestOnContextCancel {
+				// The connection is now established so a cancel request can be used to interrupt operations. Stop the
+				// original context watcher first so it cannot interrupt the connection after it has been replaced. The
+				// deferred Unwatch still applies to the original context watcher and is then a no-op.
+				pgConn.contextWatcher.Unwatch()
+				pgConn.contextWatcher = newCancelRequestContextWatcher(pgConn)
+			}
 			if config.ValidateConnect != nil {
 				// ValidateConnect may execute commands that cause the context to be watched again. Unwatch first to avoid
 				// the watch already in progress panic. This is that last thing done by this method so there is no need to
@@ -413,6 +477,38 @@ func newContextWatcher(conn net.Conn) *ctxwatch.ContextWatcher {
 	)
 }
 
+// newCancelRequestContextWatcher returns a context watcher that sends a cancel request when the context is canceled.
+// The network connection is only interrupted if the operation is still in progress after
+// pgConn.config.CancelRequestDeadlineDelay.
+func newCancelRequestContextWatcher(pgConn *PgConn) *ctxwatch.ContextWatcher {
+	deadlineDelay := pgConn.config.CancelRequestDeadlineDelay
+	if deadlineDelay <= 0 {
+		deadlineDelay = time.Second
+	}
+
+	var cancelRequestDone chan struct{}
+
+	return ctxwatch.NewContextWatcher(
+		func() {
+			deadline := time.Now().Add(deadlineDelay)
+			pgConn.conn.SetDeadline(deadline)
+
+			cancelRequestDone = make(chan struct{})
+			go func() {
+				defer close(cancelRequestDone)
+				ctx, cancel := context.WithDeadline(context.Background(), deadline)
+				defer cancel()
+				pgConn.CancelRequest(ctx)
+			}()
+		},
+		func() {
+			// Wait for the cancel request to be sent so it cannot be delivered during a later operation.
+			<-cancelRequestDone
+			pgConn.conn.SetDeadline(time.Time{})
+		},
+	)
+}
+
 func startTLS(conn net.Conn, tlsConfig *tls.Config) (net.Conn, error) {
 	err := binary.Write(conn, binary.BigEndian, []int32{8, 80877103})
 	if err != nil {
@@ -546,6 +642,9 @@ func (pgConn *PgConn) receiveMessage() (pgproto3.BackendMessage, error) {
 		pgConn.txStatus = msg.TxStatus
 	case *pgproto3.ParameterStatus:
 		pgConn.parameterStatuses[msg.Name] = msg.Value
+		if pgConn.config.OnParameterStatus != nil {
+			pgConn.config.OnParameterStatus(pgConn, msg.Name, msg.Value)
+		}
 	case *pgproto3.ErrorResponse:
 		if msg.Severity == "FATAL" {
 			pgConn.status = connStatusClosed
@@ -1992,6 +2091,13 @@ func (p *Pipeline) Flush() error {
 
 // Sync establishes a synchronization point and flushes the queued requests.
 func (p *Pipeline) Sync() error {
+	if p.closed {
+		if p.err != nil {
+			return p.err
+		}
+		return errors.New("pipeline closed")
+	}
+
 	p.conn.frontend.SendSync(&pgproto3.Sync{})
 	err := p.Flush()
 	if err != nil {
@@ -1,9 +1,24 @@
 package pgconn
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5/internal/pgmock"
+	"github.com/jackc/pgx/v5/pgproto3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCommandTag(t *testing.T) {
@@ -39,3 +54,272 @@ func TestCommandTag(t *testing.T) {
 		assert.Equalf(t, tt.isSelect, ct.Select(), "%d. %v", i, tt.commandTag)
 	}
 }
+
+func TestNewScramClientChannelBinding(t *testing.T) {
+	t.Parallel()
+
+	cbData := []byte("channel binding data")
+
+	for _, tt := range []struct {
+		name          string
+		mechanisms    []string
+		cbData        []byte
+		require       bool
+		authMechanism string
+		gs2Header     string
+		err           bool
+	}{
+		{name: "no TLS", mechanisms: []string{"SCRAM-SHA-256"}, authMechanism: "SCRAM-SHA-256", gs2Header: "n,,"},
+		{name: "TLS and PLUS", mechanisms: []string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"}, cbData: cbData, authMechanism: "SCRAM-SHA-256-PLUS", gs2Header: "p=tls-server-end-point,,"},
+		{name: "TLS without PLUS", mechanisms: []string{"SCRAM-SHA-256"}, cbData: cbData, authMechanism: "SCRAM-SHA-256", gs2Header: "y,,"},
+		{name: "PLUS without TLS", mechanisms: []string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"}, authMechanism: "SCRAM-SHA-256", gs2Header: "n,,"},
+		{name: "require with PLUS", mechanisms: []string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"}, cbData: cbData, require: true, authMechanism: "SCRAM-SHA-256-PLUS", gs2Header: "p=tls-server-end-point,,"},
+		{name: "require without PLUS", mechanisms: []string{"SCRAM-SHA-256"}, cbData: cbData, require: true, err: true},
+		{name: "require without TLS", mechanisms: []string{"SCRAM-SHA-256-PLUS", "SCRAM-SHA-256"}, require: true, err: true},
+		{name: "no SCRAM-SHA-256", mechanisms: []string{"OTHER"}, err: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := newScramClient(tt.mechanisms, "secret", tt.cbData, tt.require)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.authMechanism, sc.authMechanism)
+			assert.True(t, strings.HasPrefix(string(sc.clientFirstMessage()), tt.gs2Header+"n=,r="))
+
+			err = sc.recvServerFirstMessage([]byte("r=" + string(sc.clientNonce) + "server,s=c2FsdA==,i=4096"))
+			require.NoError(t, err)
+			channelBinding := base64.StdEncoding.EncodeToString([]byte(tt.gs2Header + string(sc.channelBindingData)))
+			assert.True(t, strings.HasPrefix(sc.clientFinalMessage(), "c="+channelBinding+",r="))
+		})
+	}
+}
+
+func TestTLSServerEndPoint(t *testing.T) {
+	t.Parallel()
+
+	raw := []byte("certificate")
+	sha256Sum := sha256.Sum256(raw)
+	sha384Sum := sha512.Sum384(raw)
+	sha512Sum := sha512.Sum512(raw)
+
+	for _, tt := range []struct {
+		algorithm x509.SignatureAlgorithm
+		expected  []byte
+	}{
+		{algorithm: x509.SHA1WithRSA, expected: sha256Sum[:]},
+		{algorithm: x509.SHA256WithRSA, expected: sha256Sum[:]},
+		{algorithm: x509.ECDSAWithSHA384, expected: sha384Sum[:]},
+		{algorithm: x509.SHA512WithRSAPSS, expected: sha512Sum[:]},
+		{algorithm: x509.PureEd25519, expected: nil},
+	} {
+		connState := tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Raw: raw, SignatureAlgorithm: tt.algorithm}}}
+		assert.Equal(t, tt.expected, tlsServerEndPoint(connState), tt.algorithm.String())
+	}
+
+	assert.Nil(t, tlsServerEndPoint(tls.ConnectionState{}))
+}
+
+// testGSSEncrypter is a GSSEncrypter that "encrypts" by inverting every byte.
+type testGSSEncrypter struct{}
+
+func (testGSSEncrypter) GetInitToken(host string, service string) ([]byte, error) {
+	return []byte(service + "@" + host), nil
+}
+
+func (testGSSEncrypter) GetInitTokenFromSPN(spn string) ([]byte, error) {
+	return []byte(spn), nil
+}
+
+func (testGSSEncrypter) Continue(inToken []byte) (bool, []byte, error) {
+	if string(inToken) != "server token" {
+		return false, nil, errors.New("unexpected server token")
+	}
+	return true, nil, nil
+}
+
+func (testGSSEncrypter) Wrap(msg []byte) ([]byte, error) {
+	return invertBytes(msg), nil
+}
+
+func (testGSSEncrypter) Unwrap(token []byte) ([]byte, error) {
+	return invertBytes(token), nil
+}
+
+func invertBytes(b []byte) []byte {
+	inverted := make([]byte, len(b))
+	for i := range b {
+		inverted[i] = ^b[i]
+	}
+	return inverted
+}
+
+func readTestGSSEncPacket(t *testing.T, r io.Reader) []byte {
+	header := make([]byte, 4)
+	_, err := io.ReadFull(r, header)
+	require.NoError(t, err)
+	buf := make([]byte, binary.BigEndian.Uint32(header))
+	_, err = io.ReadFull(r, buf)
+	require.NoError(t, err)
+	return buf
+}
+
+func TestStartGSSEnc(t *testing.T) {
+	defer func(f NewGSSFunc) { newGSS = f }(newGSS)
+	RegisterGSSProvider(func() (GSS, error) { return testGSSEncrypter{}, nil })
+
+	config := &Config{Host: "db.example.com"}
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	serverErrChan := make(chan error, 1)
+	go func() {
+		defer close(serverErrChan)
+
+		request := make([]byte, 8)
+		_, err := io.ReadFull(serverConn, request)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0, 0, 0, 8, 0x04, 0xd2, 0x16, 0x30}, request)
+
+		_, err = serverConn.Write([]byte{'G'})
+		require.NoError(t, err)
+
+		assert.Equal(t, "postgres@db.example.com", string(readTestGSSEncPacket(t, serverConn)))
+		err = writeGSSEncPacket(serverConn, []byte("server token"))
+		require.NoError(t, err)
+
+		msg := invertBytes(readTestGSSEncPacket(t, serverConn))
+		assert.Equal(t, "ping", string(msg))
+		err = writeGSSEncPacket(serverConn, invertBytes([]byte("pong")))
+		serverErrChan <- err
+	}()
+
+	conn, err := startGSSEnc(clientConn, config, "tcp")
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(buf))
+	require.NoError(t, <-serverErrChan)
+}
+
+func TestStartGSSEncRejected(t *testing.T) {
+	defer func(f NewGSSFunc) { newGSS = f }(newGSS)
+	RegisterGSSProvider(func() (GSS, error) { return testGSSEncrypter{}, nil })
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	go func() {
+		request := make([]byte, 8)
+		io.ReadFull(serverConn, request)
+		serverConn.Write([]byte{'N'})
+	}()
+
+	_, err := startGSSEnc(clientConn, &Config{Host: "db.example.com"}, "tcp")
+	require.ErrorIs(t, err, errGSSEncRejected)
+}
+
+func TestStartGSSEncHandshakeFailed(t *testing.T) {
+	defer func(f NewGSSFunc) { newGSS = f }(newGSS)
+	RegisterGSSProvider(func() (GSS, error) { return testGSSEncrypter{}, nil })
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	go func() {
+		request := make([]byte, 8)
+		io.ReadFull(serverConn, request)
+		serverConn.Write([]byte{'G'})
+		readTestGSSEncPacket(t, serverConn)
+		writeGSSEncPacket(serverConn, []byte("wrong token"))
+	}()
+
+	_, err := startGSSEnc(clientConn, &Config{Host: "db.example.com"}, "tcp")
+	require.Error(t, err)
+	require.True(t, isGSSEncHandshakeError(err))
+	require.EqualError(t, errors.Unwrap(err), "unexpected server token")
+}
+
+func TestConnectGSSEncPreferFallsBackAfterHandshakeFailure(t *testing.T) {
+	defer func(f NewGSSFunc) { newGSS = f }(newGSS)
+	RegisterGSSProvider(func() (GSS, error) { return testGSSEncrypter{}, nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config, err := ParseConfig("host=127.0.0.1 sslmode=disable gssencmode=prefer")
+	require.NoError(t, err)
+
+	dials := 0
+	serverErrChan := make(chan error, 1)
+	config.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials++
+		clientConn, serverConn := net.Pipe()
+		if dials == 1 {
+			go func() {
+				defer serverConn.Close()
+				request := make([]byte, 8)
+				io.ReadFull(serverConn, request)
+				serverConn.Write([]byte{'G'})
+				readTestGSSEncPacket(t, serverConn)
+				writeGSSEncPacket(serverConn, []byte("wrong token"))
+			}()
+		} else {
+			go func() {
+				defer serverConn.Close()
+				script := &pgmock.Script{Steps: pgmock.AcceptUnauthenticatedConnRequestSteps()}
+				serverErrChan <- script.Run(pgproto3.NewBackend(serverConn, serverConn))
+			}()
+		}
+		return clientConn, nil
+	}
+
+	pgConn, err := ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	defer pgConn.conn.Close()
+	require.Equal(t, 2, dials)
+	require.NoError(t, <-serverErrChan)
+}
+
+func TestStartGSSEncUnavailable(t *testing.T) {
+	defer func(f NewGSSFunc) { newGSS = f }(newGSS)
+	newGSS = nil
+
+	_, err := startGSSEnc(nil, &Config{Host: "db.example.com"}, "tcp")
+	require.ErrorIs(t, err, errGSSEncUnavailable)
+
+	RegisterGSSProvider(func() (GSS, error) { return testGSSEncrypter{}, nil })
+	_, err = startGSSEnc(nil, &Config{Host: "/tmp"}, "unix")
+	require.ErrorIs(t, err, errGSSEncUnavailable)
+}
+
+func TestGSSEncConnLargeWrite(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	client := &gssEncConn{Conn: clientConn, gss: testGSSEncrypter{}}
+	server := &gssEncConn{Conn: serverConn, gss: testGSSEncrypter{}}
+
+	msg := make([]byte, gssEncMaxWriteSize*2+100)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	go client.Write(msg)
+
+	received := make([]byte, len(msg))
+	_, err := io.ReadFull(server, received)
+	require.NoError(t, err)
+	assert.Equal(t, msg, received)
+}
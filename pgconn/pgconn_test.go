@@ -335,6 +335,76 @@ func TestConnectTimeoutStuckOnTLSHandshake(t *testing.T) {
 	}
 }
 
+func TestConnectTimeoutStuckOnAuthentication(t *testing.T) {
+	t.Parallel()
+
+	script := &pgmock.Script{
+		Steps: []pgmock.Step{
+			pgmock.ExpectAnyMessage(&pgproto3.StartupMessage{ProtocolVersion: pgproto3.ProtocolVersionNumber, Parameters: map[string]string{}}),
+			pgmock.SendMessage(&pgproto3.AuthenticationSASL{AuthMechanisms: []string{"SCRAM-SHA-256"}}),
+			// Never respond to the client's SASLInitialResponse.
+			pgmockWaitStep(time.Minute),
+		},
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		script.Run(pgproto3.NewBackend(conn, conn))
+	}()
+
+	host, port, _ := strings.Cut(ln.Addr().String(), ":")
+	config, err := pgconn.ParseConfig(fmt.Sprintf("sslmode=disable host=%s port=%s password=secret", host, port))
+	require.NoError(t, err)
+	config.ConnectTimeout = 50 * time.Millisecond
+
+	errChan := make(chan error)
+	go func() {
+		_, err := pgconn.ConnectConfig(context.Background(), config)
+		errChan <- err
+	}()
+
+	select {
+	case err = <-errChan:
+		require.True(t, pgconn.Timeout(err), err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("exceeded connection timeout without erroring out")
+	}
+}
+
+func TestConnectTimeoutStuckOnLookup(t *testing.T) {
+	t.Parallel()
+
+	config, err := pgconn.ParseConfig("host=example.invalid sslmode=disable")
+	require.NoError(t, err)
+	config.ConnectTimeout = 50 * time.Millisecond
+	config.LookupFunc = func(ctx context.Context, host string) ([]string, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	errChan := make(chan error)
+	go func() {
+		_, err := pgconn.ConnectConfig(context.Background(), config)
+		errChan <- err
+	}()
+
+	select {
+	case err = <-errChan:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("exceeded connection timeout without erroring out")
+	}
+}
+
 func TestConnectInvalidUser(t *testing.T) {
 	t.Parallel()
 
@@ -1495,6 +1565,35 @@ end$$;`)
 	ensureConnValid(t, pgConn)
 }
 
+func TestConnOnParameterStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgconn.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	statuses := map[string]string{}
+	config.OnParameterStatus = func(c *pgconn.PgConn, name, value string) {
+		statuses[name] = value
+	}
+
+	pgConn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	require.NotEmpty(t, statuses["server_version"])
+	require.Equal(t, pgConn.ParameterStatus("server_version"), statuses["server_version"])
+
+	_, err = pgConn.Exec(ctx, "set time zone 'America/Chicago'").ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, "America/Chicago", statuses["TimeZone"])
+	assert.Equal(t, "America/Chicago", pgConn.ParameterStatus("TimeZone"))
+
+	ensureConnValid(t, pgConn)
+}
+
 func TestConnOnNotification(t *testing.T) {
 	t.Parallel()
 
@@ -2252,6 +2351,43 @@ func TestConnCancelRequest(t *testing.T) {
 	ensureConnValid(t, pgConn)
 }
 
+func TestConnContextCanceledWithCancelRequestOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgconn.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.CancelRequestOnContextCancel = true
+	config.CancelRequestDeadlineDelay = 5 * time.Second
+
+	pgConn, err := pgconn.ConnectConfig(ctx, config)
+	require.NoError(t, err)
+	defer closeConn(t, pgConn)
+
+	if pgConn.ParameterStatus("crdb_version") != "" {
+		t.Skip("Server does not support query cancellation (https://github.com/cockroachdb/cockroach/issues/41335)")
+	}
+
+	queryCtx, queryCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer queryCancel()
+
+	startTime := time.Now()
+	multiResult := pgConn.Exec(queryCtx, "select 'Hello, world', pg_sleep(10)")
+	for multiResult.NextResult() {
+	}
+	err = multiResult.Close()
+	require.Less(t, time.Since(startTime), 5*time.Second)
+
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "57014", pgErr.Code)
+	require.False(t, pgConn.IsClosed())
+
+	ensureConnValid(t, pgConn)
+}
+
 // https://github.com/jackc/pgx/issues/659
 func TestConnContextCanceledCancelsRunningQueryOnServer(t *testing.T) {
 	t.Parallel()
@@ -97,7 +97,8 @@ type untypedTextArray struct {
 	Dimensions []ArrayDimension
 }
 
-func parseUntypedTextArray(src string) (*untypedTextArray, error) {
+// parseUntypedTextArray parses the text format of an array whose elements are separated by delim.
+func parseUntypedTextArray(src string, delim byte) (*untypedTextArray, error) {
 	dst := &untypedTextArray{
 		Elements:   []string{},
 		Quoted:     []bool{},
@@ -204,7 +205,7 @@ func parseUntypedTextArray(src string) (*untypedTextArray, error) {
 				implicitDimensions[currentDim].Length++
 			}
 			currentDim++
-		case ',':
+		case rune(delim):
 		case '}':
 			currentDim--
 			if currentDim < counterDim {
@@ -212,7 +213,7 @@ func parseUntypedTextArray(src string) (*untypedTextArray, error) {
 			}
 		default:
 			buf.UnreadRune()
-			value, quoted, err := arrayParseValue(buf)
+			value, quoted, err := arrayParseValue(buf, delim)
 			if err != nil {
 				return nil, fmt.Errorf("invalid array value: %v", err)
 			}
@@ -255,7 +256,7 @@ func skipWhitespace(buf *bytes.Buffer) {
 	}
 }
 
-func arrayParseValue(buf *bytes.Buffer) (string, bool, error) {
+func arrayParseValue(buf *bytes.Buffer, delim byte) (string, bool, error) {
 	r, _, err := buf.ReadRune()
 	if err != nil {
 		return "", false, err
@@ -273,8 +274,7 @@ func arrayParseValue(buf *bytes.Buffer) (string, bool, error) {
 			return "", false, err
 		}
 
-		switch r {
-		case ',', '}':
+		if r == rune(delim) || r == '}' {
 			buf.UnreadRune()
 			return s.String(), false, nil
 		}
@@ -367,8 +367,8 @@ func isSpace(ch byte) bool {
 	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\v' || ch == '\f'
 }
 
-func quoteArrayElementIfNeeded(src string) string {
-	if src == "" || (len(src) == 4 && strings.EqualFold(src, "null")) || isSpace(src[0]) || isSpace(src[len(src)-1]) || strings.ContainsAny(src, `{},"\`) {
+func quoteArrayElementIfNeeded(src string, delim byte) string {
+	if src == "" || (len(src) == 4 && strings.EqualFold(src, "null")) || isSpace(src[0]) || isSpace(src[len(src)-1]) || strings.ContainsAny(src, `{}"\`) || strings.IndexByte(src, delim) >= 0 {
 		return quoteArrayElement(src)
 	}
 	return src
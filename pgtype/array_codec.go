@@ -4,6 +4,7 @@ import (
 	"database/sql/driver"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"reflect"
 
 	"github.com/jackc/pgx/v5/internal/anynil"
@@ -40,6 +41,17 @@ type ArraySetter interface {
 // ArrayCodec is a codec for any array type.
 type ArrayCodec struct {
 	ElementType *Type
+
+	// Delimiter separates the elements in the text format. It is the typdelim of the element type in pg_type. 0 means
+	// ','. Of the built-in types only box uses a different delimiter, ';'.
+	Delimiter byte
+}
+
+func (c *ArrayCodec) delimiter() byte {
+	if c.Delimiter == 0 {
+		return ','
+	}
+	return c.Delimiter
 }
 
 func (c *ArrayCodec) FormatSupported(format int16) bool {
@@ -60,6 +72,12 @@ func (c *ArrayCodec) PreferredFormat() int16 {
 }
 
 func (c *ArrayCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	if format == BinaryFormatCode {
+		if plan := c.planEncodeBinarySlice(value); plan != nil {
+			return plan
+		}
+	}
+
 	arrayValuer, ok := value.(ArrayGetter)
 	if !ok {
 		return nil
@@ -116,12 +134,13 @@ func (p *encodePlanArrayCodecText) Encode(value any, buf []byte) (newBuf []byte,
 		dimElemCounts[i] = int(dimensions[i].Length) * dimElemCounts[i+1]
 	}
 
+	delim := p.ac.delimiter()
 	var encodePlan EncodePlan
 	var lastElemType reflect.Type
 	inElemBuf := make([]byte, 0, 32)
 	for i := 0; i < elementCount; i++ {
 		if i > 0 {
-			buf = append(buf, ',')
+			buf = append(buf, delim)
 		}
 
 		for _, dec := range dimElemCounts {
@@ -150,7 +169,7 @@ func (p *encodePlanArrayCodecText) Encode(value any, buf []byte) (newBuf []byte,
 		if elemBuf == nil {
 			buf = append(buf, `NULL`...)
 		} else {
-			buf = append(buf, quoteArrayElementIfNeeded(string(elemBuf))...)
+			buf = append(buf, quoteArrayElementIfNeeded(string(elemBuf), delim)...)
 		}
 
 		for _, dec := range dimElemCounts {
@@ -222,6 +241,106 @@ func (p *encodePlanArrayCodecBinary) Encode(value any, buf []byte) (newBuf []byt
 	return buf, nil
 }
 
+// planEncodeBinarySlice returns a plan that encodes common slice types directly when the element type uses the codec
+// the element type would be encoded with anyway. This avoids converting each element to an any and planning its
+// encoding, which makes encoding large arrays such as the parameter of "= any($1)" much faster.
+func (c *ArrayCodec) planEncodeBinarySlice(value any) EncodePlan {
+	elementOID := c.ElementType.OID
+
+	switch c.ElementType.Codec.(type) {
+	case Int2Codec:
+		switch value.(type) {
+		case []int16, FlatArray[int16]:
+			return &encodePlanArrayCodecBinarySlice[int16]{elementOID: elementOID, appendElement: appendBinaryInt16}
+		}
+	case Int4Codec:
+		switch value.(type) {
+		case []int32, FlatArray[int32]:
+			return &encodePlanArrayCodecBinarySlice[int32]{elementOID: elementOID, appendElement: appendBinaryInt32}
+		}
+	case Int8Codec:
+		switch value.(type) {
+		case []int64, FlatArray[int64]:
+			return &encodePlanArrayCodecBinarySlice[int64]{elementOID: elementOID, appendElement: appendBinaryInt64}
+		}
+	case Float4Codec:
+		switch value.(type) {
+		case []float32, FlatArray[float32]:
+			return &encodePlanArrayCodecBinarySlice[float32]{elementOID: elementOID, appendElement: appendBinaryFloat32}
+		}
+	case Float8Codec:
+		switch value.(type) {
+		case []float64, FlatArray[float64]:
+			return &encodePlanArrayCodecBinarySlice[float64]{elementOID: elementOID, appendElement: appendBinaryFloat64}
+		}
+	case TextCodec:
+		switch value.(type) {
+		case []string, FlatArray[string]:
+			return &encodePlanArrayCodecBinarySlice[string]{elementOID: elementOID, appendElement: appendBinaryString}
+		}
+	}
+
+	return nil
+}
+
+type encodePlanArrayCodecBinarySlice[T any] struct {
+	elementOID    uint32
+	appendElement func(buf []byte, v T) []byte
+}
+
+func (p *encodePlanArrayCodecBinarySlice[T]) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	var slice []T
+	switch value := value.(type) {
+	case []T:
+		slice = value
+	case FlatArray[T]:
+		slice = value
+	}
+
+	if slice == nil {
+		return nil, nil
+	}
+
+	buf = pgio.AppendInt32(buf, 1) // dimensions
+	buf = pgio.AppendInt32(buf, 0) // contains null
+	buf = pgio.AppendUint32(buf, p.elementOID)
+	buf = pgio.AppendInt32(buf, int32(len(slice)))
+	buf = pgio.AppendInt32(buf, 1) // lower bound
+
+	for _, v := range slice {
+		sp := len(buf)
+		buf = pgio.AppendInt32(buf, -1)
+		buf = p.appendElement(buf, v)
+		pgio.SetInt32(buf[sp:], int32(len(buf[sp:])-4))
+	}
+
+	return buf, nil
+}
+
+func appendBinaryInt16(buf []byte, v int16) []byte {
+	return pgio.AppendInt16(buf, v)
+}
+
+func appendBinaryInt32(buf []byte, v int32) []byte {
+	return pgio.AppendInt32(buf, v)
+}
+
+func appendBinaryInt64(buf []byte, v int64) []byte {
+	return pgio.AppendInt64(buf, v)
+}
+
+func appendBinaryFloat32(buf []byte, v float32) []byte {
+	return pgio.AppendUint32(buf, math.Float32bits(v))
+}
+
+func appendBinaryFloat64(buf []byte, v float64) []byte {
+	return pgio.AppendUint64(buf, math.Float64bits(v))
+}
+
+func appendBinaryString(buf []byte, v string) []byte {
+	return append(buf, v...)
+}
+
 func (c *ArrayCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
 	arrayScanner, ok := target.(ArraySetter)
 	if !ok {
@@ -290,7 +409,7 @@ func (c *ArrayCodec) decodeBinary(m *Map, arrayOID uint32, src []byte, array Arr
 }
 
 func (c *ArrayCodec) decodeText(m *Map, arrayOID uint32, src []byte, array ArraySetter) error {
-	uta, err := parseUntypedTextArray(string(src))
+	uta, err := parseUntypedTextArray(string(src), c.delimiter())
 	if err != nil {
 		return err
 	}
@@ -377,9 +496,38 @@ func (c *ArrayCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (
 		return nil, nil
 	}
 
-	var slice []any
-	err := m.PlanScan(oid, format, &slice).Scan(src, &slice)
-	return slice, err
+	var array Array[any]
+	err := m.PlanScan(oid, format, &array).Scan(src, &array)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(array.Dims) <= 1 {
+		return array.Elements, nil
+	}
+
+	return nestArrayElements(array.Elements, array.Dims), nil
+}
+
+// nestArrayElements returns the flat elements of a multi-dimensional array as nested []any slices with the shape of
+// dimensions.
+func nestArrayElements(elements []any, dimensions []ArrayDimension) []any {
+	if len(dimensions) == 1 {
+		return elements
+	}
+
+	length := int(dimensions[0].Length)
+	nested := make([]any, length)
+	if length == 0 {
+		return nested
+	}
+
+	stride := len(elements) / length
+	for i := range nested {
+		nested[i] = nestArrayElements(elements[i*stride:(i+1)*stride], dimensions[1:])
+	}
+
+	return nested
 }
 
 func isRagged(slice reflect.Value) bool {
@@ -3,6 +3,7 @@ package pgtype_test
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -234,6 +235,14 @@ func TestArrayCodecDecodeValue(t *testing.T) {
 				sql:      `select '{foo,bar}'::text[]`,
 				expected: []any{"foo", "bar"},
 			},
+			{
+				sql:      `select '{{1,2,3},{4,5,6}}'::int4[]`,
+				expected: []any{[]any{int32(1), int32(2), int32(3)}, []any{int32(4), int32(5), int32(6)}},
+			},
+			{
+				sql:      `select '{{{1},{2}},{{3},{4}}}'::float8[]`,
+				expected: []any{[]any{[]any{float64(1)}, []any{float64(2)}}, []any{[]any{float64(3)}, []any{float64(4)}}},
+			},
 		} {
 			t.Run(tt.sql, func(t *testing.T) {
 				rows, err := conn.Query(ctx, tt.sql)
@@ -304,6 +313,90 @@ func TestArrayCodecScanWrongMultipleDimensions(t *testing.T) {
 	})
 }
 
+func TestArrayCodecMultipleDimensionsPreserveShape(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		src, err := m.Encode(pgtype.Float8ArrayOID, format, [][]float64{{1, 2, 3}, {4, 5, 6}}, nil)
+		require.NoError(t, err)
+
+		var matrix [][]float64
+		err = m.Scan(pgtype.Float8ArrayOID, format, src, &matrix)
+		require.NoError(t, err)
+		require.Equal(t, [][]float64{{1, 2, 3}, {4, 5, 6}}, matrix)
+
+		var array pgtype.Array[float64]
+		err = m.Scan(pgtype.Float8ArrayOID, format, src, &array)
+		require.NoError(t, err)
+		require.Equal(t, []float64{1, 2, 3, 4, 5, 6}, array.Elements)
+		require.Equal(t, []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}, {Length: 3, LowerBound: 1}}, array.Dimensions())
+
+		var flat []float64
+		err = m.Scan(pgtype.Float8ArrayOID, format, src, &flat)
+		require.NoError(t, err)
+		require.Equal(t, []float64{1, 2, 3, 4, 5, 6}, flat)
+
+		dt, ok := m.TypeForOID(pgtype.Float8ArrayOID)
+		require.True(t, ok)
+		value, err := dt.Codec.DecodeValue(m, pgtype.Float8ArrayOID, format, src)
+		require.NoError(t, err)
+		require.Equal(t, []any{[]any{float64(1), float64(2), float64(3)}, []any{float64(4), float64(5), float64(6)}}, value)
+	}
+}
+
+func TestArrayCodecNullAndEmptyAreDistinct(t *testing.T) {
+	type int32Slice []int32
+
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		empty, err := m.Encode(pgtype.Int4ArrayOID, format, []int32{}, nil)
+		require.NoError(t, err)
+		require.NotNil(t, empty)
+
+		null, err := m.Encode(pgtype.Int4ArrayOID, format, []int32(nil), nil)
+		require.NoError(t, err)
+		require.Nil(t, null)
+
+		for _, tt := range []struct {
+			name   string
+			target func() any
+		}{
+			{"[]int32", func() any { return &[]int32{1} }},
+			{"[]any", func() any { return &[]any{1} }},
+			{"int32Slice", func() any { return &int32Slice{1} }},
+			{"[][]int32", func() any { return &[][]int32{{1}} }},
+			{"FlatArray", func() any { return &pgtype.FlatArray[int32]{1} }},
+		} {
+			t.Run(fmt.Sprintf("%s/%d", tt.name, format), func(t *testing.T) {
+				target := tt.target()
+				err := m.Scan(pgtype.Int4ArrayOID, format, empty, target)
+				require.NoError(t, err)
+				slice := reflect.ValueOf(target).Elem()
+				require.False(t, slice.IsNil())
+				require.Equal(t, 0, slice.Len())
+
+				target = tt.target()
+				err = m.Scan(pgtype.Int4ArrayOID, format, nil, target)
+				require.NoError(t, err)
+				require.True(t, reflect.ValueOf(target).Elem().IsNil())
+			})
+		}
+
+		var array pgtype.Array[int32]
+		err = m.Scan(pgtype.Int4ArrayOID, format, empty, &array)
+		require.NoError(t, err)
+		require.True(t, array.Valid)
+		require.NotNil(t, array.Elements)
+		require.Len(t, array.Elements, 0)
+
+		err = m.Scan(pgtype.Int4ArrayOID, format, nil, &array)
+		require.NoError(t, err)
+		require.False(t, array.Valid)
+		require.Nil(t, array.Elements)
+	}
+}
+
 func TestArrayCodecEncodeMultipleDimensions(t *testing.T) {
 	skipCockroachDB(t, "Server does not support nested arrays (https://github.com/cockroachdb/cockroach/issues/36815)")
 
@@ -363,3 +456,78 @@ func TestArrayCodecDecodeTextArrayPrefersBinaryFormat(t *testing.T) {
 		require.Equal(t, int16(1), conn.TypeMap().FormatCodeForOID(sd.Fields[0].DataTypeOID))
 	})
 }
+
+func TestArrayCodecEncodeBinarySlice(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for i, tt := range []struct {
+		oid      uint32
+		value    any
+		expected any // encoded without the slice fast path
+	}{
+		{pgtype.Int2ArrayOID, []int16{1, -2, 3}, pgtype.Array[int16]{Elements: []int16{1, -2, 3}, Dims: []pgtype.ArrayDimension{{Length: 3, LowerBound: 1}}, Valid: true}},
+		{pgtype.Int4ArrayOID, []int32{1, -2, 1 << 30}, pgtype.Array[int32]{Elements: []int32{1, -2, 1 << 30}, Dims: []pgtype.ArrayDimension{{Length: 3, LowerBound: 1}}, Valid: true}},
+		{pgtype.Int8ArrayOID, []int64{1, -2, 1 << 40}, pgtype.Array[int64]{Elements: []int64{1, -2, 1 << 40}, Dims: []pgtype.ArrayDimension{{Length: 3, LowerBound: 1}}, Valid: true}},
+		{pgtype.Float4ArrayOID, []float32{1.5, -2}, pgtype.Array[float32]{Elements: []float32{1.5, -2}, Dims: []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}}, Valid: true}},
+		{pgtype.Float8ArrayOID, []float64{1.5, -2}, pgtype.Array[float64]{Elements: []float64{1.5, -2}, Dims: []pgtype.ArrayDimension{{Length: 2, LowerBound: 1}}, Valid: true}},
+		{pgtype.TextArrayOID, []string{"foo", "", "baz"}, pgtype.Array[string]{Elements: []string{"foo", "", "baz"}, Dims: []pgtype.ArrayDimension{{Length: 3, LowerBound: 1}}, Valid: true}},
+		{pgtype.VarcharArrayOID, pgtype.FlatArray[string]{"foo"}, pgtype.Array[string]{Elements: []string{"foo"}, Dims: []pgtype.ArrayDimension{{Length: 1, LowerBound: 1}}, Valid: true}},
+		{pgtype.Int4ArrayOID, []int32{}, pgtype.Array[int32]{Elements: []int32{}, Dims: []pgtype.ArrayDimension{{Length: 0, LowerBound: 1}}, Valid: true}},
+	} {
+		buf, err := m.Encode(tt.oid, pgtype.BinaryFormatCode, tt.value, nil)
+		require.NoErrorf(t, err, "%d", i)
+
+		expected, err := m.Encode(tt.oid, pgtype.BinaryFormatCode, tt.expected, nil)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, expected, buf, "%d", i)
+	}
+
+	buf, err := m.Encode(pgtype.Int4ArrayOID, pgtype.BinaryFormatCode, []int32(nil), nil)
+	require.NoError(t, err)
+	require.Nil(t, buf)
+
+	// Slices that do not match the element type still use the general path.
+	buf, err = m.Encode(pgtype.Int8ArrayOID, pgtype.BinaryFormatCode, []int32{1, 2}, nil)
+	require.NoError(t, err)
+	expected, err := m.Encode(pgtype.Int8ArrayOID, pgtype.BinaryFormatCode, []int64{1, 2}, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, buf)
+}
+
+func BenchmarkArrayCodecEncodeBinaryInt32Slice(b *testing.B) {
+	m := pgtype.NewMap()
+	src := make([]int32, 1000)
+	for i := range src {
+		src[i] = int32(i * 1000)
+	}
+
+	var buf []byte
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var err error
+		buf, err = m.Encode(pgtype.Int4ArrayOID, pgtype.BinaryFormatCode, src, buf[:0])
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkArrayCodecEncodeBinaryStringSlice(b *testing.B) {
+	m := pgtype.NewMap()
+	src := make([]string, 1000)
+	for i := range src {
+		src[i] = strings.Repeat("x", i%32)
+	}
+
+	var buf []byte
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var err error
+		buf, err = m.Encode(pgtype.TextArrayOID, pgtype.BinaryFormatCode, src, buf[:0])
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
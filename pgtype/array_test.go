@@ -108,7 +108,7 @@ func TestParseUntypedTextArray(t *testing.T) {
 	}
 
 	for i, tt := range tests {
-		r, err := parseUntypedTextArray(tt.source)
+		r, err := parseUntypedTextArray(tt.source, ',')
 		if err != nil {
 			t.Errorf("%d: %v", i, err)
 			continue
@@ -119,3 +119,19 @@ func TestParseUntypedTextArray(t *testing.T) {
 		}
 	}
 }
+
+func TestParseUntypedTextArrayDelimiter(t *testing.T) {
+	r, err := parseUntypedTextArray(`{(1,2),(3,4);a,b;"c;d";NULL}`, ';')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := untypedTextArray{
+		Elements:   []string{"(1,2),(3,4)", "a,b", "c;d", "NULL"},
+		Quoted:     []bool{false, false, true, false},
+		Dimensions: []ArrayDimension{{Length: 4, LowerBound: 1}},
+	}
+	if !reflect.DeepEqual(*r, expected) {
+		t.Errorf("expected %+v, but it was %+v", expected, *r)
+	}
+}
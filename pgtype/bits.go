@@ -32,6 +32,87 @@ func (b Bits) BitsValue() (Bits, error) {
 	return b, nil
 }
 
+// BitsFromUint64 returns a Bits of width bits holding the low width bits of v. The most significant bit is first. It
+// can be used to encode an integer into a bit(n) or varbit column.
+func BitsFromUint64(v uint64, width int32) (Bits, error) {
+	if width < 0 || width > 64 {
+		return Bits{}, fmt.Errorf("bit width %d is not between 0 and 64", width)
+	}
+	if width < 64 && v>>uint(width) != 0 {
+		return Bits{}, fmt.Errorf("%d does not fit in %d bits", v, width)
+	}
+
+	buf := make([]byte, (width+7)/8)
+	for i := int32(0); i < width; i++ {
+		if v&(1<<uint(width-1-i)) != 0 {
+			buf[i/8] |= 128 >> uint(i%8)
+		}
+	}
+
+	return Bits{Bytes: buf, Len: width, Valid: true}, nil
+}
+
+// checkLen returns an error if the number of bytes in b.Bytes does not match b.Len.
+func (b Bits) checkLen() error {
+	if b.Len < 0 || int64(len(b.Bytes)) != (int64(b.Len)+7)/8 {
+		return fmt.Errorf("invalid bits: %d bytes for %d bits", len(b.Bytes), b.Len)
+	}
+	return nil
+}
+
+// Uint64 returns the bits as an integer with the first bit as the most significant bit. It returns an error if b is
+// NULL, has more than 64 bits, or if len(b.Bytes) does not match b.Len.
+func (b Bits) Uint64() (uint64, error) {
+	if !b.Valid {
+		return 0, fmt.Errorf("cannot convert NULL bits to uint64")
+	}
+	if b.Len > 64 {
+		return 0, fmt.Errorf("cannot convert %d bits to uint64", b.Len)
+	}
+	if err := b.checkLen(); err != nil {
+		return 0, err
+	}
+
+	var v uint64
+	for i := int32(0); i < b.Len; i++ {
+		v <<= 1
+		if b.Bytes[i/8]&(128>>uint(i%8)) != 0 {
+			v |= 1
+		}
+	}
+
+	return v, nil
+}
+
+// Bools returns the bits as a slice of bool. It returns nil if b is NULL. It returns an error if len(b.Bytes) does not
+// match b.Len.
+func (b Bits) Bools() ([]bool, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	if err := b.checkLen(); err != nil {
+		return nil, err
+	}
+
+	bools := make([]bool, b.Len)
+	for i := range bools {
+		bools[i] = b.Bytes[i/8]&(128>>uint(i%8)) != 0
+	}
+
+	return bools, nil
+}
+
+func bitsFromBools(bools []bool) Bits {
+	buf := make([]byte, (len(bools)+7)/8)
+	for i, set := range bools {
+		if set {
+			buf[i/8] |= 128 >> uint(i%8)
+		}
+	}
+
+	return Bits{Bytes: buf, Len: int32(len(bools)), Valid: true}
+}
+
 // Scan implements the database/sql Scanner interface.
 func (dst *Bits) Scan(src any) error {
 	if src == nil {
@@ -71,20 +152,39 @@ func (BitsCodec) PreferredFormat() int16 {
 }
 
 func (BitsCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
-	if _, ok := value.(BitsValuer); !ok {
-		return nil
-	}
-
+	var plan EncodePlan
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanBitsCodecBinary{}
+		plan = encodePlanBitsCodecBinary{}
 	case TextFormatCode:
-		return encodePlanBitsCodecText{}
+		plan = encodePlanBitsCodecText{}
+	default:
+		return nil
+	}
+
+	switch value.(type) {
+	case BitsValuer:
+		return plan
+	case []bool:
+		return &encodePlanBitsCodecBoolSlice{next: plan}
 	}
 
 	return nil
 }
 
+type encodePlanBitsCodecBoolSlice struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanBitsCodecBoolSlice) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	bools := value.([]bool)
+	if bools == nil {
+		return nil, nil
+	}
+
+	return plan.next.Encode(bitsFromBools(bools), buf)
+}
+
 type encodePlanBitsCodecBinary struct{}
 
 func (encodePlanBitsCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
@@ -127,20 +227,69 @@ func (encodePlanBitsCodecText) Encode(value any, buf []byte) (newBuf []byte, err
 }
 
 func (BitsCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
-
+	var plan ScanPlan
 	switch format {
 	case BinaryFormatCode:
-		switch target.(type) {
-		case BitsScanner:
-			return scanPlanBinaryBitsToBitsScanner{}
-		}
+		plan = scanPlanBinaryBitsToBitsScanner{}
 	case TextFormatCode:
-		switch target.(type) {
-		case BitsScanner:
-			return scanPlanTextAnyToBitsScanner{}
-		}
+		plan = scanPlanTextAnyToBitsScanner{}
+	default:
+		return nil
+	}
+
+	switch target.(type) {
+	case BitsScanner:
+		return plan
+	case *uint64:
+		return &scanPlanBitsToUint64{next: plan}
+	case *[]bool:
+		return &scanPlanBitsToBoolSlice{next: plan}
+	}
+
+	return nil
+}
+
+type scanPlanBitsToUint64 struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanBitsToUint64) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+
+	var bits Bits
+	err := plan.next.Scan(src, &bits)
+	if err != nil {
+		return err
+	}
+
+	v, err := bits.Uint64()
+	if err != nil {
+		return err
+	}
+
+	*(dst.(*uint64)) = v
+	return nil
+}
+
+type scanPlanBitsToBoolSlice struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanBitsToBoolSlice) Scan(src []byte, dst any) error {
+	var bits Bits
+	err := plan.next.Scan(src, &bits)
+	if err != nil {
+		return err
+	}
+
+	bools, err := bits.Bools()
+	if err != nil {
+		return err
 	}
 
+	*(dst.(*[]bool)) = bools
 	return nil
 }
 
@@ -3,10 +3,12 @@ package pgtype_test
 import (
 	"bytes"
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func isExpectedEqBits(a any) func(any) bool {
@@ -55,3 +57,89 @@ func TestBitsCodecVarbit(t *testing.T) {
 		{nil, new(pgtype.Bits), isExpectedEqBits(pgtype.Bits{})},
 	})
 }
+
+func TestBitsCodecUint64AndBoolSlice(t *testing.T) {
+	flags, err := pgtype.BitsFromUint64(0x8000000000000001, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "bit(64)", []pgxtest.ValueRoundTripTest{
+		{flags, new(uint64), isExpectedEq(uint64(0x8000000000000001))},
+		{flags, new([]bool), func(v any) bool {
+			bools := v.([]bool)
+			return len(bools) == 64 && bools[0] && bools[63] && !bools[1]
+		}},
+	})
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "varbit", []pgxtest.ValueRoundTripTest{
+		{[]bool{true, false, true}, new([]bool), func(v any) bool { return reflect.DeepEqual([]bool{true, false, true}, v) }},
+		{[]bool{true, false, true}, new(uint64), isExpectedEq(uint64(5))},
+		{[]bool{}, new(pgtype.Bits), isExpectedEqBits(pgtype.Bits{Bytes: []byte{}, Len: 0, Valid: true})},
+		{nil, new([]bool), func(v any) bool { return v.([]bool) == nil }},
+	})
+}
+
+func TestBitsUint64(t *testing.T) {
+	for _, tt := range []struct {
+		v     uint64
+		width int32
+		bits  pgtype.Bits
+	}{
+		{v: 0, width: 0, bits: pgtype.Bits{Bytes: []byte{}, Len: 0, Valid: true}},
+		{v: 5, width: 3, bits: pgtype.Bits{Bytes: []byte{0b10100000}, Len: 3, Valid: true}},
+		{v: 1, width: 9, bits: pgtype.Bits{Bytes: []byte{0, 0b10000000}, Len: 9, Valid: true}},
+		{v: 0xffffffffffffffff, width: 64, bits: pgtype.Bits{Bytes: bytes.Repeat([]byte{0xff}, 8), Len: 64, Valid: true}},
+	} {
+		bits, err := pgtype.BitsFromUint64(tt.v, tt.width)
+		require.NoError(t, err)
+		require.Equal(t, tt.bits, bits)
+
+		v, err := bits.Uint64()
+		require.NoError(t, err)
+		require.Equal(t, tt.v, v)
+	}
+
+	_, err := pgtype.BitsFromUint64(8, 3)
+	require.Error(t, err)
+
+	_, err = pgtype.BitsFromUint64(1, 65)
+	require.Error(t, err)
+
+	_, err = pgtype.Bits{Bytes: make([]byte, 9), Len: 65, Valid: true}.Uint64()
+	require.Error(t, err)
+
+	_, err = pgtype.Bits{}.Uint64()
+	require.Error(t, err)
+
+	_, err = pgtype.Bits{Bytes: []byte{0xff}, Len: 9, Valid: true}.Uint64()
+	require.Error(t, err)
+
+	bools, err := pgtype.Bits{Bytes: []byte{0b10100000}, Len: 3, Valid: true}.Bools()
+	require.NoError(t, err)
+	require.Equal(t, []bool{true, false, true}, bools)
+
+	bools, err = pgtype.Bits{}.Bools()
+	require.NoError(t, err)
+	require.Nil(t, bools)
+
+	_, err = pgtype.Bits{Bytes: []byte{0xff}, Len: 9, Valid: true}.Bools()
+	require.Error(t, err)
+
+	_, err = pgtype.Bits{Bytes: []byte{0xff}, Len: -1, Valid: true}.Bools()
+	require.Error(t, err)
+
+	m := pgtype.NewMap()
+	var scanned []bool
+	err = m.Scan(pgtype.VarbitOID, pgtype.BinaryFormatCode, []byte{0, 0, 0, 9, 0xff}, &scanned)
+	require.Error(t, err)
+
+	buf, err := m.Encode(pgtype.VarbitOID, pgtype.TextFormatCode, []bool{true, false, true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "101", string(buf))
+
+	var n uint64
+	err = m.Scan(pgtype.VarbitOID, pgtype.BinaryFormatCode, []byte{0, 0, 0, 3, 0b10100000}, &n)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, n)
+}
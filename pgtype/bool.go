@@ -70,6 +70,19 @@ func (src Bool) Value() (driver.Value, error) {
 	return src.Bool, nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (src Bool) Key() string {
+	if !src.Valid {
+		return ""
+	}
+
+	if src.Bool {
+		return "t"
+	}
+	return "f"
+}
+
 func (src Bool) MarshalJSON() ([]byte, error) {
 	if !src.Valid {
 		return []byte("null"), nil
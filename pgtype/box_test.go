@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBoxCodec(t *testing.T) {
@@ -38,3 +39,36 @@ func TestBoxCodec(t *testing.T) {
 		{nil, new(pgtype.Box), isExpectedEq(pgtype.Box{})},
 	})
 }
+
+func TestBoxArrayCodec(t *testing.T) {
+	skipCockroachDB(t, "Server does not support box type")
+
+	boxes := []pgtype.Box{
+		{P: [2]pgtype.Vec2{{7.1, 5.5}, {3.25, 1.5}}, Valid: true},
+		{},
+		{P: [2]pgtype.Vec2{{2, 2}, {-1, -1}}, Valid: true},
+	}
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "_box", []pgxtest.ValueRoundTripTest{
+		{boxes, new([]pgtype.Box), isExpectedEq(boxes)},
+		{[]pgtype.Box{}, new([]pgtype.Box), isExpectedEq([]pgtype.Box{})},
+	})
+}
+
+func TestBoxArrayCodecText(t *testing.T) {
+	m := pgtype.NewMap()
+
+	boxes := []pgtype.Box{
+		{P: [2]pgtype.Vec2{{7.1, 5.5}, {3.25, 1.5}}, Valid: true},
+		{},
+	}
+
+	buf, err := m.Encode(pgtype.BoxArrayOID, pgtype.TextFormatCode, boxes, nil)
+	require.NoError(t, err)
+	require.Equal(t, "{(7.1,5.5),(3.25,1.5);NULL}", string(buf))
+
+	var actual []pgtype.Box
+	err = m.Scan(pgtype.BoxArrayOID, pgtype.TextFormatCode, []byte("{(7.1,5.5),(3.25,1.5);NULL}"), &actual)
+	require.NoError(t, err)
+	require.Equal(t, boxes, actual)
+}
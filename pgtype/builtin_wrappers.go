@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/netip"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -504,8 +505,11 @@ func (w *durationWrapper) ScanInterval(v Interval) error {
 		return fmt.Errorf("cannot scan NULL into *time.Interval")
 	}
 
-	us := int64(v.Months)*microsecondsPerMonth + int64(v.Days)*microsecondsPerDay + v.Microseconds
-	*w = durationWrapper(time.Duration(us) * time.Microsecond)
+	d, err := v.durationAssumingMonthLength(microsecondsPerMonth)
+	if err != nil {
+		return err
+	}
+	*w = durationWrapper(d)
 	return nil
 }
 
@@ -617,15 +621,18 @@ func (w mapStringToPointerStringWrapper) HstoreValue() (Hstore, error) {
 	return Hstore(w), nil
 }
 
+// mapStringToStringWrapper scans NULL hstore values as empty strings. Use map[string]*string or Hstore to distinguish
+// NULL from "".
 type mapStringToStringWrapper map[string]string
 
 func (w *mapStringToStringWrapper) ScanHstore(v Hstore) error {
 	*w = make(mapStringToStringWrapper, len(v))
 	for k, v := range v {
 		if v == nil {
-			return fmt.Errorf("cannot scan NULL to string")
+			(*w)[k] = ""
+		} else {
+			(*w)[k] = *v
 		}
-		(*w)[k] = *v
 	}
 	return nil
 }
@@ -707,10 +714,20 @@ func (w byteSliceWrapper) UUIDValue() (UUID, error) {
 	return uuid, nil
 }
 
-// structWrapper implements CompositeIndexGetter for a struct.
+// structWrapper implements CompositeIndexGetter for a struct. namedFields is set as in ptrStructWrapper so a struct is
+// encoded with the same field mapping it is scanned with.
 type structWrapper struct {
 	s              any
 	exportedFields []reflect.Value
+	namedFields    map[string]reflect.Value
+}
+
+func newStructWrapper(value any, structValue reflect.Value) structWrapper {
+	return structWrapper{
+		s:              value,
+		exportedFields: getExportedFieldValues(structValue),
+		namedFields:    getNamedFieldValues(structValue),
+	}
 }
 
 func (w structWrapper) IsNull() bool {
@@ -725,10 +742,77 @@ func (w structWrapper) Index(i int) any {
 	return w.exportedFields[i].Interface()
 }
 
-// ptrStructWrapper implements CompositeIndexScanner for a pointer to a struct.
+func (w structWrapper) indexNamed(i int, name string) (any, error) {
+	if w.namedFields == nil {
+		return w.Index(i), nil
+	}
+
+	field, ok := lookupNamedField(w.namedFields, name)
+	if !ok {
+		return nil, fmt.Errorf("%T has no field for composite field %s", w.s, name)
+	}
+
+	return field.Interface(), nil
+}
+
+// ptrStructWrapper implements CompositeIndexScanner for a pointer to a struct. If any exported field has a pgx tag then
+// namedFields is set and fields are matched to composite fields by name instead of by position.
 type ptrStructWrapper struct {
 	s              any
 	exportedFields []reflect.Value
+	namedFields    map[string]reflect.Value
+}
+
+func newPtrStructWrapper(target any, structValue reflect.Value) ptrStructWrapper {
+	return ptrStructWrapper{
+		s:              target,
+		exportedFields: getExportedFieldValues(structValue),
+		namedFields:    getNamedFieldValues(structValue),
+	}
+}
+
+// getNamedFieldValues returns the exported fields of structValue keyed by composite field name if any of them has a pgx
+// tag. The name is the pgx tag or, for untagged fields, the lower case field name. Fields tagged "-" are omitted. It
+// returns nil if no field has a pgx tag.
+func getNamedFieldValues(structValue reflect.Value) map[string]reflect.Value {
+	structType := structValue.Type()
+	hasTag := false
+	for i := 0; i < structType.NumField(); i++ {
+		if _, ok := structType.Field(i).Tag.Lookup("pgx"); ok && structType.Field(i).IsExported() {
+			hasTag = true
+			break
+		}
+	}
+	if !hasTag {
+		return nil
+	}
+
+	namedFields := make(map[string]reflect.Value, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		sf := structType.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := strings.ToLower(sf.Name)
+		if tag, ok := sf.Tag.Lookup("pgx"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		namedFields[name] = structValue.Field(i)
+	}
+
+	return namedFields
+}
+
+func lookupNamedField(namedFields map[string]reflect.Value, name string) (reflect.Value, bool) {
+	field, ok := namedFields[name]
+	if !ok {
+		field, ok = namedFields[strings.ToLower(name)]
+	}
+	return field, ok
 }
 
 func (w *ptrStructWrapper) ScanNull() error {
@@ -743,6 +827,19 @@ func (w *ptrStructWrapper) ScanIndex(i int) any {
 	return w.exportedFields[i].Addr().Interface()
 }
 
+func (w *ptrStructWrapper) scanIndexNamed(i int, name string) (any, error) {
+	if w.namedFields == nil {
+		return w.ScanIndex(i), nil
+	}
+
+	field, ok := lookupNamedField(w.namedFields, name)
+	if !ok {
+		return nil, fmt.Errorf("%T has no field for composite field %s", w.s, name)
+	}
+
+	return field.Addr().Interface(), nil
+}
+
 type anySliceArrayReflect struct {
 	slice reflect.Value
 }
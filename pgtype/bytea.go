@@ -62,6 +62,70 @@ func (scanPlanAnyToUndecodedBytes) Scan(src []byte, dst any) error {
 	return nil
 }
 
+// Undecoded can be used as a scan target to get the raw bytes of a value along with the OID and format they were
+// received in. It can be used as a query argument to send the bytes back to PostgreSQL verbatim. A binary format value
+// can only be sent as a parameter of the same OID. The parameter OIDs must be known so it cannot be used with
+// QueryExecModeExec or QueryExecModeSimpleProtocol. A NULL is scanned as an Undecoded whose Bytes are nil and is sent
+// as NULL.
+type Undecoded struct {
+	bytes  []byte
+	oid    uint32
+	format int16
+}
+
+// Bytes returns the raw bytes of the value. It returns nil if the value is NULL.
+func (u Undecoded) Bytes() []byte {
+	return u.bytes
+}
+
+// OID returns the OID of the PostgreSQL type of the value.
+func (u Undecoded) OID() uint32 {
+	return u.oid
+}
+
+// Format returns the format code of the value.
+func (u Undecoded) Format() int16 {
+	return u.format
+}
+
+type scanPlanAnyToUndecoded struct {
+	oid    uint32
+	format int16
+}
+
+func (plan scanPlanAnyToUndecoded) Scan(src []byte, dst any) error {
+	u := dst.(*Undecoded)
+	*u = Undecoded{oid: plan.oid, format: plan.format}
+	if src != nil {
+		u.bytes = make([]byte, len(src))
+		copy(u.bytes, src)
+	}
+
+	return nil
+}
+
+type encodePlanUndecoded struct {
+	oid    uint32
+	format int16
+}
+
+func (plan encodePlanUndecoded) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	u := value.(Undecoded)
+	if u.bytes == nil {
+		return nil, nil
+	}
+
+	if u.format != plan.format {
+		return nil, fmt.Errorf("cannot encode value received in format %d in format %d", u.format, plan.format)
+	}
+
+	if u.format == BinaryFormatCode && plan.oid != 0 && u.oid != plan.oid {
+		return nil, fmt.Errorf("cannot encode binary value of OID %d as OID %d", u.oid, plan.oid)
+	}
+
+	return append(buf, u.bytes...), nil
+}
+
 type ByteaCodec struct{}
 
 func (ByteaCodec) FormatSupported(format int16) bool {
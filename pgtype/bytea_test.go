@@ -116,6 +116,68 @@ func TestUndecodedBytes(t *testing.T) {
 	})
 }
 
+func TestUndecoded(t *testing.T) {
+	ctx := context.Background()
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var u pgtype.Undecoded
+		err := conn.QueryRow(ctx, `select 1::int4`).Scan(&u)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0, 0, 0, 1}, u.Bytes())
+		require.EqualValues(t, pgtype.Int4OID, u.OID())
+		require.EqualValues(t, pgtype.BinaryFormatCode, u.Format())
+
+		var n int32
+		err = conn.QueryRow(ctx, `select $1::int4 + 1`, u).Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n)
+
+		err = conn.QueryRow(ctx, `select null::int4`).Scan(&u)
+		require.NoError(t, err)
+		require.Nil(t, u.Bytes())
+
+		var isNull bool
+		err = conn.QueryRow(ctx, `select $1::int4 is null`, u).Scan(&isNull)
+		require.NoError(t, err)
+		require.True(t, isNull)
+	})
+}
+
+func TestUndecodedWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var u pgtype.Undecoded
+	err := m.Scan(pgtype.Int8OID, pgtype.TextFormatCode, []byte("42"), &u)
+	require.NoError(t, err)
+	require.Equal(t, []byte("42"), u.Bytes())
+	require.EqualValues(t, pgtype.Int8OID, u.OID())
+	require.EqualValues(t, pgtype.TextFormatCode, u.Format())
+
+	buf, err := m.Encode(pgtype.Int4OID, pgtype.TextFormatCode, u, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("42"), buf)
+
+	_, err = m.Encode(pgtype.Int8OID, pgtype.BinaryFormatCode, u, nil)
+	require.Error(t, err)
+
+	err = m.Scan(pgtype.Int8OID, pgtype.BinaryFormatCode, []byte{0, 0, 0, 0, 0, 0, 0, 42}, &u)
+	require.NoError(t, err)
+
+	buf, err = m.Encode(pgtype.Int8OID, pgtype.BinaryFormatCode, u, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 42}, buf)
+
+	_, err = m.Encode(pgtype.Int4OID, pgtype.BinaryFormatCode, u, nil)
+	require.Error(t, err)
+
+	err = m.Scan(pgtype.JSONOID, pgtype.TextFormatCode, nil, &u)
+	require.NoError(t, err)
+	require.Nil(t, u.Bytes())
+
+	buf, err = m.Encode(pgtype.JSONOID, pgtype.TextFormatCode, u, nil)
+	require.NoError(t, err)
+	require.Nil(t, buf)
+}
+
 func TestByteaCodecDecodeDatabaseSQLValue(t *testing.T) {
 	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
 		var buf []byte
@@ -135,3 +197,84 @@ func TestByteaCodecDecodeDatabaseSQLValue(t *testing.T) {
 		require.Equal(t, buf, []byte{0xa1, 0xb2, 0xc3, 0xd4})
 	})
 }
+
+// binaryMoney is a type with its own binary serialization and no pgtype support.
+type binaryMoney struct {
+	currency string
+	cents    int64
+}
+
+func (m binaryMoney) MarshalBinary() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s:%d", m.currency, m.cents)), nil
+}
+
+func (m *binaryMoney) UnmarshalBinary(data []byte) error {
+	_, err := fmt.Sscanf(string(data), "%3s:%d", &m.currency, &m.cents)
+	return err
+}
+
+func TestByteaCodecBinaryMarshaler(t *testing.T) {
+	// Only testing known OID query exec modes as an encoding.BinaryMarshaler is not mapped to a PostgreSQL type.
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, "bytea", []pgxtest.ValueRoundTripTest{
+		{binaryMoney{currency: "USD", cents: 1234}, new(binaryMoney), isExpectedEq(binaryMoney{currency: "USD", cents: 1234})},
+		{binaryMoney{currency: "EUR", cents: -5}, new([]byte), isExpectedEqBytes([]byte("EUR:-5"))},
+		{[]byte("JPY:100"), new(binaryMoney), isExpectedEq(binaryMoney{currency: "JPY", cents: 100})},
+		{nil, new(*binaryMoney), isExpectedEq((*binaryMoney)(nil))},
+	})
+}
+
+func TestByteaCodecBinaryMarshalerWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.ByteaOID, format, binaryMoney{currency: "USD", cents: 1234}, nil)
+		require.NoError(t, err)
+
+		var money binaryMoney
+		err = m.Scan(pgtype.ByteaOID, format, buf, &money)
+		require.NoError(t, err)
+		require.Equal(t, binaryMoney{currency: "USD", cents: 1234}, money)
+
+		err = m.Scan(pgtype.ByteaOID, format, nil, &money)
+		require.EqualError(t, err, "cannot scan NULL into *pgtype_test.binaryMoney")
+	}
+
+	buf, err := m.Encode(pgtype.ByteaOID, pgtype.BinaryFormatCode, binaryMoney{currency: "USD", cents: 1234}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("USD:1234"), buf)
+}
+
+// binaryMarshalerString is a named string type that also implements encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler.
+type binaryMarshalerString string
+
+func (s binaryMarshalerString) MarshalBinary() ([]byte, error) {
+	return []byte("marshaled:" + s), nil
+}
+
+func (s *binaryMarshalerString) UnmarshalBinary(data []byte) error {
+	*s = binaryMarshalerString("unmarshaled:" + string(data))
+	return nil
+}
+
+func TestBinaryMarshalerNamedStringUsesUnderlyingType(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, tt := range []struct {
+		oid uint32
+		src string
+	}{
+		{pgtype.TextOID, "abc"},
+		{pgtype.VarcharOID, "abc"},
+		{pgtype.JSONOID, `"abc"`},
+	} {
+		buf, err := m.Encode(tt.oid, pgtype.TextFormatCode, binaryMarshalerString("abc"), nil)
+		require.NoError(t, err)
+		require.NotContains(t, string(buf), "marshaled:")
+
+		var s binaryMarshalerString
+		err = m.Scan(tt.oid, pgtype.TextFormatCode, []byte(tt.src), &s)
+		require.NoError(t, err)
+		require.Equal(t, binaryMarshalerString("abc"), s)
+	}
+}
@@ -28,6 +28,34 @@ type CompositeIndexScanner interface {
 	ScanIndex(i int) any
 }
 
+// compositeNamedIndexGetter is a CompositeIndexGetter that can choose values by composite field name.
+type compositeNamedIndexGetter interface {
+	indexNamed(i int, name string) (any, error)
+}
+
+// compositeIndex returns the value of field i of a composite.
+func compositeIndex(getter CompositeIndexGetter, i int, field CompositeCodecField) (any, error) {
+	if namedGetter, ok := getter.(compositeNamedIndexGetter); ok {
+		return namedGetter.indexNamed(i, field.Name)
+	}
+
+	return getter.Index(i), nil
+}
+
+// compositeNamedIndexScanner is a CompositeIndexScanner that can choose scan targets by composite field name.
+type compositeNamedIndexScanner interface {
+	scanIndexNamed(i int, name string) (any, error)
+}
+
+// compositeScanIndex returns the scan target for field i of a composite.
+func compositeScanIndex(targetScanner CompositeIndexScanner, i int, field CompositeCodecField) (any, error) {
+	if namedScanner, ok := targetScanner.(compositeNamedIndexScanner); ok {
+		return namedScanner.scanIndexNamed(i, field.Name)
+	}
+
+	return targetScanner.ScanIndex(i), nil
+}
+
 type CompositeCodecField struct {
 	Name string
 	Type *Type
@@ -83,7 +111,11 @@ func (plan *encodePlanCompositeCodecCompositeIndexGetterToBinary) Encode(value a
 
 	builder := NewCompositeBinaryBuilder(plan.m, buf)
 	for i, field := range plan.cc.Fields {
-		builder.AppendValue(field.Type.OID, getter.Index(i))
+		fieldValue, err := compositeIndex(getter, i, field)
+		if err != nil {
+			return nil, err
+		}
+		builder.AppendValue(field.Type.OID, fieldValue)
 	}
 
 	return builder.Finish()
@@ -103,7 +135,11 @@ func (plan *encodePlanCompositeCodecCompositeIndexGetterToText) Encode(value any
 
 	b := NewCompositeTextBuilder(plan.m, buf)
 	for i, field := range plan.cc.Fields {
-		b.AppendValue(field.Type.OID, getter.Index(i))
+		fieldValue, err := compositeIndex(getter, i, field)
+		if err != nil {
+			return nil, err
+		}
+		b.AppendValue(field.Type.OID, fieldValue)
 	}
 
 	return b.Finish()
@@ -141,7 +177,10 @@ func (plan *scanPlanBinaryCompositeToCompositeIndexScanner) Scan(src []byte, tar
 	scanner := NewCompositeBinaryScanner(plan.m, src)
 	for i, field := range plan.cc.Fields {
 		if scanner.Next() {
-			fieldTarget := targetScanner.ScanIndex(i)
+			fieldTarget, err := compositeScanIndex(targetScanner, i, field)
+			if err != nil {
+				return err
+			}
 			if fieldTarget != nil {
 				fieldPlan := plan.m.PlanScan(field.Type.OID, BinaryFormatCode, fieldTarget)
 				if fieldPlan == nil {
@@ -180,7 +219,10 @@ func (plan *scanPlanTextCompositeToCompositeIndexScanner) Scan(src []byte, targe
 	scanner := NewCompositeTextScanner(plan.m, src)
 	for i, field := range plan.cc.Fields {
 		if scanner.Next() {
-			fieldTarget := targetScanner.ScanIndex(i)
+			fieldTarget, err := compositeScanIndex(targetScanner, i, field)
+			if err != nil {
+				return err
+			}
 			if fieldTarget != nil {
 				fieldPlan := plan.m.PlanScan(field.Type.OID, TextFormatCode, fieldTarget)
 				if fieldPlan == nil {
@@ -253,3 +253,168 @@ create table point3d (
 		}
 	})
 }
+
+func TestCompositeCodecScanStructByTag(t *testing.T) {
+	m := pgtype.NewMap()
+	textType, _ := m.TypeForOID(pgtype.TextOID)
+	m.RegisterType(&pgtype.Type{
+		Name: "address",
+		OID:  100001,
+		Codec: &pgtype.CompositeCodec{
+			Fields: []pgtype.CompositeCodecField{
+				{Name: "street", Type: textType},
+				{Name: "city", Type: textType},
+				{Name: "zip", Type: textType},
+			},
+		},
+	})
+
+	type address struct {
+		Zip    *string `pgx:"zip"`
+		Town   string  `pgx:"city"`
+		Street string
+		Ignore string `pgx:"-"`
+	}
+
+	var a address
+	err := m.Scan(100001, pgx.TextFormatCode, []byte(`("1 Main St",Springfield,)`), &a)
+	require.NoError(t, err)
+	require.Equal(t, address{Street: "1 Main St", Town: "Springfield"}, a)
+
+	var pa *address
+	err = m.Scan(100001, pgx.TextFormatCode, nil, &pa)
+	require.NoError(t, err)
+	require.Nil(t, pa)
+
+	err = m.Scan(100001, pgx.TextFormatCode, []byte(`("1 Main St",Springfield,12345)`), &pa)
+	require.NoError(t, err)
+	require.NotNil(t, pa)
+	require.Equal(t, "12345", *pa.Zip)
+
+	err = m.Scan(100001, pgx.TextFormatCode, nil, &a)
+	require.Error(t, err)
+
+	type missingField struct {
+		Street string `pgx:"street"`
+		City   string
+	}
+	var mf missingField
+	err = m.Scan(100001, pgx.TextFormatCode, []byte(`("1 Main St",Springfield,12345)`), &mf)
+	require.ErrorContains(t, err, "has no field for composite field zip")
+
+	type nullField struct {
+		Street string
+		City   string
+		Zip    string `pgx:"zip"`
+	}
+	var nf nullField
+	err = m.Scan(100001, pgx.TextFormatCode, []byte(`("1 Main St",Springfield,)`), &nf)
+	require.Error(t, err)
+
+	// Encoding uses the same mapping so a struct round trips even though its field order differs from the composite.
+	zip := "12345"
+	for _, format := range []int16{pgx.TextFormatCode, pgx.BinaryFormatCode} {
+		buf, err := m.Encode(100001, format, address{Zip: &zip, Town: "Springfield", Street: "1 Main St", Ignore: "x"}, nil)
+		require.NoError(t, err)
+
+		var rt address
+		err = m.Scan(100001, format, buf, &rt)
+		require.NoError(t, err)
+		require.Equal(t, address{Zip: &zip, Town: "Springfield", Street: "1 Main St"}, rt)
+	}
+
+	buf, err := m.Encode(100001, pgx.TextFormatCode, address{Zip: &zip, Town: "Springfield", Street: "1 Main St"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, `(1 Main St,Springfield,12345)`, string(buf))
+
+	_, err = m.Encode(100001, pgx.TextFormatCode, missingField{Street: "1 Main St"}, nil)
+	require.ErrorContains(t, err, "has no field for composite field zip")
+}
+
+func TestCompositeCodecTranscodeStructByTag(t *testing.T) {
+	skipCockroachDB(t, "Server does not support composite types (see https://github.com/cockroachdb/cockroach/issues/27792)")
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+
+		_, err := conn.Exec(ctx, `drop type if exists address;
+
+create type address as (
+	street text,
+	city text,
+	zip text
+);`)
+		require.NoError(t, err)
+		defer conn.Exec(ctx, "drop type address")
+
+		dt, err := conn.LoadType(ctx, "address")
+		require.NoError(t, err)
+		conn.TypeMap().RegisterType(dt)
+
+		type address struct {
+			City   string  `pgx:"city"`
+			Zip    *string `pgx:"zip"`
+			Street string  `pgx:"street"`
+		}
+
+		formats := []struct {
+			name string
+			code int16
+		}{
+			{name: "TextFormat", code: pgx.TextFormatCode},
+			{name: "BinaryFormat", code: pgx.BinaryFormatCode},
+		}
+
+		for _, format := range formats {
+			var output address
+			err := conn.QueryRow(ctx, "select row('1 Main St', 'Springfield', null)::address", pgx.QueryResultFormats{format.code}).Scan(&output)
+			require.NoErrorf(t, err, "%v", format.name)
+			require.Equalf(t, address{Street: "1 Main St", City: "Springfield"}, output, "%v", format.name)
+
+			var roundTripped address
+			err = conn.QueryRow(ctx, "select $1::address", pgx.QueryResultFormats{format.code}, output).Scan(&roundTripped)
+			require.NoErrorf(t, err, "%v", format.name)
+			require.Equalf(t, output, roundTripped, "%v", format.name)
+
+			var ptrOutput *address
+			err = conn.QueryRow(ctx, "select null::address", pgx.QueryResultFormats{format.code}).Scan(&ptrOutput)
+			require.NoErrorf(t, err, "%v", format.name)
+			require.Nilf(t, ptrOutput, "%v", format.name)
+		}
+	})
+}
+
+func TestCompositeCodecArrayScan(t *testing.T) {
+	m := pgtype.NewMap()
+	textType, _ := m.TypeForOID(pgtype.TextOID)
+	m.RegisterType(&pgtype.Type{
+		Name: "address",
+		OID:  100001,
+		Codec: &pgtype.CompositeCodec{
+			Fields: []pgtype.CompositeCodecField{
+				{Name: "street", Type: textType},
+				{Name: "city", Type: textType},
+			},
+		},
+	})
+	addressType, _ := m.TypeForOID(100001)
+	m.RegisterType(&pgtype.Type{Name: "_address", OID: 100002, Codec: &pgtype.ArrayCodec{ElementType: addressType}})
+
+	type address struct {
+		Street string
+		City   string
+	}
+
+	for _, format := range []int16{pgx.TextFormatCode, pgx.BinaryFormatCode} {
+		buf, err := m.Encode(100002, format, []*address{{Street: "1 Main St", City: "Springfield"}, nil}, nil)
+		require.NoError(t, err)
+
+		var addresses []*address
+		err = m.Scan(100002, format, buf, &addresses)
+		require.NoError(t, err)
+		require.Equal(t, []*address{{Street: "1 Main St", City: "Springfield"}, nil}, addresses)
+
+		var nonNullAddresses []address
+		err = m.Scan(100002, format, buf, &nonNullAddresses)
+		require.Error(t, err)
+	}
+}
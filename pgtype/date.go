@@ -70,6 +70,21 @@ func (src Date) Value() (driver.Value, error) {
 	return src.Time, nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (d Date) Key() string {
+	if !d.Valid {
+		return ""
+	}
+
+	if d.InfinityModifier != Finite {
+		return d.InfinityModifier.String()
+	}
+
+	year, month, day := d.Time.Date()
+	return fmt.Sprintf("%d-%02d-%02d", year, month, day)
+}
+
 func (src Date) MarshalJSON() ([]byte, error) {
 	if !src.Valid {
 		return []byte("null"), nil
@@ -55,7 +55,23 @@ similar fashion to database/sql. The second is to use a pointer to a pointer.
 
 JSON Support
 
-pgtype automatically marshals and unmarshals data from json and jsonb PostgreSQL types.
+pgtype automatically marshals and unmarshals data from json and jsonb PostgreSQL types. encoding/json is used by
+default. A different implementation or stricter decoding can be used by registering JSONCodec and JSONBCodec with
+Funcs set. e.g.
+
+    conn.TypeMap().RegisterType(&pgtype.Type{Name: "jsonb", OID: pgtype.JSONBOID, Codec: pgtype.JSONBCodec{
+        Funcs: &pgtype.JSONFuncs{
+            Marshal: json.Marshal,
+            Unmarshal: func(data []byte, v any) error {
+                decoder := json.NewDecoder(bytes.NewReader(data))
+                decoder.DisallowUnknownFields()
+                return decoder.Decode(v)
+            },
+        },
+    }})
+
+Array types such as jsonb[] keep the element type they were registered with. Register them again with an ArrayCodec
+whose ElementType is the new type if arrays should use it as well.
 
 Extending Existing PostgreSQL Type Support
 
@@ -101,16 +117,30 @@ For example, the following function could be called after a connection is establ
     }
 
 A type cannot be registered unless all types it depends on are already registered. e.g. An array type cannot be
-registered until its element type is registered.
+registered until its element type is registered. pgx.Conn LoadType handles this for arrays by also loading and
+registering the element type when it is not already registered.
 
 ArrayCodec implements support for arrays. If pgtype supports type T then it can easily support []T by registering an
 ArrayCodec for the appropriate PostgreSQL OID. In addition, Array[T] type can support multi-dimensional arrays.
+Array[T].Dims holds the length and lower bound of each dimension. A multi-dimensional array can also be scanned into a
+multi-dimensional slice such as [][]float64 with the same shape. Scanning into a one-dimensional slice flattens the
+elements in row-major order. When decoded without a scan target, e.g. by Rows.Values, a multi-dimensional array is
+returned as nested []any slices.
+
+A NULL array and an empty array remain distinct when scanned into a slice. NULL sets the slice to nil and '{}' sets it
+to a non-nil slice of length 0. Likewise a nil slice is encoded as NULL and an empty slice as '{}'. Array[T] represents
+NULL with Valid set to false.
 
 CompositeCodec implements support for PostgreSQL composite types. Go structs can be scanned into if the public fields of
 the struct are in the exact order and type of the PostgreSQL type or by implementing CompositeIndexScanner and
-CompositeIndexGetter.
+CompositeIndexGetter. If any public field has a pgx tag then the fields are instead matched to the composite fields by
+name. The tag gives the composite field name and untagged fields are matched by case-insensitive field name. A tag of
+"-" ignores the field. A NULL composite can be scanned into a pointer to a struct pointer, and NULL composite fields
+require struct fields that can hold NULL such as pointers or pgtype.Text.
 
-Domain types are treated as their underlying type if the underlying type and the domain type are registered.
+Domain types are treated as their underlying type if the underlying type and the domain type are registered. When
+pgx.Conn prepares or describes a statement that uses an unregistered domain whose underlying type is registered, the
+domain is registered automatically with the codec of the underlying type.
 
 PostgreSQL enums can usually be treated as text. However, EnumCodec implements support for interning strings which can
 reduce memory usage.
@@ -127,6 +157,8 @@ Encoding Unknown Types
 
 pgtype works best when the OID of the PostgreSQL type is known. But in some cases such as using the simple protocol the
 OID is unknown. In this case Map.RegisterDefaultPgType can be used to register an assumed OID for a particular Go type.
+RegisterDefaultPgTypeVariants registers a Go type along with its pointer and slice variants. This also lets Go types be
+used with types whose OID differs per database, such as domains, without registering the OID.
 
 Renamed Types
 
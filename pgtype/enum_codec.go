@@ -9,6 +9,10 @@ import (
 // allocated. These strings are only garbage collected when the EnumCodec is garbage collected. EnumCodec can be used
 // for any text type not only enums, but it should only be used when there are a small number of possible values.
 type EnumCodec struct {
+	// Labels are the valid values of the enum. If Labels is not empty then values are checked against it when encoding
+	// so an invalid value is rejected before it is sent to the server.
+	Labels []string
+
 	membersMap map[string]string // map to quickly lookup member and reuse string instead of allocating
 }
 
@@ -20,20 +24,52 @@ func (EnumCodec) PreferredFormat() int16 {
 	return TextFormatCode
 }
 
-func (EnumCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+func (c EnumCodec) validatesText() bool {
+	return len(c.Labels) > 0
+}
+
+func (c EnumCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	var plan EncodePlan
+
 	switch format {
 	case TextFormatCode, BinaryFormatCode:
 		switch value.(type) {
 		case string:
-			return encodePlanTextCodecString{}
+			plan = encodePlanTextCodecString{}
 		case []byte:
-			return encodePlanTextCodecByteSlice{}
+			plan = encodePlanTextCodecByteSlice{}
 		case TextValuer:
-			return encodePlanTextCodecTextValuer{}
+			plan = encodePlanTextCodecTextValuer{}
 		}
 	}
 
-	return nil
+	if plan != nil && len(c.Labels) > 0 {
+		return &encodePlanEnumCodecCheckLabels{labels: c.Labels, next: plan}
+	}
+
+	return plan
+}
+
+type encodePlanEnumCodecCheckLabels struct {
+	labels []string
+	next   EncodePlan
+}
+
+func (plan *encodePlanEnumCodecCheckLabels) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	start := len(buf)
+	newBuf, err = plan.next.Encode(value, buf)
+	if err != nil || newBuf == nil {
+		return newBuf, err
+	}
+
+	label := string(newBuf[start:])
+	for _, l := range plan.labels {
+		if l == label {
+			return newBuf, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid enum value: %q", label)
 }
 
 func (c *EnumCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/require"
 )
 
@@ -67,3 +68,23 @@ create type enum_test as enum ('foo', 'bar', 'baz');`)
 		require.Equal(t, values, []any{"foo"})
 	})
 }
+
+func TestEnumCodecLabels(t *testing.T) {
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "enum_test", OID: 999999, Codec: &pgtype.EnumCodec{Labels: []string{"foo", "bar"}}})
+
+	buf, err := m.Encode(999999, pgtype.TextFormatCode, "foo", nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("foo"), buf)
+
+	buf, err = m.Encode(999999, pgtype.TextFormatCode, pgtype.Text{String: "bar", Valid: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("bar"), buf)
+
+	buf, err = m.Encode(999999, pgtype.TextFormatCode, pgtype.Text{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, buf)
+
+	_, err = m.Encode(999999, pgtype.TextFormatCode, "qux", nil)
+	require.ErrorContains(t, err, `invalid enum value: "qux"`)
+}
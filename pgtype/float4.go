@@ -65,6 +65,20 @@ func (f Float4) Value() (driver.Value, error) {
 	return float64(f.Float32), nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (f Float4) Key() string {
+	if !f.Valid {
+		return ""
+	}
+
+	// -0 and 0 are equal.
+	if f.Float32 == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(float64(f.Float32), 'g', -1, 32)
+}
+
 type Float4Codec struct{}
 
 func (Float4Codec) FormatSupported(format int16) bool {
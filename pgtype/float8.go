@@ -74,6 +74,20 @@ func (f Float8) Value() (driver.Value, error) {
 	return f.Float64, nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (f Float8) Key() string {
+	if !f.Valid {
+		return ""
+	}
+
+	// -0 and 0 are equal.
+	if f.Float64 == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(f.Float64, 'g', -1, 64)
+}
+
 type Float8Codec struct{}
 
 func (Float8Codec) FormatSupported(format int16) bool {
@@ -20,6 +20,9 @@ type HstoreValuer interface {
 
 // Hstore represents an hstore column that can be null or have null values
 // associated with its keys.
+//
+// An hstore can also be scanned into a map[string]*string, which preserves NULL values as nil pointers, or into a
+// map[string]string. The latter scans NULL values as empty strings, so NULL and "" cannot be told apart.
 type Hstore map[string]*string
 
 func (h *Hstore) ScanHstore(v Hstore) error {
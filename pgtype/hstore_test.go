@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func isExpectedEqMapStringString(a any) func(any) bool {
@@ -246,6 +247,28 @@ func TestHstoreCodec(t *testing.T) {
 	})
 }
 
+func TestHstoreCodecScanMaps(t *testing.T) {
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "hstore", OID: 100002, Codec: pgtype.HstoreCodec{}})
+
+	h := pgtype.Hstore{"null": nil, "empty": stringPtr(""), "foo": stringPtr("bar")}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(100002, format, h, nil)
+		require.NoError(t, err)
+
+		var ptrMap map[string]*string
+		err = m.Scan(100002, format, buf, &ptrMap)
+		require.NoError(t, err)
+		require.Equal(t, map[string]*string{"null": nil, "empty": stringPtr(""), "foo": stringPtr("bar")}, ptrMap)
+
+		var strMap map[string]string
+		err = m.Scan(100002, format, buf, &strMap)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"null": "", "empty": "", "foo": "bar"}, strMap)
+	}
+}
+
 func TestParseInvalidInputs(t *testing.T) {
 	// these inputs should be invalid, but previously were considered correct
 	invalidInputs := []string{
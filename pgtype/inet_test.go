@@ -6,7 +6,9 @@ import (
 	"net/netip"
 	"testing"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func isExpectedEqIPNet(a any) func(any) bool {
@@ -97,3 +99,37 @@ func TestCidrTranscode(t *testing.T) {
 		{nil, new(netip.Prefix), isExpectedEq(netip.Prefix{})},
 	})
 }
+
+func TestInetCodecNetipWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		addrs := []netip.Addr{netip.MustParseAddr("127.0.0.1"), netip.MustParseAddr("::1")}
+		buf, err := m.Encode(pgtype.InetArrayOID, format, addrs, nil)
+		require.NoError(t, err)
+		var gotAddrs []netip.Addr
+		err = m.Scan(pgtype.InetArrayOID, format, buf, &gotAddrs)
+		require.NoError(t, err)
+		require.Equal(t, addrs, gotAddrs)
+
+		prefixes := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::/32")}
+		buf, err = m.Encode(pgtype.CIDRArrayOID, format, prefixes, nil)
+		require.NoError(t, err)
+		var gotPrefixes []netip.Prefix
+		err = m.Scan(pgtype.CIDRArrayOID, format, buf, &gotPrefixes)
+		require.NoError(t, err)
+		require.Equal(t, prefixes, gotPrefixes)
+
+		// A network cannot be represented by netip.Addr.
+		buf, err = m.Encode(pgtype.CIDROID, format, netip.MustParsePrefix("10.0.0.0/8"), nil)
+		require.NoError(t, err)
+		var addr netip.Addr
+		err = m.Scan(pgtype.CIDROID, format, buf, &addr)
+		require.Error(t, err)
+
+		var nullAddr *netip.Addr
+		err = m.Scan(pgtype.InetOID, format, nil, &nullAddr)
+		require.NoError(t, err)
+		require.Nil(t, nullAddr)
+	}
+}
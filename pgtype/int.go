@@ -94,6 +94,16 @@ func (src Int2) Value() (driver.Value, error) {
 	return int64(src.Int16), nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (src Int2) Key() string {
+	if !src.Valid {
+		return ""
+	}
+
+	return strconv.FormatInt(int64(src.Int16), 10)
+}
+
 func (src Int2) MarshalJSON() ([]byte, error) {
 	if !src.Valid {
 		return []byte("null"), nil
@@ -654,6 +664,16 @@ func (src Int4) Value() (driver.Value, error) {
 	return int64(src.Int32), nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (src Int4) Key() string {
+	if !src.Valid {
+		return ""
+	}
+
+	return strconv.FormatInt(int64(src.Int32), 10)
+}
+
 func (src Int4) MarshalJSON() ([]byte, error) {
 	if !src.Valid {
 		return []byte("null"), nil
@@ -1225,6 +1245,16 @@ func (src Int8) Value() (driver.Value, error) {
 	return int64(src.Int64), nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (src Int8) Key() string {
+	if !src.Valid {
+		return ""
+	}
+
+	return strconv.FormatInt(int64(src.Int64), 10)
+}
+
 func (src Int8) MarshalJSON() ([]byte, error) {
 	if !src.Valid {
 		return []byte("null"), nil
@@ -4,8 +4,10 @@ import (
 	"database/sql/driver"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/internal/pgio"
 )
@@ -33,6 +35,12 @@ type Interval struct {
 	Valid        bool
 }
 
+// infinityInterval and negativeInfinityInterval are the values PostgreSQL 17 uses for the infinite intervals.
+var (
+	infinityInterval         = Interval{Microseconds: math.MaxInt64, Days: math.MaxInt32, Months: math.MaxInt32, Valid: true}
+	negativeInfinityInterval = Interval{Microseconds: math.MinInt64, Days: math.MinInt32, Months: math.MinInt32, Valid: true}
+)
+
 func (interval *Interval) ScanInterval(v Interval) error {
 	*interval = v
 	return nil
@@ -70,6 +78,277 @@ func (interval Interval) Value() (driver.Value, error) {
 	return string(buf), err
 }
 
+// Duration returns interval as a time.Duration. Months do not have a fixed length so an error is returned if Months is
+// not 0. An error is also returned if interval is NULL or does not fit in a time.Duration.
+//
+// Scanning an interval into a *time.Duration instead treats a month as 30 days.
+func (interval Interval) Duration() (time.Duration, error) {
+	if !interval.Valid {
+		return 0, fmt.Errorf("cannot convert NULL interval to time.Duration")
+	}
+	if interval.Months != 0 {
+		return 0, fmt.Errorf("cannot convert interval with %d months to time.Duration: months have a variable length", interval.Months)
+	}
+
+	return interval.durationAssumingMonthLength(microsecondsPerMonth)
+}
+
+// durationAssumingMonthLength converts interval to a time.Duration with each month monthMicroseconds long.
+func (interval Interval) durationAssumingMonthLength(monthMicroseconds int64) (time.Duration, error) {
+	us, ok := addIntervalDurationMicroseconds(0, interval.Microseconds, 1)
+	if ok {
+		us, ok = addIntervalDurationMicroseconds(us, int64(interval.Days), microsecondsPerDay)
+	}
+	if ok {
+		us, ok = addIntervalDurationMicroseconds(us, int64(interval.Months), monthMicroseconds)
+	}
+	if !ok {
+		return 0, fmt.Errorf("interval %d months %d days %d microseconds overflows time.Duration", interval.Months, interval.Days, interval.Microseconds)
+	}
+
+	return time.Duration(us) * time.Microsecond, nil
+}
+
+// addIntervalDurationMicroseconds returns acc + n*unit. ok is false if the result cannot be represented in a
+// time.Duration.
+func addIntervalDurationMicroseconds(acc, n, unit int64) (sum int64, ok bool) {
+	const maxMicroseconds = int64(math.MaxInt64 / time.Microsecond)
+
+	if n > maxMicroseconds/unit || n < -maxMicroseconds/unit {
+		return 0, false
+	}
+
+	// acc and n*unit are both within maxMicroseconds so the sum cannot overflow an int64.
+	sum = acc + n*unit
+	if sum > maxMicroseconds || sum < -maxMicroseconds {
+		return 0, false
+	}
+
+	return sum, true
+}
+
+// ISO8601 returns interval in the ISO 8601 duration format such as P1Y2M3DT4H5M6.5S. Like PostgreSQL's iso_8601
+// IntervalStyle, each component carries its own sign. The PostgreSQL 17 infinite intervals are returned as infinity
+// and -infinity as PostgreSQL does in every IntervalStyle. It returns an empty string if interval is NULL.
+func (interval Interval) ISO8601() string {
+	if !interval.Valid {
+		return ""
+	}
+
+	switch interval {
+	case infinityInterval:
+		return "infinity"
+	case negativeInfinityInterval:
+		return "-infinity"
+	}
+
+	buf := []byte{'P'}
+
+	years := interval.Months / 12
+	months := interval.Months % 12
+	if years != 0 {
+		buf = strconv.AppendInt(buf, int64(years), 10)
+		buf = append(buf, 'Y')
+	}
+	if months != 0 {
+		buf = strconv.AppendInt(buf, int64(months), 10)
+		buf = append(buf, 'M')
+	}
+	if interval.Days != 0 {
+		buf = strconv.AppendInt(buf, int64(interval.Days), 10)
+		buf = append(buf, 'D')
+	}
+
+	if interval.Microseconds != 0 {
+		buf = append(buf, 'T')
+
+		// The magnitude is computed as unsigned so math.MinInt64 does not overflow.
+		absMicroseconds := uint64(interval.Microseconds)
+		sign := ""
+		if interval.Microseconds < 0 {
+			absMicroseconds = uint64(-(interval.Microseconds + 1)) + 1
+			sign = "-"
+		}
+
+		hours := absMicroseconds / microsecondsPerHour
+		minutes := (absMicroseconds % microsecondsPerHour) / microsecondsPerMinute
+		seconds := (absMicroseconds % microsecondsPerMinute) / microsecondsPerSecond
+		microseconds := absMicroseconds % microsecondsPerSecond
+
+		if hours != 0 {
+			buf = append(buf, sign...)
+			buf = strconv.AppendUint(buf, hours, 10)
+			buf = append(buf, 'H')
+		}
+		if minutes != 0 {
+			buf = append(buf, sign...)
+			buf = strconv.AppendUint(buf, minutes, 10)
+			buf = append(buf, 'M')
+		}
+		if seconds != 0 || microseconds != 0 {
+			buf = append(buf, sign...)
+			buf = strconv.AppendUint(buf, seconds, 10)
+			if microseconds != 0 {
+				buf = append(buf, '.')
+				buf = append(buf, strings.TrimRight(fmt.Sprintf("%06d", microseconds), "0")...)
+			}
+			buf = append(buf, 'S')
+		}
+	}
+
+	if len(buf) == 1 {
+		return "PT0S"
+	}
+
+	return string(buf)
+}
+
+// ParseInterval parses s as a Go duration string such as 1h30m in the format accepted by time.ParseDuration or as an
+// ISO 8601 duration such as P1DT2H. A Go duration is stored entirely in Microseconds with any sub-microsecond precision
+// truncated. In an ISO 8601 duration each component may carry its own sign as in the output of Interval.ISO8601 and
+// only seconds may have a fractional part. infinity and -infinity are parsed as the PostgreSQL 17 infinite intervals.
+func ParseInterval(s string) (Interval, error) {
+	switch s {
+	case "infinity":
+		return infinityInterval, nil
+	case "-infinity":
+		return negativeInfinityInterval, nil
+	}
+
+	if strings.HasPrefix(s, "P") {
+		return parseISO8601Interval(s)
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return Interval{}, fmt.Errorf("cannot parse %q as interval: %w", s, err)
+	}
+
+	return Interval{Microseconds: d.Microseconds(), Valid: true}, nil
+}
+
+func parseISO8601Interval(s string) (Interval, error) {
+	var months, days, microseconds int64
+	inTime := false
+
+	rest := s[1:]
+	if rest == "" {
+		return Interval{}, fmt.Errorf("cannot parse %q as interval: no components", s)
+	}
+
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return Interval{}, fmt.Errorf("cannot parse %q as interval: misplaced T", s)
+			}
+			inTime = true
+			rest = rest[1:]
+			continue
+		}
+
+		i := strings.IndexAny(rest, "YMWDHS")
+		if i <= 0 {
+			return Interval{}, fmt.Errorf("cannot parse %q as interval: bad component %q", s, rest)
+		}
+		num, designator := rest[:i], rest[i]
+		rest = rest[i+1:]
+
+		if inTime && designator == 'S' {
+			n, err := parseISO8601IntervalSeconds(num)
+			if err != nil {
+				return Interval{}, fmt.Errorf("cannot parse %q as interval: %w", s, err)
+			}
+			var ok bool
+			if microseconds, ok = addIntervalComponent(microseconds, n, 1, math.MaxInt64); !ok {
+				return Interval{}, fmt.Errorf("cannot parse %q as interval: out of range", s)
+			}
+			continue
+		}
+
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return Interval{}, fmt.Errorf("cannot parse %q as interval: bad number %q", s, num)
+		}
+
+		var ok bool
+		switch {
+		case !inTime && designator == 'Y':
+			months, ok = addIntervalComponent(months, n, 12, math.MaxInt32)
+		case !inTime && designator == 'M':
+			months, ok = addIntervalComponent(months, n, 1, math.MaxInt32)
+		case !inTime && designator == 'W':
+			days, ok = addIntervalComponent(days, n, 7, math.MaxInt32)
+		case !inTime && designator == 'D':
+			days, ok = addIntervalComponent(days, n, 1, math.MaxInt32)
+		case inTime && designator == 'H':
+			microseconds, ok = addIntervalComponent(microseconds, n, microsecondsPerHour, math.MaxInt64)
+		case inTime && designator == 'M':
+			microseconds, ok = addIntervalComponent(microseconds, n, microsecondsPerMinute, math.MaxInt64)
+		default:
+			return Interval{}, fmt.Errorf("cannot parse %q as interval: misplaced %c", s, designator)
+		}
+		if !ok {
+			return Interval{}, fmt.Errorf("cannot parse %q as interval: out of range", s)
+		}
+	}
+
+	return Interval{Microseconds: microseconds, Days: int32(days), Months: int32(months), Valid: true}, nil
+}
+
+// parseISO8601IntervalSeconds parses the number of seconds of an ISO 8601 duration such as -6.5 into microseconds.
+func parseISO8601IntervalSeconds(num string) (int64, error) {
+	negative := strings.HasPrefix(num, "-")
+	digits := strings.TrimLeft(num, "+-")
+	if len(num)-len(digits) > 1 {
+		return 0, fmt.Errorf("bad seconds %q", num)
+	}
+
+	sec, frac, _ := strings.Cut(digits, ".")
+	if len(frac) > 6 {
+		return 0, fmt.Errorf("seconds %q are more precise than microseconds", num)
+	}
+
+	seconds, err := strconv.ParseUint(sec, 10, 63)
+	if err != nil {
+		return 0, fmt.Errorf("bad seconds %q", num)
+	}
+
+	var fracMicroseconds uint64
+	if frac != "" {
+		fracMicroseconds, err = strconv.ParseUint(frac+strings.Repeat("0", 6-len(frac)), 10, 63)
+		if err != nil {
+			return 0, fmt.Errorf("bad seconds %q", num)
+		}
+	}
+
+	us, ok := addIntervalComponent(int64(fracMicroseconds), int64(seconds), microsecondsPerSecond, math.MaxInt64)
+	if !ok {
+		return 0, fmt.Errorf("seconds %q out of range", num)
+	}
+
+	if negative {
+		us = -us
+	}
+
+	return us, nil
+}
+
+// addIntervalComponent returns acc + n*unit. ok is false if the result would be outside the range -max-1 to max of a
+// two's complement integer.
+func addIntervalComponent(acc, n, unit, max int64) (sum int64, ok bool) {
+	min := -max - 1
+	if n > max/unit || n < min/unit {
+		return 0, false
+	}
+
+	p := n * unit
+	if (p > 0 && acc > max-p) || (p < 0 && acc < min-p) {
+		return 0, false
+	}
+
+	return acc + p, true
+}
+
 type IntervalCodec struct{}
 
 func (IntervalCodec) FormatSupported(format int16) bool {
@@ -198,6 +477,15 @@ func (scanPlanTextAnyToIntervalScanner) Scan(src []byte, dst any) error {
 		return scanner.ScanInterval(Interval{})
 	}
 
+	// The iso_8601 IntervalStyle formats intervals as ISO 8601 durations.
+	if len(src) > 0 && src[0] == 'P' {
+		interval, err := parseISO8601Interval(string(src))
+		if err != nil {
+			return err
+		}
+		return scanner.ScanInterval(interval)
+	}
+
 	var microseconds int64
 	var days int32
 	var months int32
@@ -2,11 +2,13 @@ package pgtype_test
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIntervalCodec(t *testing.T) {
@@ -136,3 +138,103 @@ func TestIntervalCodec(t *testing.T) {
 		{nil, new(pgtype.Interval), isExpectedEq(pgtype.Interval{})},
 	})
 }
+
+func TestIntervalDuration(t *testing.T) {
+	d, err := pgtype.Interval{Days: 1, Microseconds: 1500000, Valid: true}.Duration()
+	require.NoError(t, err)
+	require.Equal(t, 24*time.Hour+1500*time.Millisecond, d)
+
+	d, err = pgtype.Interval{Days: -1, Microseconds: 3600000000, Valid: true}.Duration()
+	require.NoError(t, err)
+	require.Equal(t, -23*time.Hour, d)
+
+	_, err = pgtype.Interval{Months: 1, Valid: true}.Duration()
+	require.ErrorContains(t, err, "months have a variable length")
+
+	_, err = pgtype.Interval{}.Duration()
+	require.Error(t, err)
+
+	_, err = pgtype.Interval{Days: 200000, Valid: true}.Duration()
+	require.ErrorContains(t, err, "overflows time.Duration")
+
+	_, err = pgtype.Interval{Days: 100000, Microseconds: 100000 * 86400000000, Valid: true}.Duration()
+	require.ErrorContains(t, err, "overflows time.Duration")
+
+	_, err = pgtype.Interval{Microseconds: math.MaxInt64, Valid: true}.Duration()
+	require.ErrorContains(t, err, "overflows time.Duration")
+}
+
+func TestIntervalScanDurationOverflow(t *testing.T) {
+	m := pgtype.NewMap()
+
+	buf, err := m.Encode(pgtype.IntervalOID, pgtype.BinaryFormatCode, pgtype.Interval{Months: 12000, Valid: true}, nil)
+	require.NoError(t, err)
+
+	var d time.Duration
+	err = m.Scan(pgtype.IntervalOID, pgtype.BinaryFormatCode, buf, &d)
+	require.ErrorContains(t, err, "overflows time.Duration")
+}
+
+func TestIntervalISO8601(t *testing.T) {
+	for i, tt := range []struct {
+		interval pgtype.Interval
+		expected string
+	}{
+		{pgtype.Interval{}, ""},
+		{pgtype.Interval{Valid: true}, "PT0S"},
+		{pgtype.Interval{Months: 14, Days: 3, Microseconds: 4 * 3600000000, Valid: true}, "P1Y2M3DT4H"},
+		{pgtype.Interval{Microseconds: 5*60000000 + 6500000, Valid: true}, "PT5M6.5S"},
+		{pgtype.Interval{Microseconds: 1, Valid: true}, "PT0.000001S"},
+		{pgtype.Interval{Months: -13, Days: 2, Microseconds: -(3600000000 + 30000000), Valid: true}, "P-1Y-1M2DT-1H-30S"},
+		{pgtype.Interval{Microseconds: -500000, Valid: true}, "PT-0.5S"},
+		{pgtype.Interval{Microseconds: math.MinInt64, Valid: true}, "PT-2562047788H-54.775808S"},
+		{pgtype.Interval{Months: math.MinInt32, Days: math.MinInt32, Valid: true}, "P-178956970Y-8M-2147483648D"},
+		{pgtype.Interval{Microseconds: math.MaxInt64, Days: math.MaxInt32, Months: math.MaxInt32, Valid: true}, "infinity"},
+		{pgtype.Interval{Microseconds: math.MinInt64, Days: math.MinInt32, Months: math.MinInt32, Valid: true}, "-infinity"},
+	} {
+		require.Equalf(t, tt.expected, tt.interval.ISO8601(), "%d", i)
+
+		if tt.interval.Valid {
+			interval, err := pgtype.ParseInterval(tt.expected)
+			require.NoErrorf(t, err, "%d", i)
+			require.Equalf(t, tt.interval, interval, "%d", i)
+		}
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	for i, tt := range []struct {
+		s        string
+		expected pgtype.Interval
+	}{
+		{"1h30m", pgtype.Interval{Microseconds: 90 * 60000000, Valid: true}},
+		{"-2.5s", pgtype.Interval{Microseconds: -2500000, Valid: true}},
+		{"1500ns", pgtype.Interval{Microseconds: 1, Valid: true}},
+		{"P1DT2H", pgtype.Interval{Days: 1, Microseconds: 2 * 3600000000, Valid: true}},
+		{"P2W", pgtype.Interval{Days: 14, Valid: true}},
+		{"PT0S", pgtype.Interval{Valid: true}},
+		{"P1Y2M3DT4H", pgtype.Interval{Months: 14, Days: 3, Microseconds: 4 * 3600000000, Valid: true}},
+		{"PT5M6.5S", pgtype.Interval{Microseconds: 5*60000000 + 6500000, Valid: true}},
+		{"PT0.000001S", pgtype.Interval{Microseconds: 1, Valid: true}},
+		{"P-1Y-1M2DT-1H-30S", pgtype.Interval{Months: -13, Days: 2, Microseconds: -(3600000000 + 30000000), Valid: true}},
+		{"PT-0.5S", pgtype.Interval{Microseconds: -500000, Valid: true}},
+	} {
+		interval, err := pgtype.ParseInterval(tt.s)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.expected, interval, "%d", i)
+	}
+
+	for i, s := range []string{"", "1 day", "P", "PT", "P1DT", "P1H", "PT1D", "PT1.5H", "PT1.0000001S", "P3000000000D", "P1X"} {
+		_, err := pgtype.ParseInterval(s)
+		require.Errorf(t, err, "%d", i)
+	}
+}
+
+func TestIntervalScanISO8601Text(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var interval pgtype.Interval
+	err := m.Scan(pgtype.IntervalOID, pgtype.TextFormatCode, []byte("P1Y2M3DT4H5M6.5S"), &interval)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Interval{Months: 14, Days: 3, Microseconds: 4*3600000000 + 5*60000000 + 6500000, Valid: true}, interval)
+}
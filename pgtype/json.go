@@ -8,7 +8,44 @@ import (
 	"reflect"
 )
 
-type JSONCodec struct{}
+// JSONFuncs replaces the encoding/json functions used by JSONCodec and JSONBCodec. A nil field uses the
+// encoding/json function.
+type JSONFuncs struct {
+	// Marshal is used in place of json.Marshal if not nil.
+	Marshal func(v any) ([]byte, error)
+
+	// Unmarshal is used in place of json.Unmarshal if not nil.
+	Unmarshal func(data []byte, v any) error
+}
+
+func (f *JSONFuncs) marshal(v any) ([]byte, error) {
+	if f != nil && f.Marshal != nil {
+		return f.Marshal(v)
+	}
+	return json.Marshal(v)
+}
+
+func (f *JSONFuncs) unmarshal(data []byte, v any) error {
+	if f != nil && f.Unmarshal != nil {
+		return f.Unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// JSONCodec is a codec for the PostgreSQL json type. Values that are not strings or []byte are marshaled and
+// unmarshaled with encoding/json unless Funcs is set.
+type JSONCodec struct {
+	// Funcs replaces encoding/json if not nil. It is a pointer so JSONCodec remains comparable.
+	Funcs *JSONFuncs
+}
+
+func (c JSONCodec) marshal(v any) ([]byte, error) {
+	return c.Funcs.marshal(v)
+}
+
+func (c JSONCodec) unmarshal(data []byte, v any) error {
+	return c.Funcs.unmarshal(data, v)
+}
 
 func (JSONCodec) FormatSupported(format int16) bool {
 	return format == TextFormatCode || format == BinaryFormatCode
@@ -30,7 +67,7 @@ func (c JSONCodec) PlanEncode(m *Map, oid uint32, format int16, value any) Encod
 	//
 	// https://github.com/jackc/pgx/issues/1681
 	case json.Marshaler:
-		return encodePlanJSONCodecEitherFormatMarshal{}
+		return encodePlanJSONCodecEitherFormatMarshal{marshal: c.marshal}
 
 	// Cannot rely on driver.Valuer being handled later because anything can be marshalled.
 	//
@@ -53,7 +90,7 @@ func (c JSONCodec) PlanEncode(m *Map, oid uint32, format int16, value any) Encod
 		}
 	}
 
-	return encodePlanJSONCodecEitherFormatMarshal{}
+	return encodePlanJSONCodecEitherFormatMarshal{marshal: c.marshal}
 }
 
 type encodePlanJSONCodecEitherFormatString struct{}
@@ -76,10 +113,12 @@ func (encodePlanJSONCodecEitherFormatByteSlice) Encode(value any, buf []byte) (n
 	return buf, nil
 }
 
-type encodePlanJSONCodecEitherFormatMarshal struct{}
+type encodePlanJSONCodecEitherFormatMarshal struct {
+	marshal func(v any) ([]byte, error)
+}
 
-func (encodePlanJSONCodecEitherFormatMarshal) Encode(value any, buf []byte) (newBuf []byte, err error) {
-	jsonBytes, err := json.Marshal(value)
+func (plan encodePlanJSONCodecEitherFormatMarshal) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	jsonBytes, err := plan.marshal(value)
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +127,7 @@ func (encodePlanJSONCodecEitherFormatMarshal) Encode(value any, buf []byte) (new
 	return buf, nil
 }
 
-func (JSONCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+func (c JSONCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
 	switch target.(type) {
 	case *string:
 		return scanPlanAnyToString{}
@@ -121,7 +160,7 @@ func (JSONCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan
 		return &scanPlanSQLScanner{formatCode: format}
 	}
 
-	return scanPlanJSONToJSONUnmarshal{}
+	return scanPlanJSONToJSONUnmarshal{unmarshal: c.unmarshal}
 }
 
 type scanPlanAnyToString struct{}
@@ -153,9 +192,11 @@ func (scanPlanJSONToBytesScanner) Scan(src []byte, dst any) error {
 	return scanner.ScanBytes(src)
 }
 
-type scanPlanJSONToJSONUnmarshal struct{}
+type scanPlanJSONToJSONUnmarshal struct {
+	unmarshal func(data []byte, v any) error
+}
 
-func (scanPlanJSONToJSONUnmarshal) Scan(src []byte, dst any) error {
+func (plan scanPlanJSONToJSONUnmarshal) Scan(src []byte, dst any) error {
 	if src == nil {
 		dstValue := reflect.ValueOf(dst)
 		if dstValue.Kind() == reflect.Ptr {
@@ -173,7 +214,7 @@ func (scanPlanJSONToJSONUnmarshal) Scan(src []byte, dst any) error {
 	elem := reflect.ValueOf(dst).Elem()
 	elem.Set(reflect.Zero(elem.Type()))
 
-	return json.Unmarshal(src, dst)
+	return plan.unmarshal(src, dst)
 }
 
 func (c JSONCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
@@ -192,6 +233,6 @@ func (c JSONCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (an
 	}
 
 	var dst any
-	err := json.Unmarshal(src, &dst)
+	err := c.unmarshal(src, &dst)
 	return dst, err
 }
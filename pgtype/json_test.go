@@ -1,11 +1,14 @@
 package pgtype_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"testing"
 
 	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/require"
 )
@@ -185,3 +188,58 @@ func TestJSONCodecEncodeJSONMarshalerThatCanBeWrapped(t *testing.T) {
 		require.Equal(t, `{"custom":"thing"}`, jsonStr)
 	})
 }
+
+func TestJSONCodecCustomMarshalUnmarshal(t *testing.T) {
+	strictUnmarshal := func(data []byte, v any) error {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		return decoder.Decode(v)
+	}
+
+	var marshalCalls int
+	countingMarshal := func(v any) ([]byte, error) {
+		marshalCalls++
+		return json.Marshal(v)
+	}
+
+	type widget struct {
+		Name string `json:"name"`
+	}
+
+	funcs := &pgtype.JSONFuncs{Marshal: countingMarshal, Unmarshal: strictUnmarshal}
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "json", OID: pgtype.JSONOID, Codec: pgtype.JSONCodec{Funcs: funcs}})
+	m.RegisterType(&pgtype.Type{Name: "jsonb", OID: pgtype.JSONBOID, Codec: pgtype.JSONBCodec{Funcs: funcs}})
+
+	// Codecs are compared as interface values, which panics if the dynamic type is not comparable.
+	var codec pgtype.Codec = pgtype.JSONBCodec{Funcs: funcs}
+	require.True(t, codec == pgtype.Codec(pgtype.JSONBCodec{Funcs: funcs}))
+	require.False(t, codec == pgtype.Codec(pgtype.JSONBCodec{}))
+
+	for _, oid := range []uint32{pgtype.JSONOID, pgtype.JSONBOID} {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			marshalCalls = 0
+			buf, err := m.Encode(oid, format, widget{Name: "foo"}, nil)
+			require.NoError(t, err)
+			require.Equal(t, 1, marshalCalls)
+
+			var w widget
+			err = m.Scan(oid, format, buf, &w)
+			require.NoError(t, err)
+			require.Equal(t, widget{Name: "foo"}, w)
+
+			src := []byte(`{"name": "foo", "extra": 1}`)
+			if oid == pgtype.JSONBOID && format == pgtype.BinaryFormatCode {
+				src = append([]byte{1}, src...)
+			}
+			err = m.Scan(oid, format, src, &w)
+			require.ErrorContains(t, err, "unknown field")
+		}
+	}
+
+	// The zero value codecs use encoding/json.
+	var w widget
+	err := pgtype.NewMap().Scan(pgtype.JSONBOID, pgtype.TextFormatCode, []byte(`{"name": "foo", "extra": 1}`), &w)
+	require.NoError(t, err)
+	require.Equal(t, widget{Name: "foo"}, w)
+}
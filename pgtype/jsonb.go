@@ -2,11 +2,19 @@ package pgtype
 
 import (
 	"database/sql/driver"
-	"encoding/json"
 	"fmt"
 )
 
-type JSONBCodec struct{}
+// JSONBCodec is a codec for the PostgreSQL jsonb type. Values that are not strings or []byte are marshaled and
+// unmarshaled with encoding/json unless Funcs is set.
+type JSONBCodec struct {
+	// Funcs replaces encoding/json if not nil. It is a pointer so JSONBCodec remains comparable.
+	Funcs *JSONFuncs
+}
+
+func (c JSONBCodec) jsonCodec() JSONCodec {
+	return JSONCodec{Funcs: c.Funcs}
+}
 
 func (JSONBCodec) FormatSupported(format int16) bool {
 	return format == TextFormatCode || format == BinaryFormatCode
@@ -16,15 +24,15 @@ func (JSONBCodec) PreferredFormat() int16 {
 	return TextFormatCode
 }
 
-func (JSONBCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+func (c JSONBCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
 	switch format {
 	case BinaryFormatCode:
-		plan := JSONCodec{}.PlanEncode(m, oid, TextFormatCode, value)
+		plan := c.jsonCodec().PlanEncode(m, oid, TextFormatCode, value)
 		if plan != nil {
 			return &encodePlanJSONBCodecBinaryWrapper{textPlan: plan}
 		}
 	case TextFormatCode:
-		return JSONCodec{}.PlanEncode(m, oid, format, value)
+		return c.jsonCodec().PlanEncode(m, oid, format, value)
 	}
 
 	return nil
@@ -35,19 +43,19 @@ type encodePlanJSONBCodecBinaryWrapper struct {
 }
 
 func (plan *encodePlanJSONBCodecBinaryWrapper) Encode(value any, buf []byte) (newBuf []byte, err error) {
-	buf = append(buf, 1)
+	buf = append(buf, jsonbVersion)
 	return plan.textPlan.Encode(value, buf)
 }
 
-func (JSONBCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+func (c JSONBCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
 	switch format {
 	case BinaryFormatCode:
-		plan := JSONCodec{}.PlanScan(m, oid, TextFormatCode, target)
+		plan := c.jsonCodec().PlanScan(m, oid, TextFormatCode, target)
 		if plan != nil {
 			return &scanPlanJSONBCodecBinaryUnwrapper{textPlan: plan}
 		}
 	case TextFormatCode:
-		return JSONCodec{}.PlanScan(m, oid, format, target)
+		return c.jsonCodec().PlanScan(m, oid, format, target)
 	}
 
 	return nil
@@ -62,15 +70,30 @@ func (plan *scanPlanJSONBCodecBinaryUnwrapper) Scan(src []byte, dst any) error {
 		return plan.textPlan.Scan(src, dst)
 	}
 
+	jsonSrc, err := jsonbBinaryToText(src)
+	if err != nil {
+		return err
+	}
+
+	return plan.textPlan.Scan(jsonSrc, dst)
+}
+
+// jsonbVersion is the only jsonb binary format version. It is the first byte of the binary format and it is followed by
+// the JSON text.
+const jsonbVersion = 1
+
+// jsonbBinaryToText returns the JSON text of the jsonb binary format value src. An unknown version is an error rather
+// than being treated as part of the JSON text.
+func jsonbBinaryToText(src []byte) ([]byte, error) {
 	if len(src) == 0 {
-		return fmt.Errorf("jsonb too short")
+		return nil, fmt.Errorf("jsonb too short")
 	}
 
-	if src[0] != 1 {
-		return fmt.Errorf("unknown jsonb version number %d", src[0])
+	if src[0] != jsonbVersion {
+		return nil, fmt.Errorf("unknown jsonb version number %d", src[0])
 	}
 
-	return plan.textPlan.Scan(src[1:], dst)
+	return src[1:], nil
 }
 
 func (c JSONBCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
@@ -80,16 +103,13 @@ func (c JSONBCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src
 
 	switch format {
 	case BinaryFormatCode:
-		if len(src) == 0 {
-			return nil, fmt.Errorf("jsonb too short")
-		}
-
-		if src[0] != 1 {
-			return nil, fmt.Errorf("unknown jsonb version number %d", src[0])
+		jsonSrc, err := jsonbBinaryToText(src)
+		if err != nil {
+			return nil, err
 		}
 
-		dstBuf := make([]byte, len(src)-1)
-		copy(dstBuf, src[1:])
+		dstBuf := make([]byte, len(jsonSrc))
+		copy(dstBuf, jsonSrc)
 		return dstBuf, nil
 	case TextFormatCode:
 		dstBuf := make([]byte, len(src))
@@ -107,21 +127,17 @@ func (c JSONBCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (a
 
 	switch format {
 	case BinaryFormatCode:
-		if len(src) == 0 {
-			return nil, fmt.Errorf("jsonb too short")
+		var err error
+		src, err = jsonbBinaryToText(src)
+		if err != nil {
+			return nil, err
 		}
-
-		if src[0] != 1 {
-			return nil, fmt.Errorf("unknown jsonb version number %d", src[0])
-		}
-
-		src = src[1:]
 	case TextFormatCode:
 	default:
 		return nil, fmt.Errorf("unknown format code: %v", format)
 	}
 
 	var dst any
-	err := json.Unmarshal(src, &dst)
+	err := c.jsonCodec().unmarshal(src, &dst)
 	return dst, err
 }
@@ -2,9 +2,13 @@ package pgtype_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/require"
 )
@@ -80,3 +84,60 @@ func TestJSONBCodecEncodeJSONMarshalerThatCanBeWrapped(t *testing.T) {
 		require.Equal(t, `{"custom": "thing"}`, jsonStr) // Note that unlike json, jsonb reformats the JSON string.
 	})
 }
+
+func makeLargeJSONBDocument() map[string]any {
+	doc := make(map[string]any, 20000)
+	for i := 0; i < 20000; i++ {
+		doc[fmt.Sprintf("key%d", i)] = strings.Repeat("x", 100)
+	}
+	return doc
+}
+
+func TestJSONBCodecBinaryVersion(t *testing.T) {
+	m := pgtype.NewMap()
+
+	doc := makeLargeJSONBDocument()
+
+	buf, err := m.Encode(pgtype.JSONBOID, pgtype.BinaryFormatCode, doc, nil)
+	require.NoError(t, err)
+	require.Greater(t, len(buf), 2*1024*1024)
+	require.EqualValues(t, 1, buf[0])
+	require.True(t, json.Valid(buf[1:]))
+
+	// Encoding appends to a buffer that already has data.
+	prefixedBuf, err := m.Encode(pgtype.JSONBOID, pgtype.BinaryFormatCode, doc, []byte("prefix"))
+	require.NoError(t, err)
+	require.Equal(t, append([]byte("prefix"), buf...), prefixedBuf)
+
+	var result map[string]any
+	err = m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, buf, &result)
+	require.NoError(t, err)
+	require.Equal(t, doc, result)
+
+	var raw []byte
+	err = m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, buf, &raw)
+	require.NoError(t, err)
+	require.Equal(t, buf[1:], raw)
+
+	unknownVersion := append([]byte{2}, buf[1:]...)
+	err = m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, unknownVersion, &result)
+	require.EqualError(t, err, "unknown jsonb version number 2")
+
+	_, err = pgtype.JSONBCodec{}.DecodeValue(m, pgtype.JSONBOID, pgtype.BinaryFormatCode, unknownVersion)
+	require.EqualError(t, err, "unknown jsonb version number 2")
+
+	_, err = pgtype.JSONBCodec{}.DecodeDatabaseSQLValue(m, pgtype.JSONBOID, pgtype.BinaryFormatCode, unknownVersion)
+	require.EqualError(t, err, "unknown jsonb version number 2")
+
+	// Text that happens to start with a printable character must not be treated as JSON.
+	err = m.Scan(pgtype.JSONBOID, pgtype.BinaryFormatCode, []byte(`{"a":1}`), &result)
+	require.ErrorContains(t, err, "unknown jsonb version number")
+}
+
+func TestJSONBCodecBinaryLargeDocument(t *testing.T) {
+	doc := makeLargeJSONBDocument()
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, "jsonb", []pgxtest.ValueRoundTripTest{
+		{doc, new(map[string]any), isExpectedEqMap(doc)},
+	})
+}
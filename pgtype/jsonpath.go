@@ -0,0 +1,268 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// jsonpathBinaryVersion is the version byte that prefixes the binary format of jsonpath.
+const jsonpathBinaryVersion = 1
+
+type JSONPathScanner interface {
+	ScanJSONPath(v JSONPath) error
+}
+
+type JSONPathValuer interface {
+	JSONPathValue() (JSONPath, error)
+}
+
+// JSONPath represents a PostgreSQL SQL/JSON path expression such as "$.items[*] ? (@.price > 10)".
+//
+// A jsonpath can also be scanned into or encoded from a string. Paths are checked on encode for errors that can be
+// detected without the server's parser: an empty path, a NUL byte, an unterminated string literal, and unbalanced
+// parentheses, brackets, or braces. All other syntax errors are reported by the server.
+type JSONPath struct {
+	Path  string
+	Valid bool
+}
+
+func (p *JSONPath) ScanJSONPath(v JSONPath) error {
+	*p = v
+	return nil
+}
+
+func (p JSONPath) JSONPathValue() (JSONPath, error) {
+	return p, nil
+}
+
+// Scan implements the database/sql Scanner interface.
+func (p *JSONPath) Scan(src any) error {
+	if src == nil {
+		*p = JSONPath{}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case string:
+		*p = JSONPath{Path: src, Valid: true}
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (p JSONPath) Value() (driver.Value, error) {
+	if !p.Valid {
+		return nil, nil
+	}
+
+	if err := validateJSONPath(p.Path); err != nil {
+		return nil, err
+	}
+
+	return p.Path, nil
+}
+
+// validateJSONPath returns an error if s is obviously not a jsonpath. It does not parse the jsonpath grammar.
+func validateJSONPath(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return fmt.Errorf("invalid jsonpath: empty path")
+	}
+
+	var closers []byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case 0:
+			return fmt.Errorf("invalid jsonpath: contains NUL byte")
+		case '"':
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(s) {
+				return fmt.Errorf("invalid jsonpath: unterminated string literal")
+			}
+		case '(':
+			closers = append(closers, ')')
+		case '[':
+			closers = append(closers, ']')
+		case '{':
+			closers = append(closers, '}')
+		case ')', ']', '}':
+			if len(closers) == 0 || closers[len(closers)-1] != c {
+				return fmt.Errorf("invalid jsonpath: unexpected %q at position %d", c, i)
+			}
+			closers = closers[:len(closers)-1]
+		}
+	}
+
+	if len(closers) > 0 {
+		return fmt.Errorf("invalid jsonpath: missing %q", closers[len(closers)-1])
+	}
+
+	return nil
+}
+
+// JSONPathCodec is a codec for the PostgreSQL jsonpath type. Values are decoded as strings.
+type JSONPathCodec struct{}
+
+func (JSONPathCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (JSONPathCodec) PreferredFormat() int16 {
+	return TextFormatCode
+}
+
+func (JSONPathCodec) validatesText() bool {
+	return true
+}
+
+func (JSONPathCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	var plan EncodePlan
+
+	switch value.(type) {
+	case JSONPathValuer:
+		plan = encodePlanJSONPathCodecJSONPathValuer{}
+	case TextValuer:
+		plan = encodePlanJSONPathCodecTextValuer{}
+	default:
+		return nil
+	}
+
+	if format == BinaryFormatCode {
+		return &encodePlanJSONPathCodecBinary{next: plan}
+	}
+
+	return plan
+}
+
+type encodePlanJSONPathCodecJSONPathValuer struct{}
+
+func (encodePlanJSONPathCodecJSONPathValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	path, err := value.(JSONPathValuer).JSONPathValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !path.Valid {
+		return nil, nil
+	}
+
+	return appendJSONPath(buf, path.Path)
+}
+
+type encodePlanJSONPathCodecTextValuer struct{}
+
+func (encodePlanJSONPathCodecTextValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	text, err := value.(TextValuer).TextValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !text.Valid {
+		return nil, nil
+	}
+
+	return appendJSONPath(buf, text.String)
+}
+
+// encodePlanJSONPathCodecBinary prefixes the text format written by next with the binary format version byte.
+type encodePlanJSONPathCodecBinary struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanJSONPathCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(value, append(buf, jsonpathBinaryVersion))
+}
+
+func appendJSONPath(buf []byte, path string) ([]byte, error) {
+	if err := validateJSONPath(path); err != nil {
+		return nil, err
+	}
+
+	return append(buf, path...), nil
+}
+
+func (JSONPathCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	switch format {
+	case BinaryFormatCode, TextFormatCode:
+		switch target.(type) {
+		case JSONPathScanner:
+			return scanPlanJSONPathToJSONPathScanner{format: format}
+		case TextScanner:
+			return scanPlanJSONPathToTextScanner{format: format}
+		}
+	}
+
+	return nil
+}
+
+func (c JSONPathCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return c.DecodeValue(m, oid, format, src)
+}
+
+func (c JSONPathCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	return jsonpathTextFromSrc(format, src)
+}
+
+// jsonpathTextFromSrc returns the text format of src. The binary format is the text format prefixed with a version
+// byte.
+func jsonpathTextFromSrc(format int16, src []byte) (string, error) {
+	if format == BinaryFormatCode {
+		if len(src) == 0 {
+			return "", fmt.Errorf("invalid length for jsonpath: %v", len(src))
+		}
+		if src[0] != jsonpathBinaryVersion {
+			return "", fmt.Errorf("unsupported jsonpath binary version: %d", src[0])
+		}
+		src = src[1:]
+	}
+
+	return string(src), nil
+}
+
+type scanPlanJSONPathToJSONPathScanner struct {
+	format int16
+}
+
+func (plan scanPlanJSONPathToJSONPathScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(JSONPathScanner)
+
+	if src == nil {
+		return scanner.ScanJSONPath(JSONPath{})
+	}
+
+	s, err := jsonpathTextFromSrc(plan.format, src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanJSONPath(JSONPath{Path: s, Valid: true})
+}
+
+type scanPlanJSONPathToTextScanner struct {
+	format int16
+}
+
+func (plan scanPlanJSONPathToTextScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TextScanner)
+
+	if src == nil {
+		return scanner.ScanText(Text{})
+	}
+
+	s, err := jsonpathTextFromSrc(plan.format, src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanText(Text{String: s, Valid: true})
+}
@@ -0,0 +1,66 @@
+package pgtype_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPathCodec(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "jsonpath", []pgxtest.ValueRoundTripTest{
+		{
+			pgtype.JSONPath{Path: `$."items"[*]?(@."price" > 10)`, Valid: true},
+			new(pgtype.JSONPath),
+			isExpectedEq(pgtype.JSONPath{Path: `$."items"[*]?(@."price" > 10)`, Valid: true}),
+		},
+		{`$."a"`, new(string), isExpectedEq(`$."a"`)},
+		{`strict $."a"[0 to 2]`, new(pgtype.Text), isExpectedEq(pgtype.Text{String: `strict $."a"[0 to 2]`, Valid: true})},
+		{pgtype.JSONPath{}, new(pgtype.JSONPath), isExpectedEq(pgtype.JSONPath{})},
+		{nil, new(*string), isExpectedEq((*string)(nil))},
+	})
+}
+
+func TestJSONPathCodecWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	buf, err := m.Encode(pgtype.JSONPathOID, pgtype.BinaryFormatCode, `$.a`, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x01$.a"), buf)
+
+	buf, err = m.Encode(pgtype.JSONPathOID, pgtype.TextFormatCode, pgtype.JSONPath{Path: `$.a ? (@ like_regex "[(]")`, Valid: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`$.a ? (@ like_regex "[(]")`), buf)
+
+	buf, err = m.Encode(pgtype.JSONPathOID, pgtype.TextFormatCode, pgtype.JSONPath{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, buf)
+
+	for _, value := range []any{"", " ", `$.a[0`, `$.a)`, `$.a ? (@ == "b)`, `$.**{1 to 2]`, "$.a\x00"} {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			_, err = m.Encode(pgtype.JSONPathOID, format, value, nil)
+			require.Errorf(t, err, "%q", value)
+		}
+	}
+
+	var s string
+	err = m.Scan(pgtype.JSONPathOID, pgtype.BinaryFormatCode, []byte("\x01$.a"), &s)
+	require.NoError(t, err)
+	require.Equal(t, "$.a", s)
+
+	var path pgtype.JSONPath
+	err = m.Scan(pgtype.JSONPathOID, pgtype.TextFormatCode, []byte("$.a"), &path)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.JSONPath{Path: "$.a", Valid: true}, path)
+
+	err = m.Scan(pgtype.JSONPathOID, pgtype.BinaryFormatCode, []byte("\x02$.a"), &path)
+	require.ErrorContains(t, err, "unsupported jsonpath binary version")
+
+	dt, ok := m.TypeForOID(pgtype.JSONPathOID)
+	require.True(t, ok)
+	v, err := dt.Codec.DecodeValue(m, pgtype.JSONPathOID, pgtype.BinaryFormatCode, []byte("\x01$.a"))
+	require.NoError(t, err)
+	require.Equal(t, "$.a", v)
+}
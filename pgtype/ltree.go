@@ -0,0 +1,355 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ltreeBinaryVersion is the version byte that prefixes the binary format of ltree.
+const ltreeBinaryVersion = 1
+
+// ltreeMaxLabelLength is the maximum number of characters in an ltree label.
+const ltreeMaxLabelLength = 1000
+
+type LtreeScanner interface {
+	ScanLtree(v Ltree) error
+}
+
+type LtreeValuer interface {
+	LtreeValue() (Ltree, error)
+}
+
+// Ltree represents a label path of the ltree extension such as "Top.Science.Astronomy". A valid Ltree with no Labels
+// is the empty path.
+//
+// An ltree can also be scanned into or encoded from a []string of labels or a dotted string. Labels are validated on
+// encode. A label must be non-empty and may only contain letters, digits, underscores, and hyphens.
+//
+// The OID of ltree depends on the database, so LtreeCodec must be registered after the extension is installed. e.g.
+//
+//	var oid uint32
+//	err := conn.QueryRow(ctx, "select 'ltree'::regtype::oid").Scan(&oid)
+//	conn.TypeMap().RegisterType(&pgtype.Type{Name: "ltree", OID: oid, Codec: pgtype.LtreeCodec{}})
+type Ltree struct {
+	Labels []string
+	Valid  bool
+}
+
+func (l *Ltree) ScanLtree(v Ltree) error {
+	*l = v
+	return nil
+}
+
+func (l Ltree) LtreeValue() (Ltree, error) {
+	return l, nil
+}
+
+// String returns the labels joined by dots.
+func (l Ltree) String() string {
+	return strings.Join(l.Labels, ".")
+}
+
+// Scan implements the database/sql Scanner interface.
+func (l *Ltree) Scan(src any) error {
+	if src == nil {
+		*l = Ltree{}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case string:
+		labels, err := parseLtree(src)
+		if err != nil {
+			return err
+		}
+		*l = Ltree{Labels: labels, Valid: true}
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (l Ltree) Value() (driver.Value, error) {
+	if !l.Valid {
+		return nil, nil
+	}
+
+	if err := validateLtreeLabels(l.Labels); err != nil {
+		return nil, err
+	}
+
+	return l.String(), nil
+}
+
+func parseLtree(s string) ([]string, error) {
+	if s == "" {
+		return []string{}, nil
+	}
+
+	labels := strings.Split(s, ".")
+	if err := validateLtreeLabels(labels); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+func validateLtreeLabels(labels []string) error {
+	for _, label := range labels {
+		if label == "" {
+			return fmt.Errorf("invalid ltree: empty label")
+		}
+
+		if utf8.RuneCountInString(label) > ltreeMaxLabelLength {
+			return fmt.Errorf("invalid ltree: label exceeds %d characters", ltreeMaxLabelLength)
+		}
+
+		for _, r := range label {
+			if !(unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-') {
+				return fmt.Errorf("invalid ltree: label %q contains invalid character %q", label, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LtreeCodec is a codec for the ltree extension type. The text format is preferred because the binary format requires
+// ltree 1.2 (PostgreSQL 13) or later.
+type LtreeCodec struct{}
+
+func (LtreeCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (LtreeCodec) PreferredFormat() int16 {
+	return TextFormatCode
+}
+
+func (LtreeCodec) validatesText() bool {
+	return true
+}
+
+func (LtreeCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	var plan EncodePlan
+
+	switch value.(type) {
+	case []string:
+		plan = encodePlanLtreeCodecStringSlice{}
+	case LtreeValuer:
+		plan = encodePlanLtreeCodecLtreeValuer{}
+	case TextValuer:
+		plan = encodePlanLtreeCodecTextValuer{}
+	default:
+		return nil
+	}
+
+	if format == BinaryFormatCode {
+		return &encodePlanLtreeCodecBinary{next: plan}
+	}
+
+	return plan
+}
+
+type encodePlanLtreeCodecStringSlice struct{}
+
+func (encodePlanLtreeCodecStringSlice) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	labels := value.([]string)
+	if labels == nil {
+		return nil, nil
+	}
+
+	return appendLtree(buf, labels)
+}
+
+type encodePlanLtreeCodecLtreeValuer struct{}
+
+func (encodePlanLtreeCodecLtreeValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	ltree, err := value.(LtreeValuer).LtreeValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ltree.Valid {
+		return nil, nil
+	}
+
+	return appendLtree(buf, ltree.Labels)
+}
+
+type encodePlanLtreeCodecTextValuer struct{}
+
+func (encodePlanLtreeCodecTextValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	text, err := value.(TextValuer).TextValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !text.Valid {
+		return nil, nil
+	}
+
+	labels, err := parseLtree(text.String)
+	if err != nil {
+		return nil, err
+	}
+
+	return appendLtree(buf, labels)
+}
+
+// encodePlanLtreeCodecBinary prefixes the text format written by next with the binary format version byte.
+type encodePlanLtreeCodecBinary struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanLtreeCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(value, append(buf, ltreeBinaryVersion))
+}
+
+func appendLtree(buf []byte, labels []string) ([]byte, error) {
+	if err := validateLtreeLabels(labels); err != nil {
+		return nil, err
+	}
+
+	if buf == nil {
+		buf = []byte{}
+	}
+
+	for i, label := range labels {
+		if i > 0 {
+			buf = append(buf, '.')
+		}
+		buf = append(buf, label...)
+	}
+
+	return buf, nil
+}
+
+func (LtreeCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	switch format {
+	case BinaryFormatCode, TextFormatCode:
+		switch target.(type) {
+		case LtreeScanner:
+			return scanPlanLtreeToLtreeScanner{format: format}
+		case *[]string:
+			return scanPlanLtreeToStringSlice{format: format}
+		case TextScanner:
+			return scanPlanLtreeToTextScanner{format: format}
+		}
+	}
+
+	return nil
+}
+
+func (c LtreeCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	s, err := ltreeTextFromSrc(format, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (c LtreeCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var ltree Ltree
+	err := codecScan(c, m, oid, format, src, &ltree)
+	if err != nil {
+		return nil, err
+	}
+	return ltree, nil
+}
+
+// ltreeTextFromSrc returns the text format of src. The binary format is the text format prefixed with a version byte.
+func ltreeTextFromSrc(format int16, src []byte) (string, error) {
+	if format == BinaryFormatCode {
+		if len(src) == 0 {
+			return "", fmt.Errorf("invalid length for ltree: %v", len(src))
+		}
+		if src[0] != ltreeBinaryVersion {
+			return "", fmt.Errorf("unsupported ltree binary version: %d", src[0])
+		}
+		src = src[1:]
+	}
+
+	return string(src), nil
+}
+
+func parseLtreeSrc(format int16, src []byte) ([]string, error) {
+	s, err := ltreeTextFromSrc(format, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLtree(s)
+}
+
+type scanPlanLtreeToLtreeScanner struct {
+	format int16
+}
+
+func (plan scanPlanLtreeToLtreeScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(LtreeScanner)
+
+	if src == nil {
+		return scanner.ScanLtree(Ltree{})
+	}
+
+	labels, err := parseLtreeSrc(plan.format, src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanLtree(Ltree{Labels: labels, Valid: true})
+}
+
+type scanPlanLtreeToStringSlice struct {
+	format int16
+}
+
+func (plan scanPlanLtreeToStringSlice) Scan(src []byte, dst any) error {
+	p := (dst).(*[]string)
+
+	if src == nil {
+		*p = nil
+		return nil
+	}
+
+	labels, err := parseLtreeSrc(plan.format, src)
+	if err != nil {
+		return err
+	}
+
+	*p = labels
+	return nil
+}
+
+type scanPlanLtreeToTextScanner struct {
+	format int16
+}
+
+func (plan scanPlanLtreeToTextScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TextScanner)
+
+	if src == nil {
+		return scanner.ScanText(Text{})
+	}
+
+	s, err := ltreeTextFromSrc(plan.format, src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanText(Text{String: s, Valid: true})
+}
@@ -0,0 +1,95 @@
+package pgtype_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func isExpectedEqLtree(a any) func(any) bool {
+	return func(v any) bool {
+		return reflect.DeepEqual(a, v)
+	}
+}
+
+func isExpectedEqStringSlice(a any) func(any) bool {
+	return func(v any) bool {
+		return reflect.DeepEqual(a, v)
+	}
+}
+
+func TestLtreeCodec(t *testing.T) {
+	ctr := defaultConnTestRunner
+	ctr.AfterConnect = func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var ltreeOID uint32
+		err := conn.QueryRow(context.Background(), `select oid from pg_type where typname = 'ltree'`).Scan(&ltreeOID)
+		if err != nil {
+			t.Skipf("Skipping: cannot find ltree OID")
+		}
+
+		conn.TypeMap().RegisterType(&pgtype.Type{Name: "ltree", OID: ltreeOID, Codec: pgtype.LtreeCodec{}})
+	}
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, ctr, pgxtest.KnownOIDQueryExecModes, "ltree", []pgxtest.ValueRoundTripTest{
+		{
+			pgtype.Ltree{Labels: []string{"Top", "Science", "Astronomy"}, Valid: true},
+			new(pgtype.Ltree),
+			isExpectedEqLtree(pgtype.Ltree{Labels: []string{"Top", "Science", "Astronomy"}, Valid: true}),
+		},
+		{pgtype.Ltree{Labels: []string{}, Valid: true}, new(pgtype.Ltree), isExpectedEqLtree(pgtype.Ltree{Labels: []string{}, Valid: true})},
+		{[]string{"a", "b_c", "d-1"}, new([]string), isExpectedEqStringSlice([]string{"a", "b_c", "d-1"})},
+		{"Top.Collections.Pictures", new([]string), isExpectedEqStringSlice([]string{"Top", "Collections", "Pictures"})},
+		{[]string{"Top", "Hobbies"}, new(string), isExpectedEq("Top.Hobbies")},
+		{pgtype.Ltree{}, new(pgtype.Ltree), isExpectedEqLtree(pgtype.Ltree{})},
+		{nil, new(*string), isExpectedEq((*string)(nil))},
+	})
+}
+
+func TestLtreeCodecWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+	const ltreeOID = 999999
+	m.RegisterType(&pgtype.Type{Name: "ltree", OID: ltreeOID, Codec: pgtype.LtreeCodec{}})
+
+	buf, err := m.Encode(ltreeOID, pgtype.BinaryFormatCode, []string{"Top", "Science"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x01Top.Science"), buf)
+
+	buf, err = m.Encode(ltreeOID, pgtype.BinaryFormatCode, pgtype.Ltree{Labels: []string{}, Valid: true}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x01"), buf)
+
+	buf, err = m.Encode(ltreeOID, pgtype.TextFormatCode, "Top.Science", nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("Top.Science"), buf)
+
+	for _, value := range []any{"Top..Science", "Top.Sci ence", []string{"Top", ""}, pgtype.Ltree{Labels: []string{"a.b"}, Valid: true}} {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			_, err = m.Encode(ltreeOID, format, value, nil)
+			require.Errorf(t, err, "%v", value)
+		}
+	}
+
+	var labels []string
+	err = m.Scan(ltreeOID, pgtype.BinaryFormatCode, []byte("\x01Top.Science"), &labels)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Top", "Science"}, labels)
+
+	var s string
+	err = m.Scan(ltreeOID, pgtype.BinaryFormatCode, []byte("\x01Top.Science"), &s)
+	require.NoError(t, err)
+	require.Equal(t, "Top.Science", s)
+
+	var ltree pgtype.Ltree
+	err = m.Scan(ltreeOID, pgtype.TextFormatCode, []byte("Top.Science"), &ltree)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Ltree{Labels: []string{"Top", "Science"}, Valid: true}, ltree)
+	require.Equal(t, "Top.Science", ltree.String())
+
+	err = m.Scan(ltreeOID, pgtype.BinaryFormatCode, []byte("\x02Top"), &ltree)
+	require.ErrorContains(t, err, "unsupported ltree binary version")
+}
@@ -2,9 +2,12 @@ package pgtype
 
 import (
 	"database/sql/driver"
+	"fmt"
 	"net"
 )
 
+// MacaddrCodec is the codec for the PostgreSQL macaddr type. Values are represented as 6 byte net.HardwareAddr. Text
+// input is parsed with net.ParseMAC so addresses may be colon, hyphen, or dot separated.
 type MacaddrCodec struct{}
 
 func (MacaddrCodec) FormatSupported(format int16) bool {
@@ -16,19 +19,89 @@ func (MacaddrCodec) PreferredFormat() int16 {
 }
 
 func (MacaddrCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	return planMacaddrEncode(macaddrSize, format, value)
+}
+
+func (MacaddrCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	return planMacaddrScan(macaddrSize, format, target)
+}
+
+// Macaddr8Codec is the codec for the PostgreSQL macaddr8 type. Values are represented as 8 byte net.HardwareAddr. As
+// with PostgreSQL, a 6 byte address may be encoded and is stored in EUI-64 form by the server. Text input is parsed with
+// net.ParseMAC so addresses may be colon, hyphen, or dot separated.
+//
+// macaddr8 values can be scanned into a net.HardwareAddr. DecodeValue returns the text format as a string, as it did
+// before macaddr8 was registered by default, so Rows.Values and scanning into *any also return a string.
+type Macaddr8Codec struct{}
+
+func (Macaddr8Codec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (Macaddr8Codec) PreferredFormat() int16 {
+	return BinaryFormatCode
+}
+
+func (Macaddr8Codec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	return planMacaddrEncode(macaddr8Size, format, value)
+}
+
+func (Macaddr8Codec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	return planMacaddrScan(macaddr8Size, format, target)
+}
+
+func (c Macaddr8Codec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return c.DecodeValue(m, oid, format, src)
+}
+
+func (c Macaddr8Codec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var text Text
+	err := codecScan(c, m, oid, format, src, &text)
+	if err != nil {
+		return nil, err
+	}
+	return text.String, nil
+}
+
+const (
+	macaddrSize  = 6
+	macaddr8Size = 8
+)
+
+// checkMacaddrLen returns an error if an address of n bytes cannot be encoded as a value of size bytes. macaddr8
+// accepts 6 byte addresses like PostgreSQL does.
+func checkMacaddrLen(size, n int) error {
+	if n == size || (size == macaddr8Size && n == macaddrSize) {
+		return nil
+	}
+	return fmt.Errorf("invalid length for %s: %d", macaddrTypeName(size), n)
+}
+
+func macaddrTypeName(size int) string {
+	if size == macaddr8Size {
+		return "macaddr8"
+	}
+	return "macaddr"
+}
+
+func planMacaddrEncode(size int, format int16, value any) EncodePlan {
 	switch format {
 	case BinaryFormatCode:
 		switch value.(type) {
 		case net.HardwareAddr:
-			return encodePlanMacaddrCodecBinaryHardwareAddr{}
+			return encodePlanMacaddrCodecBinaryHardwareAddr{size: size}
 		case TextValuer:
-			return encodePlanMacAddrCodecTextValuer{}
+			return encodePlanMacAddrCodecTextValuer{size: size}
 
 		}
 	case TextFormatCode:
 		switch value.(type) {
 		case net.HardwareAddr:
-			return encodePlanMacaddrCodecTextHardwareAddr{}
+			return encodePlanMacaddrCodecTextHardwareAddr{size: size}
 		case TextValuer:
 			return encodePlanTextCodecTextValuer{}
 		}
@@ -37,20 +110,28 @@ func (MacaddrCodec) PlanEncode(m *Map, oid uint32, format int16, value any) Enco
 	return nil
 }
 
-type encodePlanMacaddrCodecBinaryHardwareAddr struct{}
+type encodePlanMacaddrCodecBinaryHardwareAddr struct {
+	size int
+}
 
-func (encodePlanMacaddrCodecBinaryHardwareAddr) Encode(value any, buf []byte) (newBuf []byte, err error) {
+func (plan encodePlanMacaddrCodecBinaryHardwareAddr) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	addr := value.(net.HardwareAddr)
 	if addr == nil {
 		return nil, nil
 	}
 
+	if err := checkMacaddrLen(plan.size, len(addr)); err != nil {
+		return nil, err
+	}
+
 	return append(buf, addr...), nil
 }
 
-type encodePlanMacAddrCodecTextValuer struct{}
+type encodePlanMacAddrCodecTextValuer struct {
+	size int
+}
 
-func (encodePlanMacAddrCodecTextValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+func (plan encodePlanMacAddrCodecTextValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	t, err := value.(TextValuer).TextValue()
 	if err != nil {
 		return nil, err
@@ -64,33 +145,43 @@ func (encodePlanMacAddrCodecTextValuer) Encode(value any, buf []byte) (newBuf []
 		return nil, err
 	}
 
+	if err := checkMacaddrLen(plan.size, len(addr)); err != nil {
+		return nil, err
+	}
+
 	return append(buf, addr...), nil
 }
 
-type encodePlanMacaddrCodecTextHardwareAddr struct{}
+type encodePlanMacaddrCodecTextHardwareAddr struct {
+	size int
+}
 
-func (encodePlanMacaddrCodecTextHardwareAddr) Encode(value any, buf []byte) (newBuf []byte, err error) {
+func (plan encodePlanMacaddrCodecTextHardwareAddr) Encode(value any, buf []byte) (newBuf []byte, err error) {
 	addr := value.(net.HardwareAddr)
 	if addr == nil {
 		return nil, nil
 	}
 
+	if err := checkMacaddrLen(plan.size, len(addr)); err != nil {
+		return nil, err
+	}
+
 	return append(buf, addr.String()...), nil
 }
 
-func (MacaddrCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+func planMacaddrScan(size int, format int16, target any) ScanPlan {
 	switch format {
 	case BinaryFormatCode:
 		switch target.(type) {
 		case *net.HardwareAddr:
-			return scanPlanBinaryMacaddrToHardwareAddr{}
+			return scanPlanBinaryMacaddrToHardwareAddr{size: size}
 		case TextScanner:
-			return scanPlanBinaryMacaddrToTextScanner{}
+			return scanPlanBinaryMacaddrToTextScanner{size: size}
 		}
 	case TextFormatCode:
 		switch target.(type) {
 		case *net.HardwareAddr:
-			return scanPlanTextMacaddrToHardwareAddr{}
+			return scanPlanTextMacaddrToHardwareAddr{size: size}
 		case TextScanner:
 			return scanPlanTextAnyToTextScanner{}
 		}
@@ -99,34 +190,48 @@ func (MacaddrCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanP
 	return nil
 }
 
-type scanPlanBinaryMacaddrToHardwareAddr struct{}
+type scanPlanBinaryMacaddrToHardwareAddr struct {
+	size int
+}
 
-func (scanPlanBinaryMacaddrToHardwareAddr) Scan(src []byte, dst any) error {
+func (plan scanPlanBinaryMacaddrToHardwareAddr) Scan(src []byte, dst any) error {
 	dstBuf := dst.(*net.HardwareAddr)
 	if src == nil {
 		*dstBuf = nil
 		return nil
 	}
 
+	if len(src) != plan.size {
+		return fmt.Errorf("invalid length for %s: %d", macaddrTypeName(plan.size), len(src))
+	}
+
 	*dstBuf = make([]byte, len(src))
 	copy(*dstBuf, src)
 	return nil
 }
 
-type scanPlanBinaryMacaddrToTextScanner struct{}
+type scanPlanBinaryMacaddrToTextScanner struct {
+	size int
+}
 
-func (scanPlanBinaryMacaddrToTextScanner) Scan(src []byte, dst any) error {
+func (plan scanPlanBinaryMacaddrToTextScanner) Scan(src []byte, dst any) error {
 	scanner := (dst).(TextScanner)
 	if src == nil {
 		return scanner.ScanText(Text{})
 	}
 
+	if len(src) != plan.size {
+		return fmt.Errorf("invalid length for %s: %d", macaddrTypeName(plan.size), len(src))
+	}
+
 	return scanner.ScanText(Text{String: net.HardwareAddr(src).String(), Valid: true})
 }
 
-type scanPlanTextMacaddrToHardwareAddr struct{}
+type scanPlanTextMacaddrToHardwareAddr struct {
+	size int
+}
 
-func (scanPlanTextMacaddrToHardwareAddr) Scan(src []byte, dst any) error {
+func (plan scanPlanTextMacaddrToHardwareAddr) Scan(src []byte, dst any) error {
 	p := dst.(*net.HardwareAddr)
 
 	if src == nil {
@@ -139,6 +244,10 @@ func (scanPlanTextMacaddrToHardwareAddr) Scan(src []byte, dst any) error {
 		return err
 	}
 
+	if len(addr) != plan.size {
+		return fmt.Errorf("invalid length for %s: %d", macaddrTypeName(plan.size), len(addr))
+	}
+
 	*p = addr
 
 	return nil
@@ -6,7 +6,9 @@ import (
 	"net"
 	"testing"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func isExpectedEqHardwareAddr(a any) func(any) bool {
@@ -49,3 +51,108 @@ func TestMacaddrCodec(t *testing.T) {
 		{nil, new(*net.HardwareAddr), isExpectedEq((*net.HardwareAddr)(nil))},
 	})
 }
+
+func TestMacaddr8Codec(t *testing.T) {
+	skipCockroachDB(t, "Server does not support type macaddr8")
+
+	// Only testing known OID query exec modes as net.HardwareAddr could map to macaddr or macaddr8.
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, "macaddr8", []pgxtest.ValueRoundTripTest{
+		{
+			mustParseMacaddr(t, "01:23:45:67:89:ab:cd:ef"),
+			new(net.HardwareAddr),
+			isExpectedEqHardwareAddr(mustParseMacaddr(t, "01:23:45:67:89:ab:cd:ef")),
+		},
+		{
+			"01:23:45:67:89:ab:cd:ef",
+			new(net.HardwareAddr),
+			isExpectedEqHardwareAddr(mustParseMacaddr(t, "01:23:45:67:89:ab:cd:ef")),
+		},
+		{
+			"01-23-45-67-89-ab-cd-ef",
+			new(net.HardwareAddr),
+			isExpectedEqHardwareAddr(mustParseMacaddr(t, "01:23:45:67:89:ab:cd:ef")),
+		},
+		{
+			mustParseMacaddr(t, "01:23:45:67:89:ab:cd:ef"),
+			new(string),
+			isExpectedEq("01:23:45:67:89:ab:cd:ef"),
+		},
+		{nil, new(*net.HardwareAddr), isExpectedEq((*net.HardwareAddr)(nil))},
+	})
+}
+
+func TestMacaddr8CodecWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+	want := net.HardwareAddr{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}
+
+	var addr net.HardwareAddr
+	err := m.Scan(pgtype.Macaddr8OID, pgtype.BinaryFormatCode, want, &addr)
+	require.NoError(t, err)
+	require.Equal(t, want, addr)
+
+	var s string
+	err = m.Scan(pgtype.Macaddr8OID, pgtype.BinaryFormatCode, want, &s)
+	require.NoError(t, err)
+	require.Equal(t, "01:23:45:67:89:ab:cd:ef", s)
+
+	for _, text := range []string{"01:23:45:67:89:ab:cd:ef", "01-23-45-67-89-AB-CD-EF"} {
+		buf, err := m.Encode(pgtype.Macaddr8OID, pgtype.BinaryFormatCode, text, nil)
+		require.NoError(t, err)
+		require.Equal(t, []byte(want), buf)
+	}
+
+	_, err = m.Encode(pgtype.Macaddr8OID, pgtype.BinaryFormatCode, "not a mac", nil)
+	require.Error(t, err)
+
+	// macaddr8 decodes to a string as it did before it was registered by default.
+	dt, ok := m.TypeForOID(pgtype.Macaddr8OID)
+	require.True(t, ok)
+	v, err := dt.Codec.DecodeValue(m, pgtype.Macaddr8OID, pgtype.BinaryFormatCode, want)
+	require.NoError(t, err)
+	require.Equal(t, "01:23:45:67:89:ab:cd:ef", v)
+
+	v, err = dt.Codec.DecodeValue(m, pgtype.Macaddr8OID, pgtype.TextFormatCode, []byte("01:23:45:67:89:ab:cd:ef"))
+	require.NoError(t, err)
+	require.Equal(t, "01:23:45:67:89:ab:cd:ef", v)
+
+	// A 6 byte address may be sent to macaddr8 but the server always returns 8 bytes.
+	buf, err := m.Encode(pgtype.Macaddr8OID, pgtype.BinaryFormatCode, want[:6], nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte(want[:6]), buf)
+
+	err = m.Scan(pgtype.Macaddr8OID, pgtype.BinaryFormatCode, want[:6], &addr)
+	require.ErrorContains(t, err, "invalid length for macaddr8")
+
+	_, err = m.Encode(pgtype.Macaddr8OID, pgtype.BinaryFormatCode, want[:7], nil)
+	require.ErrorContains(t, err, "invalid length for macaddr8")
+}
+
+func TestMacaddrCodecChecksLength(t *testing.T) {
+	m := pgtype.NewMap()
+	eui64 := net.HardwareAddr{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		_, err := m.Encode(pgtype.MacaddrOID, format, eui64, nil)
+		require.ErrorContains(t, err, "invalid length for macaddr")
+
+		_, err = m.Encode(pgtype.MacaddrOID, format, "01:23:45:67:89:ab:cd:ef", nil)
+		if format == pgtype.BinaryFormatCode {
+			require.ErrorContains(t, err, "invalid length for macaddr")
+		}
+	}
+
+	var addr net.HardwareAddr
+	err := m.Scan(pgtype.MacaddrOID, pgtype.BinaryFormatCode, []byte(eui64), &addr)
+	require.ErrorContains(t, err, "invalid length for macaddr")
+
+	var s string
+	err = m.Scan(pgtype.MacaddrOID, pgtype.BinaryFormatCode, []byte(eui64), &s)
+	require.ErrorContains(t, err, "invalid length for macaddr")
+
+	err = m.Scan(pgtype.MacaddrOID, pgtype.TextFormatCode, []byte("01:23:45:67:89:ab:cd:ef"), &addr)
+	require.ErrorContains(t, err, "invalid length for macaddr")
+
+	err = m.Scan(pgtype.MacaddrOID, pgtype.BinaryFormatCode, []byte(eui64[:6]), &addr)
+	require.NoError(t, err)
+	require.Equal(t, eui64[:6], addr)
+}
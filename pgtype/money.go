@@ -0,0 +1,432 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/internal/pgio"
+)
+
+type MoneyScanner interface {
+	ScanMoney(v Money) error
+}
+
+type MoneyValuer interface {
+	MoneyValue() (Money, error)
+}
+
+// Money represents the PostgreSQL money type. Cents is the amount in the smallest unit of the currency.
+//
+// PostgreSQL stores money as a 64-bit integer whose number of fractional digits depends on the lc_monetary setting.
+// Money assumes 2 fractional digits which is correct for most locales. The binary format is independent of
+// lc_monetary. The text format is parsed by ignoring currency symbols and grouping separators, so values such as
+// "$1,234.56", "-1.234,56 €", and "($1.23)" are all understood.
+type Money struct {
+	Cents int64
+	Valid bool
+}
+
+func (m *Money) ScanMoney(v Money) error {
+	*m = v
+	return nil
+}
+
+func (m Money) MoneyValue() (Money, error) {
+	return m, nil
+}
+
+// String returns the amount as a decimal string with 2 fractional digits such as "-12.34". It returns "NULL" if m is
+// not valid.
+func (m Money) String() string {
+	if !m.Valid {
+		return "NULL"
+	}
+
+	return string(appendMoneyDecimal(nil, m.Cents))
+}
+
+// Scan implements the database/sql Scanner interface.
+func (m *Money) Scan(src any) error {
+	if src == nil {
+		*m = Money{}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case int64:
+		*m = Money{Cents: src, Valid: true}
+		return nil
+	case string:
+		cents, err := parseMoneyText([]byte(src))
+		if err != nil {
+			return err
+		}
+		*m = Money{Cents: cents, Valid: true}
+		return nil
+	case []byte:
+		cents, err := parseMoneyText(src)
+		if err != nil {
+			return err
+		}
+		*m = Money{Cents: cents, Valid: true}
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (m Money) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+
+	return m.String(), nil
+}
+
+// appendMoneyDecimal appends cents formatted as a decimal with 2 fractional digits to buf.
+func appendMoneyDecimal(buf []byte, cents int64) []byte {
+	u := uint64(cents)
+	if cents < 0 {
+		buf = append(buf, '-')
+		u = -u
+	}
+
+	buf = strconv.AppendUint(buf, u/100, 10)
+	buf = append(buf, '.')
+	frac := u % 100
+	if frac < 10 {
+		buf = append(buf, '0')
+	}
+	return strconv.AppendUint(buf, frac, 10)
+}
+
+// parseMoneyText parses the text format of money without depending on lc_monetary. Everything except digits, the
+// decimal separator, and the sign is ignored. The decimal separator is the last '.' or ',' if it is followed by 1 or 2
+// digits and no other digits follow. A leading '-' or enclosing parentheses make the value negative.
+func parseMoneyText(src []byte) (int64, error) {
+	negative := false
+	sepIdx := -1
+	for i, b := range src {
+		switch b {
+		case '-', '(':
+			negative = true
+		case '.', ',':
+			sepIdx = i
+		}
+	}
+
+	digits := make([]byte, 0, len(src)+2)
+	fracDigits := 0
+	for i, b := range src {
+		if b < '0' || b > '9' {
+			continue
+		}
+		digits = append(digits, b)
+		if sepIdx >= 0 && i > sepIdx {
+			fracDigits++
+		}
+	}
+
+	if len(digits) == 0 {
+		return 0, fmt.Errorf("invalid money format: %q", src)
+	}
+
+	if fracDigits > 2 {
+		// The last separator was a grouping separator so there are no fractional digits.
+		fracDigits = 0
+	}
+	for ; fracDigits < 2; fracDigits++ {
+		digits = append(digits, '0')
+	}
+
+	if negative {
+		digits = append([]byte{'-'}, digits...)
+	}
+
+	cents, err := strconv.ParseInt(string(digits), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid money format: %q: %w", src, err)
+	}
+
+	return cents, nil
+}
+
+// MoneyCodec is a codec for the PostgreSQL money type. The text format is preferred so money values scanned into a
+// string or decoded with DecodeValue are the server's formatting of the value such as "$1,234.56". Use Money or int64
+// to get the amount independently of lc_monetary.
+type MoneyCodec struct{}
+
+func (MoneyCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (MoneyCodec) PreferredFormat() int16 {
+	return TextFormatCode
+}
+
+func (MoneyCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	switch format {
+	case BinaryFormatCode:
+		switch value.(type) {
+		case MoneyValuer:
+			return encodePlanMoneyCodecBinaryMoneyValuer{}
+		case Int64Valuer:
+			return encodePlanMoneyCodecBinaryInt64Valuer{}
+		case TextValuer:
+			return encodePlanMoneyCodecBinaryTextValuer{}
+		}
+	case TextFormatCode:
+		switch value.(type) {
+		case MoneyValuer:
+			return encodePlanMoneyCodecTextMoneyValuer{}
+		case Int64Valuer:
+			return encodePlanMoneyCodecTextInt64Valuer{}
+		case TextValuer:
+			return encodePlanMoneyCodecTextTextValuer{}
+		}
+	}
+
+	return nil
+}
+
+type encodePlanMoneyCodecBinaryMoneyValuer struct{}
+
+func (encodePlanMoneyCodecBinaryMoneyValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	money, err := value.(MoneyValuer).MoneyValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !money.Valid {
+		return nil, nil
+	}
+
+	return pgio.AppendInt64(buf, money.Cents), nil
+}
+
+type encodePlanMoneyCodecBinaryInt64Valuer struct{}
+
+func (encodePlanMoneyCodecBinaryInt64Valuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	n, err := value.(Int64Valuer).Int64Value()
+	if err != nil {
+		return nil, err
+	}
+
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return pgio.AppendInt64(buf, n.Int64), nil
+}
+
+type encodePlanMoneyCodecBinaryTextValuer struct{}
+
+func (encodePlanMoneyCodecBinaryTextValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	t, err := value.(TextValuer).TextValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Valid {
+		return nil, nil
+	}
+
+	cents, err := parseMoneyText([]byte(t.String))
+	if err != nil {
+		return nil, err
+	}
+
+	return pgio.AppendInt64(buf, cents), nil
+}
+
+type encodePlanMoneyCodecTextMoneyValuer struct{}
+
+func (encodePlanMoneyCodecTextMoneyValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	money, err := value.(MoneyValuer).MoneyValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !money.Valid {
+		return nil, nil
+	}
+
+	return appendMoneyDecimal(buf, money.Cents), nil
+}
+
+type encodePlanMoneyCodecTextInt64Valuer struct{}
+
+func (encodePlanMoneyCodecTextInt64Valuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	n, err := value.(Int64Valuer).Int64Value()
+	if err != nil {
+		return nil, err
+	}
+
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return appendMoneyDecimal(buf, n.Int64), nil
+}
+
+type encodePlanMoneyCodecTextTextValuer struct{}
+
+func (encodePlanMoneyCodecTextTextValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	t, err := value.(TextValuer).TextValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.Valid {
+		return nil, nil
+	}
+
+	cents, err := parseMoneyText([]byte(t.String))
+	if err != nil {
+		return nil, err
+	}
+
+	return appendMoneyDecimal(buf, cents), nil
+}
+
+func (MoneyCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	switch format {
+	case BinaryFormatCode:
+		switch target.(type) {
+		case MoneyScanner:
+			return scanPlanBinaryMoneyToMoneyScanner{}
+		case Int64Scanner:
+			return scanPlanBinaryMoneyToInt64Scanner{}
+		case TextScanner:
+			return scanPlanBinaryMoneyToTextScanner{}
+		}
+	case TextFormatCode:
+		switch target.(type) {
+		case MoneyScanner:
+			return scanPlanTextAnyToMoneyScanner{}
+		case Int64Scanner:
+			return scanPlanTextMoneyToInt64Scanner{}
+		case TextScanner:
+			return scanPlanTextAnyToTextScanner{}
+		}
+	}
+
+	return nil
+}
+
+func (c MoneyCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	if format == TextFormatCode {
+		return string(src), nil
+	}
+
+	var money Money
+	err := codecScan(c, m, oid, format, src, &money)
+	if err != nil {
+		return nil, err
+	}
+	return money.String(), nil
+}
+
+func (c MoneyCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	return c.DecodeDatabaseSQLValue(m, oid, format, src)
+}
+
+func parseMoneyBinary(src []byte) (int64, error) {
+	if len(src) != 8 {
+		return 0, fmt.Errorf("invalid length for money: %v", len(src))
+	}
+
+	return int64(binary.BigEndian.Uint64(src)), nil
+}
+
+type scanPlanBinaryMoneyToMoneyScanner struct{}
+
+func (scanPlanBinaryMoneyToMoneyScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(MoneyScanner)
+
+	if src == nil {
+		return scanner.ScanMoney(Money{})
+	}
+
+	cents, err := parseMoneyBinary(src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanMoney(Money{Cents: cents, Valid: true})
+}
+
+type scanPlanBinaryMoneyToInt64Scanner struct{}
+
+func (scanPlanBinaryMoneyToInt64Scanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(Int64Scanner)
+
+	if src == nil {
+		return scanner.ScanInt64(Int8{})
+	}
+
+	cents, err := parseMoneyBinary(src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanInt64(Int8{Int64: cents, Valid: true})
+}
+
+type scanPlanBinaryMoneyToTextScanner struct{}
+
+func (scanPlanBinaryMoneyToTextScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TextScanner)
+
+	if src == nil {
+		return scanner.ScanText(Text{})
+	}
+
+	cents, err := parseMoneyBinary(src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanText(Text{String: string(appendMoneyDecimal(nil, cents)), Valid: true})
+}
+
+type scanPlanTextAnyToMoneyScanner struct{}
+
+func (scanPlanTextAnyToMoneyScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(MoneyScanner)
+
+	if src == nil {
+		return scanner.ScanMoney(Money{})
+	}
+
+	cents, err := parseMoneyText(src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanMoney(Money{Cents: cents, Valid: true})
+}
+
+type scanPlanTextMoneyToInt64Scanner struct{}
+
+func (scanPlanTextMoneyToInt64Scanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(Int64Scanner)
+
+	if src == nil {
+		return scanner.ScanInt64(Int8{})
+	}
+
+	cents, err := parseMoneyText(src)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanInt64(Int8{Int64: cents, Valid: true})
+}
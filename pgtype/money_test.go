@@ -0,0 +1,101 @@
+package pgtype_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyCodec(t *testing.T) {
+	skipCockroachDB(t, "Server does not support money")
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "money", []pgxtest.ValueRoundTripTest{
+		{pgtype.Money{Cents: 0, Valid: true}, new(pgtype.Money), isExpectedEq(pgtype.Money{Cents: 0, Valid: true})},
+		{pgtype.Money{Cents: 123456, Valid: true}, new(pgtype.Money), isExpectedEq(pgtype.Money{Cents: 123456, Valid: true})},
+		{pgtype.Money{Cents: -5, Valid: true}, new(pgtype.Money), isExpectedEq(pgtype.Money{Cents: -5, Valid: true})},
+		{pgtype.Money{Cents: math.MaxInt64, Valid: true}, new(pgtype.Money), isExpectedEq(pgtype.Money{Cents: math.MaxInt64, Valid: true})},
+		{pgtype.Money{Cents: math.MinInt64, Valid: true}, new(pgtype.Money), isExpectedEq(pgtype.Money{Cents: math.MinInt64, Valid: true})},
+		{int64(199), new(int64), isExpectedEq(int64(199))},
+		{int64(-199), new(pgtype.Money), isExpectedEq(pgtype.Money{Cents: -199, Valid: true})},
+		{"12.34", new(pgtype.Money), isExpectedEq(pgtype.Money{Cents: 1234, Valid: true})},
+		{pgtype.Money{}, new(pgtype.Money), isExpectedEq(pgtype.Money{})},
+		{nil, new(*int64), isExpectedEq((*int64)(nil))},
+	})
+}
+
+func TestMoneyCodecDecodeValue(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.MoneyOID, format, pgtype.Money{Cents: -123456, Valid: true}, nil)
+		require.NoError(t, err)
+
+		var money pgtype.Money
+		err = m.Scan(pgtype.MoneyOID, format, buf, &money)
+		require.NoError(t, err)
+		require.Equal(t, pgtype.Money{Cents: -123456, Valid: true}, money)
+
+		var s string
+		err = m.Scan(pgtype.MoneyOID, format, buf, &s)
+		require.NoError(t, err)
+		require.Equal(t, "-1234.56", s)
+	}
+
+	dt, ok := m.TypeForOID(pgtype.MoneyOID)
+	require.True(t, ok)
+	require.EqualValues(t, pgtype.TextFormatCode, dt.Codec.PreferredFormat())
+
+	v, err := dt.Codec.DecodeValue(m, pgtype.MoneyOID, pgtype.TextFormatCode, []byte("$1,234.56"))
+	require.NoError(t, err)
+	require.Equal(t, "$1,234.56", v)
+
+	var s string
+	err = m.Scan(pgtype.MoneyOID, pgtype.TextFormatCode, []byte("$1,234.56"), &s)
+	require.NoError(t, err)
+	require.Equal(t, "$1,234.56", s)
+
+	buf, err := m.Encode(pgtype.MoneyOID, pgtype.BinaryFormatCode, pgtype.Money{Cents: 123456, Valid: true}, nil)
+	require.NoError(t, err)
+	v, err = dt.Codec.DecodeValue(m, pgtype.MoneyOID, pgtype.BinaryFormatCode, buf)
+	require.NoError(t, err)
+	require.Equal(t, "1234.56", v)
+}
+
+func TestMoneyScanLocaleIndependentText(t *testing.T) {
+	for i, tt := range []struct {
+		src   string
+		cents int64
+	}{
+		{src: "$0.00", cents: 0},
+		{src: "$1,234.56", cents: 123456},
+		{src: "-$1,234.56", cents: -123456},
+		{src: "($1.23)", cents: -123},
+		{src: "1.234,56 €", cents: 123456},
+		{src: "-1 234,56 €", cents: -123456},
+		{src: "Fr. 1'234.50", cents: 123450},
+		{src: "12.5", cents: 1250},
+		{src: "1,234", cents: 123400},
+		{src: "42", cents: 4200},
+		{src: "-$92,233,720,368,547,758.08", cents: math.MinInt64},
+	} {
+		var money pgtype.Money
+		err := money.Scan(tt.src)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, pgtype.Money{Cents: tt.cents, Valid: true}, money, "%d: %s", i, tt.src)
+	}
+
+	var money pgtype.Money
+	require.Error(t, money.Scan("$"))
+	require.Error(t, money.Scan("$92,233,720,368,547,758.08"))
+}
+
+func TestMoneyString(t *testing.T) {
+	require.Equal(t, "12.34", pgtype.Money{Cents: 1234, Valid: true}.String())
+	require.Equal(t, "-0.05", pgtype.Money{Cents: -5, Valid: true}.String())
+	require.Equal(t, "-92233720368547758.08", pgtype.Money{Cents: math.MinInt64, Valid: true}.String())
+	require.Equal(t, "NULL", pgtype.Money{}.String())
+}
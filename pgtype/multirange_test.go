@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	pgx "github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -112,3 +113,68 @@ func TestMultirangeCodecDecodeValue(t *testing.T) {
 		}
 	})
 }
+
+func TestMultirangeCodecTstzmultirange(t *testing.T) {
+	skipPostgreSQLVersionLessThan(t, 14)
+	skipCockroachDB(t, "Server does not support range types (see https://github.com/cockroachdb/cockroach/issues/27791)")
+
+	mr := pgtype.Multirange[pgtype.Range[pgtype.Timestamptz]]{
+		{
+			Lower:     pgtype.Timestamptz{Time: time.Date(2022, 1, 1, 9, 0, 0, 0, time.UTC), Valid: true},
+			Upper:     pgtype.Timestamptz{Time: time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC), Valid: true},
+			LowerType: pgtype.Inclusive,
+			UpperType: pgtype.Exclusive,
+			Valid:     true,
+		},
+		{
+			Lower:     pgtype.Timestamptz{Time: time.Date(2022, 1, 1, 13, 0, 0, 0, time.UTC), Valid: true},
+			Upper:     pgtype.Timestamptz{Time: time.Date(2022, 1, 1, 17, 0, 0, 0, time.UTC), Valid: true},
+			LowerType: pgtype.Inclusive,
+			UpperType: pgtype.Exclusive,
+			Valid:     true,
+		},
+	}
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "tstzmultirange", []pgxtest.ValueRoundTripTest{
+		{
+			mr,
+			new(pgtype.Multirange[pgtype.Range[pgtype.Timestamptz]]),
+			func(a any) bool {
+				b := *a.(*pgtype.Multirange[pgtype.Range[pgtype.Timestamptz]])
+				if len(b) != len(mr) {
+					return false
+				}
+				for i := range mr {
+					if !mr[i].Lower.Time.Equal(b[i].Lower.Time) || !mr[i].Upper.Time.Equal(b[i].Upper.Time) ||
+						mr[i].LowerType != b[i].LowerType || mr[i].UpperType != b[i].UpperType || !b[i].Valid {
+						return false
+					}
+				}
+				return true
+			},
+		},
+	})
+}
+
+func TestMultirangeCodecArray(t *testing.T) {
+	skipPostgreSQLVersionLessThan(t, 14)
+	skipCockroachDB(t, "Server does not support range types (see https://github.com/cockroachdb/cockroach/issues/27791)")
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var mrs []pgtype.Multirange[pgtype.Range[pgtype.Int4]]
+		err := conn.QueryRow(ctx, `select array[int4multirange(int4range(1, 5)), int4multirange()]`).Scan(&mrs)
+		require.NoError(t, err)
+		require.Equal(t, []pgtype.Multirange[pgtype.Range[pgtype.Int4]]{
+			{
+				{
+					Lower:     pgtype.Int4{Int32: 1, Valid: true},
+					Upper:     pgtype.Int4{Int32: 5, Valid: true},
+					LowerType: pgtype.Inclusive,
+					UpperType: pgtype.Exclusive,
+					Valid:     true,
+				},
+			},
+			{},
+		}, mrs)
+	})
+}
@@ -0,0 +1,95 @@
+package pgtype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+)
+
+// NameMaxLen is the maximum length in bytes of a PostgreSQL name with the default NAMEDATALEN of 64.
+const NameMaxLen = 63
+
+// NameCodec is a codec for the PostgreSQL name type. It accepts and produces the same Go types as TextCodec.
+//
+// A name is stored as a fixed size, NUL-padded buffer. PostgreSQL strips the padding before sending a name, but some
+// servers and proxies that implement the wire protocol send the whole buffer. Trailing NUL bytes are removed when
+// decoding so these values compare equal to the strings they represent. Values longer than NameMaxLen bytes are
+// rejected when encoding instead of being silently truncated by the server.
+type NameCodec struct{}
+
+func (NameCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (NameCodec) PreferredFormat() int16 {
+	return TextFormatCode
+}
+
+func (NameCodec) validatesText() bool {
+	return true
+}
+
+func (NameCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	next := TextCodec{}.PlanEncode(m, oid, format, value)
+	if next == nil {
+		return nil
+	}
+
+	return &encodePlanNameCodec{next: next}
+}
+
+type encodePlanNameCodec struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanNameCodec) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	sp := len(buf)
+	newBuf, err = plan.next.Encode(value, buf)
+	if err != nil || newBuf == nil {
+		return newBuf, err
+	}
+
+	if n := len(newBuf) - sp; n > NameMaxLen {
+		return nil, fmt.Errorf("name is %d bytes long, the maximum is %d", n, NameMaxLen)
+	}
+
+	return newBuf, nil
+}
+
+func (NameCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	next := TextCodec{}.PlanScan(m, oid, format, target)
+	if next == nil {
+		return nil
+	}
+
+	return &scanPlanNameCodec{next: next}
+}
+
+type scanPlanNameCodec struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanNameCodec) Scan(src []byte, dst any) error {
+	return plan.next.Scan(trimNamePadding(src), dst)
+}
+
+func (c NameCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return c.DecodeValue(m, oid, format, src)
+}
+
+func (c NameCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	return string(trimNamePadding(src)), nil
+}
+
+// trimNamePadding truncates src at the first NUL byte, which ends a name that is padded to its fixed size. A NULL src
+// remains nil.
+func trimNamePadding(src []byte) []byte {
+	if i := bytes.IndexByte(src, 0); i >= 0 {
+		return src[:i]
+	}
+	return src
+}
@@ -0,0 +1,80 @@
+package pgtype_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameCodec(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, "name", []pgxtest.ValueRoundTripTest{
+		{"pg_class", new(string), isExpectedEq("pg_class")},
+		{strings.Repeat("x", pgtype.NameMaxLen), new(string), isExpectedEq(strings.Repeat("x", pgtype.NameMaxLen))},
+		{pgtype.Text{String: "relname", Valid: true}, new(pgtype.Text), isExpectedEq(pgtype.Text{String: "relname", Valid: true})},
+		{pgtype.Text{}, new(pgtype.Text), isExpectedEq(pgtype.Text{})},
+		{nil, new(*string), isExpectedEq((*string)(nil))},
+	})
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var relname string
+		err := conn.QueryRow(ctx, "select relname from pg_class where relname = 'pg_class'").Scan(&relname)
+		require.NoError(t, err)
+		require.Equal(t, "pg_class", relname)
+
+		_, err = conn.Exec(ctx, "select $1::name", strings.Repeat("x", pgtype.NameMaxLen+1))
+		require.Error(t, err)
+	})
+}
+
+func TestNameCodecTrimsPadding(t *testing.T) {
+	m := pgtype.NewMap()
+	padded := append([]byte("relname"), make([]byte, 64-len("relname"))...)
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		var s string
+		err := m.Scan(pgtype.NameOID, format, padded, &s)
+		require.NoError(t, err)
+		assert.Equal(t, "relname", s)
+
+		var text pgtype.Text
+		err = m.Scan(pgtype.NameOID, format, padded, &text)
+		require.NoError(t, err)
+		assert.Equal(t, pgtype.Text{String: "relname", Valid: true}, text)
+
+		var names []string
+		err = m.Scan(pgtype.NameArrayOID, pgtype.BinaryFormatCode, mustEncodeNameArray(t, m, padded), &names)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"relname"}, names)
+
+		dt, ok := m.TypeForOID(pgtype.NameOID)
+		require.True(t, ok)
+		v, err := dt.Codec.DecodeValue(m, pgtype.NameOID, format, padded)
+		require.NoError(t, err)
+		assert.Equal(t, "relname", v)
+	}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		_, err := m.Encode(pgtype.NameOID, format, strings.Repeat("x", pgtype.NameMaxLen+1), nil)
+		require.Error(t, err)
+	}
+
+	buf, err := m.Encode(pgtype.NameOID, pgtype.TextFormatCode, pgtype.Text{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, buf)
+}
+
+// mustEncodeNameArray encodes a one element name[] in the binary format with element as the raw bytes of the element.
+func mustEncodeNameArray(t *testing.T, m *pgtype.Map, element []byte) []byte {
+	buf, err := m.Encode(pgtype.ByteaArrayOID, pgtype.BinaryFormatCode, [][]byte{element}, nil)
+	require.NoError(t, err)
+
+	// Replace the element type OID in the header.
+	copy(buf[8:12], []byte{0, 0, 0, byte(pgtype.NameOID)})
+	return buf
+}
@@ -92,6 +92,239 @@ func (n Numeric) Float64Value() (Float8, error) {
 	return Float8{Float64: f, Valid: true}, nil
 }
 
+// Float64Exact returns n as a float64 and whether the conversion was exact. NaN and infinity convert exactly. exact is
+// false if n is not valid, if n is outside the range of float64, or if n has more precision than float64 can represent.
+func (n Numeric) Float64Exact() (f float64, exact bool) {
+	if !n.Valid {
+		return 0, false
+	}
+
+	f8, err := n.Float64Value()
+	if err != nil {
+		return 0, false
+	}
+
+	if n.NaN || n.InfinityModifier != Finite {
+		return f8.Float64, true
+	}
+
+	fr := new(big.Rat)
+	if fr.SetFloat64(f8.Float64) == nil {
+		return f8.Float64, false
+	}
+
+	return f8.Float64, fr.Cmp(n.toBigRat()) == 0
+}
+
+// Int64Exact returns n as an int64 and whether the conversion was exact. ok is false if n is not valid, is NaN or
+// infinity, has a fractional part, or is outside the range of int64.
+func (n Numeric) Int64Exact() (i int64, ok bool) {
+	if !n.Valid || n.NaN || n.InfinityModifier != Finite {
+		return 0, false
+	}
+
+	if n.Int == nil {
+		return 0, true
+	}
+
+	bi, err := n.toBigInt()
+	if err != nil || !bi.IsInt64() {
+		return 0, false
+	}
+
+	return bi.Int64(), true
+}
+
+// Rat returns n as an exact big.Rat. It returns nil if n is not valid, NaN, or infinity.
+func (n Numeric) Rat() *big.Rat {
+	if !n.Valid || n.NaN || n.InfinityModifier != Finite {
+		return nil
+	}
+
+	return n.toBigRat()
+}
+
+// SetRat sets n to the exact value of r. If r is nil then n is set to NULL. It returns an error if r cannot be
+// represented as a terminating decimal such as 1/3. Use SetRatRounded to round such values.
+func (n *Numeric) SetRat(r *big.Rat) error {
+	if r == nil {
+		*n = Numeric{}
+		return nil
+	}
+
+	// A fraction in lowest terms is a terminating decimal if and only if its denominator has no prime factors other than
+	// 2 and 5. The number of decimal places is the larger of the two exponents.
+	denom := new(big.Int).Set(r.Denom())
+	twos := denom.TrailingZeroBits()
+	denom.Rsh(denom, twos)
+
+	big5 := big.NewInt(5)
+	var fives uint
+	remainder := new(big.Int)
+	for {
+		quotient, rem := new(big.Int).QuoRem(denom, big5, remainder)
+		if rem.Sign() != 0 {
+			break
+		}
+		denom = quotient
+		fives++
+	}
+
+	if denom.Cmp(big1) != 0 {
+		return fmt.Errorf("%v cannot be represented as a terminating decimal", r)
+	}
+
+	scale := twos
+	if fives > scale {
+		scale = fives
+	}
+	if scale > math.MaxInt32 {
+		return fmt.Errorf("%v has too many decimal places", r)
+	}
+
+	num := new(big.Int).Exp(big10, big.NewInt(int64(scale)), nil)
+	num.Mul(num, r.Num())
+	num.Quo(num, r.Denom())
+
+	*n = Numeric{Int: num, Exp: -int32(scale), Valid: true}
+	return nil
+}
+
+// SetRatRounded sets n to r rounded to scale decimal places. Halves are rounded away from zero. If r is nil then n is
+// set to NULL. A negative scale rounds to the left of the decimal point. It returns an error if the exponent -scale is
+// out of range.
+func (n *Numeric) SetRatRounded(r *big.Rat, scale int32) error {
+	if r == nil {
+		*n = Numeric{}
+		return nil
+	}
+
+	exp64 := -int64(scale)
+	if exp64 > math.MaxInt32 {
+		return fmt.Errorf("scale %d is out of range", scale)
+	}
+
+	num := new(big.Int).Set(r.Num())
+	denom := new(big.Int).Set(r.Denom())
+	if scale >= 0 {
+		num.Mul(num, new(big.Int).Exp(big10, big.NewInt(int64(scale)), nil))
+	} else {
+		denom.Mul(denom, new(big.Int).Exp(big10, big.NewInt(-int64(scale)), nil))
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(num, denom, new(big.Int))
+	remainder.Abs(remainder)
+	remainder.Lsh(remainder, 1)
+	if remainder.Cmp(denom) >= 0 {
+		if num.Sign() < 0 {
+			quotient.Sub(quotient, big1)
+		} else {
+			quotient.Add(quotient, big1)
+		}
+	}
+
+	*n = Numeric{Int: quotient, Exp: int32(exp64), Valid: true}
+	return nil
+}
+
+// BigInt returns n as an exact *big.Int. It returns an error if n is not valid, NaN, infinity, or has a fractional part.
+func (n Numeric) BigInt() (*big.Int, error) {
+	if !n.Valid {
+		return nil, fmt.Errorf("cannot convert NULL to *big.Int")
+	}
+
+	bi, err := n.toBigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).Set(bi), nil
+}
+
+// SetBigInt sets n to the value of i. If i is nil then n is set to NULL.
+func (n *Numeric) SetBigInt(i *big.Int) {
+	if i == nil {
+		*n = Numeric{}
+		return
+	}
+
+	*n = Numeric{Int: new(big.Int).Set(i), Valid: true}
+}
+
+// BigFloat returns n as a *big.Float. The precision of the result is enough to hold the decimal digits of n and at
+// least the 53 bits of a float64. As with float64 most fractional decimals cannot be represented exactly. Infinity
+// converts to an infinite *big.Float. An error is returned if n is not valid or is NaN.
+func (n Numeric) BigFloat() (*big.Float, error) {
+	if !n.Valid {
+		return nil, fmt.Errorf("cannot convert NULL to *big.Float")
+	} else if n.NaN {
+		return nil, fmt.Errorf("cannot convert NaN to *big.Float")
+	} else if n.InfinityModifier != Finite {
+		return new(big.Float).SetInf(n.InfinityModifier == NegativeInfinity), nil
+	}
+
+	num := new(big.Int)
+	if n.Int != nil {
+		num.Set(n.Int)
+	}
+
+	digits := len(new(big.Int).Abs(num).String())
+	if n.Exp > 0 {
+		digits += int(n.Exp)
+		num.Mul(num, new(big.Int).Exp(big10, big.NewInt(int64(n.Exp)), nil))
+	}
+
+	prec := uint(math.Ceil(float64(digits) * math.Log2(10)))
+	if prec < 53 {
+		prec = 53
+	}
+
+	f := new(big.Float).SetPrec(prec).SetInt(num)
+	if n.Exp < 0 {
+		div := new(big.Float).SetInt(new(big.Int).Exp(big10, big.NewInt(int64(-n.Exp)), nil))
+		f.Quo(f, div)
+	}
+
+	return f, nil
+}
+
+// SetBigFloat sets n to the shortest decimal that rounds to f at the precision of f. Infinite values set
+// InfinityModifier. If f is nil then n is set to NULL.
+func (n *Numeric) SetBigFloat(f *big.Float) error {
+	if f == nil {
+		*n = Numeric{}
+		return nil
+	}
+
+	if f.IsInf() {
+		if f.Sign() < 0 {
+			*n = Numeric{InfinityModifier: NegativeInfinity, Valid: true}
+		} else {
+			*n = Numeric{InfinityModifier: Infinity, Valid: true}
+		}
+		return nil
+	}
+
+	mantissa, exponent, _ := strings.Cut(f.Text('e', -1), "e")
+	num, exp, err := parseNumericString(mantissa)
+	if err != nil {
+		return err
+	}
+
+	e, err := strconv.ParseInt(exponent, 10, 32)
+	if err != nil {
+		return fmt.Errorf("%v has an exponent that is out of range", f)
+	}
+
+	exp64 := int64(exp) + e
+	if exp64 > math.MaxInt32 || exp64 < math.MinInt32 {
+		return fmt.Errorf("%v has an exponent that is out of range", f)
+	}
+
+	*n = Numeric{Int: num, Exp: int32(exp64), Valid: true}
+	return nil
+}
+
 func (n *Numeric) ScanInt64(v Int8) error {
 	if !v.Valid {
 		*n = Numeric{}
@@ -119,7 +352,33 @@ func (n Numeric) Int64Value() (Int8, error) {
 	return Int8{Int64: bi.Int64(), Valid: true}, nil
 }
 
+// toBigRat returns the exact value of a finite n.
+func (n *Numeric) toBigRat() *big.Rat {
+	num := new(big.Int)
+	if n.Int != nil {
+		num.Set(n.Int)
+	}
+
+	if n.Exp >= 0 {
+		mul := new(big.Int).Exp(big10, big.NewInt(int64(n.Exp)), nil)
+		return new(big.Rat).SetInt(num.Mul(num, mul))
+	}
+
+	div := new(big.Int).Exp(big10, big.NewInt(int64(-n.Exp)), nil)
+	return new(big.Rat).SetFrac(num, div)
+}
+
 func (n *Numeric) toBigInt() (*big.Int, error) {
+	if n.NaN {
+		return nil, fmt.Errorf("cannot convert NaN to integer")
+	} else if n.InfinityModifier != Finite {
+		return nil, fmt.Errorf("cannot convert %v to integer", n.InfinityModifier)
+	}
+
+	if n.Int == nil {
+		return big.NewInt(0), nil
+	}
+
 	if n.Exp == 0 {
 		return n.Int, nil
 	}
@@ -211,13 +470,53 @@ func (n Numeric) Value() (driver.Value, error) {
 	return string(buf), err
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (n Numeric) Key() string {
+	if !n.Valid {
+		return ""
+	}
+
+	if n.NaN {
+		return "NaN"
+	}
+
+	if n.InfinityModifier != Finite {
+		return n.InfinityModifier.String()
+	}
+
+	if n.Int == nil || n.Int.Sign() == 0 {
+		return "0"
+	}
+
+	// Remove trailing zeros so that values with different scales such as 1.50 and 1.5 have the same key.
+	num := new(big.Int).Set(n.Int)
+	exp := int64(n.Exp)
+	quo, rem := new(big.Int), new(big.Int)
+	for {
+		quo.QuoRem(num, big10, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		num, quo = quo, num
+		exp++
+	}
+
+	return num.String() + "e" + strconv.FormatInt(exp, 10)
+}
+
 func (n Numeric) MarshalJSON() ([]byte, error) {
 	if !n.Valid {
 		return []byte("null"), nil
 	}
 
+	// NaN and infinity are not valid JSON numbers so they are marshaled as strings like PostgreSQL's to_json.
 	if n.NaN {
 		return []byte(`"NaN"`), nil
+	} else if n.InfinityModifier == Infinity {
+		return []byte(`"Infinity"`), nil
+	} else if n.InfinityModifier == NegativeInfinity {
+		return []byte(`"-Infinity"`), nil
 	}
 
 	return n.numberTextBytes(), nil
@@ -231,6 +530,12 @@ func (n *Numeric) UnmarshalJSON(src []byte) error {
 	if bytes.Equal(src, []byte(`"NaN"`)) {
 		*n = Numeric{NaN: true, Valid: true}
 		return nil
+	} else if bytes.Equal(src, []byte(`"Infinity"`)) {
+		*n = Numeric{InfinityModifier: Infinity, Valid: true}
+		return nil
+	} else if bytes.Equal(src, []byte(`"-Infinity"`)) {
+		*n = Numeric{InfinityModifier: NegativeInfinity, Valid: true}
+		return nil
 	}
 	return scanPlanTextAnyToNumericScanner{}.Scan(src, n)
 }
@@ -287,6 +592,8 @@ func (NumericCodec) PlanEncode(m *Map, oid uint32, format int16, value any) Enco
 	switch format {
 	case BinaryFormatCode:
 		switch value.(type) {
+		case *big.Int, *big.Float:
+			return encodePlanNumericCodecBig{next: encodePlanNumericCodecBinaryNumericValuer{}}
 		case NumericValuer:
 			return encodePlanNumericCodecBinaryNumericValuer{}
 		case Float64Valuer:
@@ -296,6 +603,8 @@ func (NumericCodec) PlanEncode(m *Map, oid uint32, format int16, value any) Enco
 		}
 	case TextFormatCode:
 		switch value.(type) {
+		case *big.Int, *big.Float:
+			return encodePlanNumericCodecBig{next: encodePlanNumericCodecTextNumericValuer{}}
 		case NumericValuer:
 			return encodePlanNumericCodecTextNumericValuer{}
 		case Float64Valuer:
@@ -308,6 +617,25 @@ func (NumericCodec) PlanEncode(m *Map, oid uint32, format int16, value any) Enco
 	return nil
 }
 
+// encodePlanNumericCodecBig encodes a *big.Int or *big.Float by converting it to a Numeric.
+type encodePlanNumericCodecBig struct {
+	next EncodePlan
+}
+
+func (plan encodePlanNumericCodecBig) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	var n Numeric
+	switch value := value.(type) {
+	case *big.Int:
+		n.SetBigInt(value)
+	case *big.Float:
+		if err := n.SetBigFloat(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan.next.Encode(n, buf)
+}
+
 type encodePlanNumericCodecBinaryNumericValuer struct{}
 
 func (encodePlanNumericCodecBinaryNumericValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
@@ -539,6 +867,8 @@ func (NumericCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanP
 		switch target.(type) {
 		case NumericScanner:
 			return scanPlanBinaryNumericToNumericScanner{}
+		case *big.Int, *big.Float:
+			return scanPlanNumericToBig{next: scanPlanBinaryNumericToNumericScanner{}}
 		case Float64Scanner:
 			return scanPlanBinaryNumericToFloat64Scanner{}
 		case Int64Scanner:
@@ -550,6 +880,8 @@ func (NumericCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanP
 		switch target.(type) {
 		case NumericScanner:
 			return scanPlanTextAnyToNumericScanner{}
+		case *big.Int, *big.Float:
+			return scanPlanNumericToBig{next: scanPlanTextAnyToNumericScanner{}}
 		case Float64Scanner:
 			return scanPlanTextAnyToFloat64Scanner{}
 		case Int64Scanner:
@@ -666,6 +998,40 @@ func (scanPlanBinaryNumericToNumericScanner) Scan(src []byte, dst any) error {
 	return scanner.ScanNumeric(Numeric{Int: accum, Exp: exp, Valid: true})
 }
 
+// scanPlanNumericToBig scans into a *big.Int or *big.Float by decoding a Numeric with next.
+type scanPlanNumericToBig struct {
+	next ScanPlan
+}
+
+func (plan scanPlanNumericToBig) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+
+	var n Numeric
+	err := plan.next.Scan(src, &n)
+	if err != nil {
+		return err
+	}
+
+	switch dst := dst.(type) {
+	case *big.Int:
+		bi, err := n.BigInt()
+		if err != nil {
+			return err
+		}
+		dst.Set(bi)
+	case *big.Float:
+		f, err := n.BigFloat()
+		if err != nil {
+			return err
+		}
+		dst.SetPrec(f.Prec()).Set(f)
+	}
+
+	return nil
+}
+
 type scanPlanBinaryNumericToFloat64Scanner struct{}
 
 func (scanPlanBinaryNumericToFloat64Scanner) Scan(src []byte, dst any) error {
@@ -169,6 +169,248 @@ func TestNumericFloat64Valuer(t *testing.T) {
 	assert.True(t, f.Valid)
 }
 
+func TestNumericFloat64Exact(t *testing.T) {
+	for i, tt := range []struct {
+		n     pgtype.Numeric
+		f     float64
+		exact bool
+	}{
+		{mustParseNumeric(t, "1"), 1, true},
+		{mustParseNumeric(t, "0.5"), 0.5, true},
+		{mustParseNumeric(t, "-1234.25"), -1234.25, true},
+		{mustParseNumeric(t, "0.1"), 0.1, false},
+		{mustParseNumeric(t, "9007199254740993"), 9007199254740992, false},
+		{pgtype.Numeric{Int: big.NewInt(1), Exp: 400, Valid: true}, 0, false},
+		{pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, math.Inf(1), true},
+		{pgtype.Numeric{Valid: true}, 0, true},
+		{pgtype.Numeric{}, 0, false},
+	} {
+		f, exact := tt.n.Float64Exact()
+		assert.Equalf(t, tt.f, f, "%d", i)
+		assert.Equalf(t, tt.exact, exact, "%d", i)
+	}
+
+	f, exact := pgtype.Numeric{NaN: true, Valid: true}.Float64Exact()
+	assert.True(t, math.IsNaN(f))
+	assert.True(t, exact)
+}
+
+func TestNumericInt64Exact(t *testing.T) {
+	for i, tt := range []struct {
+		n  pgtype.Numeric
+		i  int64
+		ok bool
+	}{
+		{mustParseNumeric(t, "1"), 1, true},
+		{mustParseNumeric(t, "-42.000"), -42, true},
+		{pgtype.Numeric{Int: big.NewInt(12), Exp: 3, Valid: true}, 12000, true},
+		{mustParseNumeric(t, "9223372036854775807"), math.MaxInt64, true},
+		{mustParseNumeric(t, "9223372036854775808"), 0, false},
+		{mustParseNumeric(t, "1.5"), 0, false},
+		{pgtype.Numeric{NaN: true, Valid: true}, 0, false},
+		{pgtype.Numeric{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, 0, false},
+		{pgtype.Numeric{Valid: true}, 0, true},
+		{pgtype.Numeric{}, 0, false},
+	} {
+		n, ok := tt.n.Int64Exact()
+		assert.Equalf(t, tt.i, n, "%d", i)
+		assert.Equalf(t, tt.ok, ok, "%d", i)
+	}
+}
+
+func TestNumericRat(t *testing.T) {
+	for i, tt := range []struct {
+		n pgtype.Numeric
+		r *big.Rat
+	}{
+		{mustParseNumeric(t, "1"), big.NewRat(1, 1)},
+		{mustParseNumeric(t, "-42.125"), big.NewRat(-42125, 1000)},
+		{pgtype.Numeric{Int: big.NewInt(12), Exp: 3, Valid: true}, big.NewRat(12000, 1)},
+		{pgtype.Numeric{Valid: true}, new(big.Rat)},
+		{pgtype.Numeric{NaN: true, Valid: true}, nil},
+		{pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, nil},
+		{pgtype.Numeric{}, nil},
+	} {
+		r := tt.n.Rat()
+		if tt.r == nil {
+			assert.Nilf(t, r, "%d", i)
+		} else if assert.NotNilf(t, r, "%d", i) {
+			assert.Equalf(t, 0, tt.r.Cmp(r), "%d: %v", i, r)
+		}
+	}
+}
+
+func TestNumericSetRat(t *testing.T) {
+	for i, tt := range []struct {
+		r        *big.Rat
+		expected string
+	}{
+		{big.NewRat(1, 1), "1"},
+		{big.NewRat(-1, 8), "-0.125"},
+		{big.NewRat(1, 20), "0.05"},
+		{big.NewRat(123456789, 100), "1234567.89"},
+		{big.NewRat(0, 1), "0"},
+	} {
+		var n pgtype.Numeric
+		err := n.SetRat(tt.r)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Truef(t, n.Valid, "%d", i)
+		assert.Equalf(t, 0, tt.r.Cmp(n.Rat()), "%d", i)
+
+		buf, err := n.MarshalJSON()
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, string(buf), "%d", i)
+	}
+
+	var n pgtype.Numeric
+	err := n.SetRat(big.NewRat(1, 3))
+	require.Error(t, err)
+
+	err = n.SetRat(nil)
+	require.NoError(t, err)
+	assert.False(t, n.Valid)
+}
+
+func TestNumericSetRatRounded(t *testing.T) {
+	for i, tt := range []struct {
+		r        *big.Rat
+		scale    int32
+		expected string
+	}{
+		{big.NewRat(1, 3), 2, "0.33"},
+		{big.NewRat(2, 3), 2, "0.67"},
+		{big.NewRat(-2, 3), 2, "-0.67"},
+		{big.NewRat(1, 8), 2, "0.13"},
+		{big.NewRat(-1, 8), 2, "-0.13"},
+		{big.NewRat(1, 8), 5, "0.12500"},
+		{big.NewRat(1250, 1), -2, "1300"},
+		{big.NewRat(5, 1), 0, "5"},
+	} {
+		var n pgtype.Numeric
+		err := n.SetRatRounded(tt.r, tt.scale)
+		require.NoErrorf(t, err, "%d", i)
+		assert.Truef(t, n.Valid, "%d", i)
+
+		buf, err := n.MarshalJSON()
+		require.NoErrorf(t, err, "%d", i)
+		assert.Equalf(t, tt.expected, string(buf), "%d", i)
+	}
+
+	var n pgtype.Numeric
+	err := n.SetRatRounded(nil, 2)
+	require.NoError(t, err)
+	assert.False(t, n.Valid)
+
+	err = n.SetRatRounded(big.NewRat(1, 3), math.MinInt32)
+	require.ErrorContains(t, err, "out of range")
+}
+
+func TestNumericBigInt(t *testing.T) {
+	bi, err := mustParseNumeric(t, "123456789012345678901234567890").BigInt()
+	require.NoError(t, err)
+	assert.Equal(t, "123456789012345678901234567890", bi.String())
+
+	bi, err = mustParseNumeric(t, "-42.000").BigInt()
+	require.NoError(t, err)
+	assert.Equal(t, "-42", bi.String())
+
+	for i, n := range []pgtype.Numeric{mustParseNumeric(t, "1.5"), {NaN: true, Valid: true}, {InfinityModifier: pgtype.Infinity, Valid: true}, {}} {
+		_, err := n.BigInt()
+		assert.Errorf(t, err, "%d", i)
+	}
+
+	var n pgtype.Numeric
+	n.SetBigInt(mustParseBigInt(t, "-98765432109876543210"))
+	assert.Equal(t, pgtype.Numeric{Int: mustParseBigInt(t, "-98765432109876543210"), Valid: true}, n)
+
+	n.SetBigInt(nil)
+	assert.False(t, n.Valid)
+}
+
+func TestNumericBigFloat(t *testing.T) {
+	f, err := mustParseNumeric(t, "1234567890.0987654321").BigFloat()
+	require.NoError(t, err)
+	assert.Equal(t, "1234567890.0987654321", f.Text('f', 10))
+
+	f, err = mustParseNumeric(t, "0.1").BigFloat()
+	require.NoError(t, err)
+	assert.EqualValues(t, 53, f.Prec())
+	assert.Equal(t, "0.1", f.Text('g', -1))
+
+	f, err = pgtype.Numeric{InfinityModifier: pgtype.NegativeInfinity, Valid: true}.BigFloat()
+	require.NoError(t, err)
+	assert.True(t, f.IsInf())
+	assert.Equal(t, -1, f.Sign())
+
+	_, err = pgtype.Numeric{NaN: true, Valid: true}.BigFloat()
+	assert.Error(t, err)
+
+	var n pgtype.Numeric
+	err = n.SetBigFloat(big.NewFloat(-2.5e10))
+	require.NoError(t, err)
+	assert.Equal(t, 0, n.Rat().Cmp(big.NewRat(-25000000000, 1)))
+
+	err = n.SetBigFloat(big.NewFloat(0.1))
+	require.NoError(t, err)
+	assert.Equal(t, 0, n.Rat().Cmp(big.NewRat(1, 10)))
+
+	err = n.SetBigFloat(new(big.Float).SetInf(false))
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, n)
+
+	err = n.SetBigFloat(nil)
+	require.NoError(t, err)
+	assert.False(t, n.Valid)
+}
+
+func TestNumericCodecBig(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, "numeric", []pgxtest.ValueRoundTripTest{
+		{
+			mustParseBigInt(t, "123456789012345678901234567890123456789"),
+			new(big.Int),
+			func(v any) bool {
+				return v.(*big.Int).Cmp(mustParseBigInt(t, "123456789012345678901234567890123456789")) == 0
+			},
+		},
+		{
+			big.NewFloat(-1.25),
+			new(big.Float),
+			func(v any) bool { return v.(*big.Float).Cmp(big.NewFloat(-1.25)) == 0 },
+		},
+	})
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var bi big.Int
+		err := conn.QueryRow(ctx, "select 1.5::numeric").Scan(&bi)
+		require.Error(t, err)
+
+		err = conn.QueryRow(ctx, "select null::numeric").Scan(&bi)
+		require.Error(t, err)
+	})
+}
+
+func TestNumericCodecBigWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.NumericOID, format, mustParseBigInt(t, "-123456789012345678901234567890"), nil)
+		require.NoError(t, err)
+
+		var bi big.Int
+		err = m.Scan(pgtype.NumericOID, format, buf, &bi)
+		require.NoError(t, err)
+		assert.Equal(t, "-123456789012345678901234567890", bi.String())
+
+		buf, err = m.Encode(pgtype.NumericOID, format, big.NewFloat(3.75), nil)
+		require.NoError(t, err)
+
+		var f big.Float
+		err = m.Scan(pgtype.NumericOID, format, buf, &f)
+		require.NoError(t, err)
+		assert.Equal(t, 0, f.Cmp(big.NewFloat(3.75)))
+	}
+}
+
 func TestNumericCodecFuzz(t *testing.T) {
 	skipCockroachDB(t, "server formats numeric text format differently")
 
@@ -253,6 +495,18 @@ func TestNumericUnmarshalJSON(t *testing.T) {
 			src:     []byte(`"NaN"`),
 			wantErr: false,
 		},
+		{
+			name:    "Infinity",
+			want:    &pgtype.Numeric{Valid: true, InfinityModifier: pgtype.Infinity},
+			src:     []byte(`"Infinity"`),
+			wantErr: false,
+		},
+		{
+			name:    "-Infinity",
+			want:    &pgtype.Numeric{Valid: true, InfinityModifier: pgtype.NegativeInfinity},
+			src:     []byte(`"-Infinity"`),
+			wantErr: false,
+		},
 		{
 			name:    "0",
 			want:    &pgtype.Numeric{Valid: true, Int: big.NewInt(0)},
@@ -302,3 +556,66 @@ func TestNumericUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNumericSpecialValues(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, tt := range []struct {
+		n    pgtype.Numeric
+		text string
+		json string
+		f    float64
+	}{
+		{pgtype.Numeric{NaN: true, Valid: true}, "NaN", `"NaN"`, math.NaN()},
+		{pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, "Infinity", `"Infinity"`, math.Inf(1)},
+		{pgtype.Numeric{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, "-Infinity", `"-Infinity"`, math.Inf(-1)},
+	} {
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			buf, err := m.Encode(pgtype.NumericOID, format, tt.n, nil)
+			require.NoErrorf(t, err, "%s %d", tt.text, format)
+
+			var n pgtype.Numeric
+			err = m.Scan(pgtype.NumericOID, format, buf, &n)
+			require.NoErrorf(t, err, "%s %d", tt.text, format)
+			require.Equalf(t, tt.n, n, "%s %d", tt.text, format)
+
+			var f float64
+			err = m.Scan(pgtype.NumericOID, format, buf, &f)
+			require.NoErrorf(t, err, "%s %d", tt.text, format)
+			if math.IsNaN(tt.f) {
+				require.Truef(t, math.IsNaN(f), "%s %d", tt.text, format)
+			} else {
+				require.Equalf(t, tt.f, f, "%s %d", tt.text, format)
+			}
+
+			var s string
+			err = m.Scan(pgtype.NumericOID, format, buf, &s)
+			require.NoErrorf(t, err, "%s %d", tt.text, format)
+			require.Equalf(t, tt.text, s, "%s %d", tt.text, format)
+
+			var i int64
+			err = m.Scan(pgtype.NumericOID, format, buf, &i)
+			require.Errorf(t, err, "%s %d", tt.text, format)
+
+			floatBuf, err := m.Encode(pgtype.NumericOID, format, tt.f, nil)
+			require.NoErrorf(t, err, "%s %d", tt.text, format)
+			require.Equalf(t, buf, floatBuf, "%s %d", tt.text, format)
+		}
+
+		_, err := tt.n.Int64Value()
+		require.Errorf(t, err, "%s", tt.text)
+
+		buf, err := json.Marshal(tt.n)
+		require.NoErrorf(t, err, "%s", tt.text)
+		require.Equalf(t, tt.json, string(buf), "%s", tt.text)
+
+		var n pgtype.Numeric
+		err = json.Unmarshal(buf, &n)
+		require.NoErrorf(t, err, "%s", tt.text)
+		require.Equalf(t, tt.n, n, "%s", tt.text)
+
+		err = n.Scan(tt.text)
+		require.NoErrorf(t, err, "%s", tt.text)
+		require.Equalf(t, tt.n, n, "%s", tt.text)
+	}
+}
@@ -74,20 +74,40 @@ func (PathCodec) PreferredFormat() int16 {
 }
 
 func (PathCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
-	if _, ok := value.(PathValuer); !ok {
-		return nil
-	}
-
+	var plan EncodePlan
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanPathCodecBinary{}
+		plan = encodePlanPathCodecBinary{}
 	case TextFormatCode:
-		return encodePlanPathCodecText{}
+		plan = encodePlanPathCodecText{}
+	default:
+		return nil
+	}
+
+	switch value.(type) {
+	case PathValuer:
+		return plan
+	case []Vec2:
+		return &encodePlanPathCodecVec2Slice{next: plan}
 	}
 
 	return nil
 }
 
+// encodePlanPathCodecVec2Slice encodes a []Vec2 as an open path.
+type encodePlanPathCodecVec2Slice struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanPathCodecVec2Slice) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	points := value.([]Vec2)
+	if points == nil {
+		return nil, nil
+	}
+
+	return plan.next.Encode(Path{P: points, Valid: true}, buf)
+}
+
 type encodePlanPathCodecBinary struct{}
 
 func (encodePlanPathCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
@@ -154,20 +174,39 @@ func (encodePlanPathCodecText) Encode(value any, buf []byte) (newBuf []byte, err
 }
 
 func (PathCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
-
+	var plan ScanPlan
 	switch format {
 	case BinaryFormatCode:
-		switch target.(type) {
-		case PathScanner:
-			return scanPlanBinaryPathToPathScanner{}
-		}
+		plan = scanPlanBinaryPathToPathScanner{}
 	case TextFormatCode:
-		switch target.(type) {
-		case PathScanner:
-			return scanPlanTextAnyToPathScanner{}
-		}
+		plan = scanPlanTextAnyToPathScanner{}
+	default:
+		return nil
+	}
+
+	switch target.(type) {
+	case PathScanner:
+		return plan
+	case *[]Vec2:
+		return &scanPlanPathToVec2Slice{next: plan}
+	}
+
+	return nil
+}
+
+// scanPlanPathToVec2Slice scans the points of a path. Whether the path is closed is discarded.
+type scanPlanPathToVec2Slice struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanPathToVec2Slice) Scan(src []byte, dst any) error {
+	var path Path
+	err := plan.next.Scan(src, &path)
+	if err != nil {
+		return err
 	}
 
+	*(dst.(*[]Vec2)) = path.P
 	return nil
 }
 
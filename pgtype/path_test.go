@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func isExpectedEqPath(a any) func(any) bool {
@@ -70,7 +71,33 @@ func TestPathTranscode(t *testing.T) {
 				Valid:  true,
 			}),
 		},
+		{
+			[]pgtype.Vec2{{3.14, 1.678}, {7.1, 5.234}},
+			new([]pgtype.Vec2),
+			isExpectedEq([]pgtype.Vec2{{3.14, 1.678}, {7.1, 5.234}}),
+		},
 		{pgtype.Path{}, new(pgtype.Path), isExpectedEqPath(pgtype.Path{})},
 		{nil, new(pgtype.Path), isExpectedEqPath(pgtype.Path{})},
+		{nil, new([]pgtype.Vec2), isExpectedEq([]pgtype.Vec2(nil))},
 	})
 }
+
+func TestPathCodecVec2Slice(t *testing.T) {
+	m := pgtype.NewMap()
+	points := []pgtype.Vec2{{1, 2}, {3, 4}}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.PathOID, format, points, nil)
+		require.NoError(t, err)
+
+		var path pgtype.Path
+		err = m.Scan(pgtype.PathOID, format, buf, &path)
+		require.NoError(t, err)
+		require.Equal(t, pgtype.Path{P: points, Closed: false, Valid: true}, path)
+
+		var got []pgtype.Vec2
+		err = m.Scan(pgtype.PathOID, format, buf, &got)
+		require.NoError(t, err)
+		require.Equal(t, points, got)
+	}
+}
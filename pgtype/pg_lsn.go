@@ -0,0 +1,324 @@
+package pgtype
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/internal/pgio"
+)
+
+type PgLSNScanner interface {
+	ScanPgLSN(v PgLSN) error
+}
+
+type PgLSNValuer interface {
+	PgLSNValue() (PgLSN, error)
+}
+
+// PgLSN represents the PostgreSQL pg_lsn type. LSN is the byte position in the write-ahead log.
+//
+// A pg_lsn can also be scanned into or encoded from a uint64.
+type PgLSN struct {
+	LSN   uint64
+	Valid bool
+}
+
+// ParsePgLSN parses s in the X/Y format used by PostgreSQL such as 16/B374D848 where X and Y are the high and low 32
+// bits of the LSN in hexadecimal.
+func ParsePgLSN(s string) (PgLSN, error) {
+	hi, lo, found := strings.Cut(s, "/")
+	if !found {
+		return PgLSN{}, fmt.Errorf("invalid pg_lsn: %q", s)
+	}
+
+	h, err := strconv.ParseUint(hi, 16, 32)
+	if err != nil {
+		return PgLSN{}, fmt.Errorf("invalid pg_lsn: %q", s)
+	}
+
+	l, err := strconv.ParseUint(lo, 16, 32)
+	if err != nil {
+		return PgLSN{}, fmt.Errorf("invalid pg_lsn: %q", s)
+	}
+
+	return PgLSN{LSN: h<<32 | l, Valid: true}, nil
+}
+
+func (lsn *PgLSN) ScanPgLSN(v PgLSN) error {
+	*lsn = v
+	return nil
+}
+
+func (lsn PgLSN) PgLSNValue() (PgLSN, error) {
+	return lsn, nil
+}
+
+// String returns lsn in the X/Y format used by PostgreSQL. It returns an empty string if lsn is NULL.
+func (lsn PgLSN) String() string {
+	if !lsn.Valid {
+		return ""
+	}
+
+	return string(appendPgLSNText(nil, lsn.LSN))
+}
+
+// Cmp compares lsn and other and returns -1 if lsn is before other, 0 if they are equal, and +1 if lsn is after other.
+// NULL is before all other values.
+func (lsn PgLSN) Cmp(other PgLSN) int {
+	switch {
+	case lsn.Valid != other.Valid:
+		if lsn.Valid {
+			return 1
+		}
+		return -1
+	case lsn.LSN < other.LSN:
+		return -1
+	case lsn.LSN > other.LSN:
+		return 1
+	}
+
+	return 0
+}
+
+// Sub returns the number of bytes of write-ahead log from other to lsn as PostgreSQL's pg_wal_lsn_diff does. e.g. The
+// replication lag of a standby is the current LSN of the primary minus the replay LSN of the standby. The result is
+// negative if other is after lsn.
+func (lsn PgLSN) Sub(other PgLSN) int64 {
+	return int64(lsn.LSN - other.LSN)
+}
+
+// Scan implements the database/sql Scanner interface.
+func (lsn *PgLSN) Scan(src any) error {
+	if src == nil {
+		*lsn = PgLSN{}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case string:
+		v, err := ParsePgLSN(src)
+		if err != nil {
+			return err
+		}
+		*lsn = v
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (lsn PgLSN) Value() (driver.Value, error) {
+	if !lsn.Valid {
+		return nil, nil
+	}
+
+	return lsn.String(), nil
+}
+
+func appendPgLSNText(buf []byte, lsn uint64) []byte {
+	return fmt.Appendf(buf, "%X/%X", lsn>>32, uint32(lsn))
+}
+
+// PgLSNCodec is the codec for the PostgreSQL pg_lsn type. DecodeValue returns the X/Y text format as a string, as it
+// did before pg_lsn was registered by default, so Rows.Values and scanning into *any also return a string. Scan into a
+// PgLSN or uint64 to get the position as a number.
+type PgLSNCodec struct{}
+
+func (PgLSNCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (PgLSNCodec) PreferredFormat() int16 {
+	return BinaryFormatCode
+}
+
+func (PgLSNCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	switch format {
+	case BinaryFormatCode:
+		switch value.(type) {
+		case uint64:
+			return encodePlanPgLSNCodecBinaryUint64{}
+		case PgLSNValuer:
+			return encodePlanPgLSNCodecBinaryPgLSNValuer{}
+		}
+	case TextFormatCode:
+		switch value.(type) {
+		case uint64:
+			return encodePlanPgLSNCodecTextUint64{}
+		case PgLSNValuer:
+			return encodePlanPgLSNCodecTextPgLSNValuer{}
+		}
+	}
+
+	return nil
+}
+
+type encodePlanPgLSNCodecBinaryUint64 struct{}
+
+func (encodePlanPgLSNCodecBinaryUint64) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return pgio.AppendUint64(buf, value.(uint64)), nil
+}
+
+type encodePlanPgLSNCodecBinaryPgLSNValuer struct{}
+
+func (encodePlanPgLSNCodecBinaryPgLSNValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	lsn, err := value.(PgLSNValuer).PgLSNValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !lsn.Valid {
+		return nil, nil
+	}
+
+	return pgio.AppendUint64(buf, lsn.LSN), nil
+}
+
+type encodePlanPgLSNCodecTextUint64 struct{}
+
+func (encodePlanPgLSNCodecTextUint64) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return appendPgLSNText(buf, value.(uint64)), nil
+}
+
+type encodePlanPgLSNCodecTextPgLSNValuer struct{}
+
+func (encodePlanPgLSNCodecTextPgLSNValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	lsn, err := value.(PgLSNValuer).PgLSNValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !lsn.Valid {
+		return nil, nil
+	}
+
+	return appendPgLSNText(buf, lsn.LSN), nil
+}
+
+func (PgLSNCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	switch format {
+	case BinaryFormatCode:
+		switch target.(type) {
+		case *uint64:
+			return scanPlanBinaryPgLSNToUint64{}
+		case PgLSNScanner:
+			return scanPlanBinaryPgLSNToPgLSNScanner{}
+		case TextScanner:
+			return scanPlanBinaryPgLSNToTextScanner{}
+		}
+	case TextFormatCode:
+		switch target.(type) {
+		case *uint64:
+			return scanPlanTextAnyToPgLSNUint64{}
+		case PgLSNScanner:
+			return scanPlanTextAnyToPgLSNScanner{}
+		}
+	}
+
+	return nil
+}
+
+type scanPlanBinaryPgLSNToUint64 struct{}
+
+func (scanPlanBinaryPgLSNToUint64) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for pg_lsn: %v", len(src))
+	}
+
+	p := (dst).(*uint64)
+	*p = binary.BigEndian.Uint64(src)
+
+	return nil
+}
+
+type scanPlanBinaryPgLSNToPgLSNScanner struct{}
+
+func (scanPlanBinaryPgLSNToPgLSNScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(PgLSNScanner)
+
+	if src == nil {
+		return scanner.ScanPgLSN(PgLSN{})
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for pg_lsn: %v", len(src))
+	}
+
+	return scanner.ScanPgLSN(PgLSN{LSN: binary.BigEndian.Uint64(src), Valid: true})
+}
+
+type scanPlanBinaryPgLSNToTextScanner struct{}
+
+func (scanPlanBinaryPgLSNToTextScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TextScanner)
+
+	if src == nil {
+		return scanner.ScanText(Text{})
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for pg_lsn: %v", len(src))
+	}
+
+	return scanner.ScanText(Text{String: string(appendPgLSNText(nil, binary.BigEndian.Uint64(src))), Valid: true})
+}
+
+type scanPlanTextAnyToPgLSNUint64 struct{}
+
+func (scanPlanTextAnyToPgLSNUint64) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+
+	lsn, err := ParsePgLSN(string(src))
+	if err != nil {
+		return err
+	}
+
+	p := (dst).(*uint64)
+	*p = lsn.LSN
+
+	return nil
+}
+
+type scanPlanTextAnyToPgLSNScanner struct{}
+
+func (scanPlanTextAnyToPgLSNScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(PgLSNScanner)
+
+	if src == nil {
+		return scanner.ScanPgLSN(PgLSN{})
+	}
+
+	lsn, err := ParsePgLSN(string(src))
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanPgLSN(lsn)
+}
+
+func (c PgLSNCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return c.DecodeValue(m, oid, format, src)
+}
+
+func (c PgLSNCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var lsn PgLSN
+	err := codecScan(c, m, oid, format, src, &lsn)
+	if err != nil {
+		return nil, err
+	}
+	return lsn.String(), nil
+}
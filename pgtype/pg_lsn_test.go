@@ -0,0 +1,96 @@
+package pgtype_test
+
+import (
+	"context"
+	"testing"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPgLSNCodec(t *testing.T) {
+	skipCockroachDB(t, "Server does not support pg_lsn")
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "pg_lsn", []pgxtest.ValueRoundTripTest{
+		{
+			pgtype.PgLSN{LSN: 0x16B374D848, Valid: true},
+			new(pgtype.PgLSN),
+			isExpectedEq(pgtype.PgLSN{LSN: 0x16B374D848, Valid: true}),
+		},
+		{pgtype.PgLSN{LSN: 0, Valid: true}, new(pgtype.PgLSN), isExpectedEq(pgtype.PgLSN{LSN: 0, Valid: true})},
+		{pgtype.PgLSN{LSN: 0xFFFFFFFFFFFFFFFF, Valid: true}, new(pgtype.PgLSN), isExpectedEq(pgtype.PgLSN{LSN: 0xFFFFFFFFFFFFFFFF, Valid: true})},
+		{pgtype.PgLSN{}, new(pgtype.PgLSN), isExpectedEq(pgtype.PgLSN{})},
+		{nil, new(pgtype.PgLSN), isExpectedEq(pgtype.PgLSN{})},
+		{"16/B374D848", new(string), isExpectedEq("16/B374D848")},
+	})
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, "pg_lsn", []pgxtest.ValueRoundTripTest{
+		{uint64(0x16B374D848), new(uint64), isExpectedEq(uint64(0x16B374D848))},
+	})
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var diff int64
+		err := conn.QueryRow(ctx, "select pg_wal_lsn_diff('16/B374D848', '16/B3740000')::int8").Scan(&diff)
+		require.NoError(t, err)
+
+		a, err := pgtype.ParsePgLSN("16/B374D848")
+		require.NoError(t, err)
+		b, err := pgtype.ParsePgLSN("16/B3740000")
+		require.NoError(t, err)
+		require.Equal(t, diff, a.Sub(b))
+	})
+}
+
+func TestPgLSN(t *testing.T) {
+	lsn, err := pgtype.ParsePgLSN("16/B374D848")
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.PgLSN{LSN: 0x16B374D848, Valid: true}, lsn)
+	assert.Equal(t, "16/B374D848", lsn.String())
+	assert.Equal(t, "0/0", pgtype.PgLSN{Valid: true}.String())
+	assert.Equal(t, "", pgtype.PgLSN{}.String())
+
+	for _, s := range []string{"", "16", "16/", "/B374D848", "16/B374D848/0", "G/0", "100000000/0"} {
+		_, err := pgtype.ParsePgLSN(s)
+		assert.Errorf(t, err, "%q", s)
+	}
+
+	earlier := pgtype.PgLSN{LSN: 100, Valid: true}
+	later := pgtype.PgLSN{LSN: 250, Valid: true}
+	assert.Equal(t, -1, earlier.Cmp(later))
+	assert.Equal(t, 1, later.Cmp(earlier))
+	assert.Equal(t, 0, later.Cmp(later))
+	assert.Equal(t, -1, pgtype.PgLSN{}.Cmp(earlier))
+	assert.Equal(t, int64(150), later.Sub(earlier))
+	assert.Equal(t, int64(-150), earlier.Sub(later))
+
+	m := pgtype.NewMap()
+	var scanned pgtype.PgLSN
+	err = m.Scan(pgtype.PgLSNOID, pgtype.TextFormatCode, []byte("A/1"), &scanned)
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.PgLSN{LSN: 0xA00000001, Valid: true}, scanned)
+
+	buf, err := m.Encode(pgtype.PgLSNOID, pgtype.BinaryFormatCode, scanned, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0xA, 0, 0, 0, 1}, buf)
+
+	// pg_lsn decodes to a string as it did before it was registered by default.
+	dt, ok := m.TypeForOID(pgtype.PgLSNOID)
+	require.True(t, ok)
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		src := []byte("A/1")
+		if format == pgtype.BinaryFormatCode {
+			src = buf
+		}
+
+		v, err := dt.Codec.DecodeValue(m, pgtype.PgLSNOID, format, src)
+		require.NoError(t, err)
+		assert.Equal(t, "A/1", v)
+
+		dv, err := dt.Codec.DecodeDatabaseSQLValue(m, pgtype.PgLSNOID, format, src)
+		require.NoError(t, err)
+		assert.Equal(t, "A/1", dv)
+	}
+}
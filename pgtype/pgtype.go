@@ -3,6 +3,7 @@ package pgtype
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
 	"errors"
 	"fmt"
 	"net"
@@ -26,6 +27,8 @@ const (
 	XIDOID                 = 28
 	CIDOID                 = 29
 	JSONOID                = 114
+	XMLOID                 = 142
+	XMLArrayOID            = 143
 	JSONArrayOID           = 199
 	PointOID               = 600
 	LsegOID                = 601
@@ -41,6 +44,10 @@ const (
 	CircleOID              = 718
 	CircleArrayOID         = 719
 	UnknownOID             = 705
+	Macaddr8OID            = 774
+	Macaddr8ArrayOID       = 775
+	MoneyOID               = 790
+	MoneyArrayOID          = 791
 	MacaddrOID             = 829
 	InetOID                = 869
 	BoolArrayOID           = 1000
@@ -90,6 +97,12 @@ const (
 	RecordArrayOID         = 2287
 	UUIDOID                = 2950
 	UUIDArrayOID           = 2951
+	PgLSNOID               = 3220
+	PgLSNArrayOID          = 3221
+	TsvectorOID            = 3614
+	TsqueryOID             = 3615
+	TsvectorArrayOID       = 3643
+	TsqueryArrayOID        = 3645
 	JSONBOID               = 3802
 	JSONBArrayOID          = 3807
 	DaterangeOID           = 3912
@@ -227,6 +240,7 @@ func NewMap() *Map {
 			TryWrapDerefPointerEncodePlan,
 			TryWrapBuiltinTypeEncodePlan,
 			TryWrapFindUnderlyingTypeEncodePlan,
+			TryWrapBinaryMarshalerEncodePlan,
 			TryWrapStructEncodePlan,
 			TryWrapSliceEncodePlan,
 			TryWrapMultiDimSliceEncodePlan,
@@ -237,6 +251,7 @@ func NewMap() *Map {
 			TryPointerPointerScanPlan,
 			TryWrapBuiltinTypeScanPlan,
 			TryFindUnderlyingTypeScanPlan,
+			TryWrapBinaryUnmarshalerScanPlan,
 			TryWrapStructScanPlan,
 			TryWrapPtrSliceScanPlan,
 			TryWrapPtrMultiDimSliceScanPlan,
@@ -261,6 +276,14 @@ func (m *Map) RegisterType(t *Type) {
 	}
 }
 
+// SetPreferredFormat overrides the format code returned by FormatCodeForOID for oid. pgx uses it to choose the format
+// of parameters and results of type oid. e.g. SetPreferredFormat(NumericOID, TextFormatCode) receives numeric
+// values in the text format while other types continue to use the binary format. format must be supported by the
+// Codec registered for oid. Registering a type for oid with RegisterType discards the override.
+func (m *Map) SetPreferredFormat(oid uint32, format int16) {
+	m.oidToFormatCode[oid] = format
+}
+
 // RegisterDefaultPgType registers a mapping of a Go type to a PostgreSQL type name. Typically the data type to be
 // encoded or decoded is determined by the PostgreSQL OID. But if the OID of a value to be encoded or decoded is
 // unknown, this additional mapping will be used by TypeForValue to determine a suitable data type.
@@ -277,6 +300,45 @@ func (m *Map) RegisterDefaultPgType(value any, name string) {
 	}
 }
 
+// RegisterDefaultPgTypeVariants registers T, *T, and the slice, Array, and FlatArray variants of T with
+// RegisterDefaultPgType. T and *T are mapped to name and the array variants are mapped to "_" + name.
+//
+// This is useful for Go types that map to types whose OID differs between databases such as a domain. For example,
+// given a domain over text and type Email string, RegisterDefaultPgTypeVariants[Email](m, "text") allows Email values
+// to be encoded and scanned even though the domain's OID is not registered. The OID reported by the server is still
+// used on the wire.
+func RegisterDefaultPgTypeVariants[T any](m *Map, name string) {
+	arrayName := "_" + name
+
+	var value T
+	m.RegisterDefaultPgType(value, name)  // T
+	m.RegisterDefaultPgType(&value, name) // *T
+
+	var sliceT []T
+	m.RegisterDefaultPgType(sliceT, arrayName)  // []T
+	m.RegisterDefaultPgType(&sliceT, arrayName) // *[]T
+
+	var slicePtrT []*T
+	m.RegisterDefaultPgType(slicePtrT, arrayName)  // []*T
+	m.RegisterDefaultPgType(&slicePtrT, arrayName) // *[]*T
+
+	var arrayOfT Array[T]
+	m.RegisterDefaultPgType(arrayOfT, arrayName)  // Array[T]
+	m.RegisterDefaultPgType(&arrayOfT, arrayName) // *Array[T]
+
+	var arrayOfPtrT Array[*T]
+	m.RegisterDefaultPgType(arrayOfPtrT, arrayName)  // Array[*T]
+	m.RegisterDefaultPgType(&arrayOfPtrT, arrayName) // *Array[*T]
+
+	var flatArrayOfT FlatArray[T]
+	m.RegisterDefaultPgType(flatArrayOfT, arrayName)  // FlatArray[T]
+	m.RegisterDefaultPgType(&flatArrayOfT, arrayName) // *FlatArray[T]
+
+	var flatArrayOfPtrT FlatArray[*T]
+	m.RegisterDefaultPgType(flatArrayOfPtrT, arrayName)  // FlatArray[*T]
+	m.RegisterDefaultPgType(&flatArrayOfPtrT, arrayName) // *FlatArray[*T]
+}
+
 // TypeForOID returns the Type registered for the given OID. The returned Type must not be mutated.
 func (m *Map) TypeForOID(oid uint32) (*Type, bool) {
 	if dt, ok := m.oidToType[oid]; ok {
@@ -463,6 +525,56 @@ func (plan *scanPlanFail) Scan(src []byte, dst any) error {
 	return fmt.Errorf("cannot scan %s (OID %d) in %v format into %T", dataTypeName, plan.oid, format, dst)
 }
 
+// NullableScanner is implemented by wrapper types such as pgx.Null that record whether a value is NULL. A non-NULL
+// value is scanned into the target returned by NullableScanTarget with the plan for that target.
+type NullableScanner interface {
+	// NullableScanTarget returns the target a non-NULL value is scanned into.
+	NullableScanTarget() any
+
+	// SetNullableValid is called after a value is scanned. valid is false if the value was NULL.
+	SetNullableValid(valid bool)
+}
+
+// NullableValuer is implemented by wrapper types such as pgx.Null that can represent NULL. A valid value is encoded
+// with the plan for the wrapped value.
+type NullableValuer interface {
+	// NullableValue returns the wrapped value and whether it is non-NULL.
+	NullableValue() (value any, valid bool)
+}
+
+type scanPlanNullable struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanNullable) Scan(src []byte, dst any) error {
+	nullable := dst.(NullableScanner)
+	if src == nil {
+		nullable.SetNullableValid(false)
+		return nil
+	}
+
+	err := plan.next.Scan(src, nullable.NullableScanTarget())
+	if err != nil {
+		return err
+	}
+
+	nullable.SetNullableValid(true)
+	return nil
+}
+
+type encodePlanNullable struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanNullable) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	v, valid := value.(NullableValuer).NullableValue()
+	if !valid {
+		return nil, nil
+	}
+
+	return plan.next.Encode(v, buf)
+}
+
 // TryWrapScanPlanFunc is a function that tries to create a wrapper plan for target. If successful it returns a plan
 // that will convert the target passed to Scan and then call the next plan. nextTarget is target as it will be converted
 // by plan. It must be used to find another suitable ScanPlan. When it is found SetNext must be called on plan for it
@@ -859,6 +971,39 @@ func (plan *wrapByteSliceScanPlan) Scan(src []byte, dst any) error {
 	return plan.next.Scan(src, (*byteSliceWrapper)(dst.(*[]byte)))
 }
 
+// TryWrapBinaryUnmarshalerScanPlan tries to wrap an encoding.BinaryUnmarshaler target as a BytesScanner. This allows
+// a type that already has a binary serialization to be scanned from bytea without implementing a pgtype interface. It
+// runs after TryFindUnderlyingTypeScanPlan so a named string or []byte type is still scanned as its underlying type.
+func TryWrapBinaryUnmarshalerScanPlan(target any) (plan WrappedScanPlanNextSetter, nextDst any, ok bool) {
+	if unmarshaler, ok := target.(encoding.BinaryUnmarshaler); ok {
+		return &wrapBinaryUnmarshalerScanPlan{}, binaryUnmarshalerWrapper{unmarshaler}, true
+	}
+
+	return nil, nil, false
+}
+
+type wrapBinaryUnmarshalerScanPlan struct {
+	next ScanPlan
+}
+
+func (plan *wrapBinaryUnmarshalerScanPlan) SetNext(next ScanPlan) { plan.next = next }
+
+func (plan *wrapBinaryUnmarshalerScanPlan) Scan(src []byte, dst any) error {
+	return plan.next.Scan(src, binaryUnmarshalerWrapper{dst.(encoding.BinaryUnmarshaler)})
+}
+
+type binaryUnmarshalerWrapper struct {
+	unmarshaler encoding.BinaryUnmarshaler
+}
+
+func (w binaryUnmarshalerWrapper) ScanBytes(v []byte) error {
+	if v == nil {
+		return fmt.Errorf("cannot scan NULL into %T", w.unmarshaler)
+	}
+
+	return w.unmarshaler.UnmarshalBinary(v)
+}
+
 type pointerEmptyInterfaceScanPlan struct {
 	codec      Codec
 	m          *Map
@@ -878,7 +1023,10 @@ func (plan *pointerEmptyInterfaceScanPlan) Scan(src []byte, dst any) error {
 	return nil
 }
 
-// TryWrapStructPlan tries to wrap a struct with a wrapper that implements CompositeIndexGetter.
+// TryWrapStructScanPlan tries to wrap a pointer to a struct with a wrapper that implements CompositeIndexScanner. The
+// exported fields are scanned in order unless any of them has a pgx tag. In that case each composite field is scanned
+// into the field whose pgx tag matches its name or, for untagged fields, whose name matches case-insensitively. A pgx
+// tag of "-" ignores the field.
 func TryWrapStructScanPlan(target any) (plan WrappedScanPlanNextSetter, nextValue any, ok bool) {
 	targetValue := reflect.ValueOf(target)
 	if targetValue.Kind() != reflect.Ptr {
@@ -894,15 +1042,11 @@ func TryWrapStructScanPlan(target any) (plan WrappedScanPlanNextSetter, nextValu
 	targetElemType := targetElemValue.Type()
 
 	if targetElemType.Kind() == reflect.Struct {
-		exportedFields := getExportedFieldValues(targetElemValue)
-		if len(exportedFields) == 0 {
+		w := newPtrStructWrapper(target, targetElemValue)
+		if len(w.exportedFields) == 0 {
 			return nil, nil, false
 		}
 
-		w := ptrStructWrapper{
-			s:              target,
-			exportedFields: exportedFields,
-		}
 		return &wrapAnyPtrStructScanPlan{}, &w, true
 	}
 
@@ -916,10 +1060,7 @@ type wrapAnyPtrStructScanPlan struct {
 func (plan *wrapAnyPtrStructScanPlan) SetNext(next ScanPlan) { plan.next = next }
 
 func (plan *wrapAnyPtrStructScanPlan) Scan(src []byte, target any) error {
-	w := ptrStructWrapper{
-		s:              target,
-		exportedFields: getExportedFieldValues(reflect.ValueOf(target).Elem()),
-	}
+	w := newPtrStructWrapper(target, reflect.ValueOf(target).Elem())
 
 	return plan.next.Scan(src, &w)
 }
@@ -1062,6 +1203,20 @@ func (m *Map) planScan(oid uint32, formatCode int16, target any) ScanPlan {
 		return scanPlanAnyToUndecodedBytes{}
 	}
 
+	if _, ok := target.(*Undecoded); ok {
+		return scanPlanAnyToUndecoded{oid: oid, format: formatCode}
+	}
+
+	// This needs to happen before the Codec is tried. Otherwise, a Codec that accepts any target such as JSONCodec would
+	// scan into the wrapper itself.
+	if nullable, ok := target.(NullableScanner); ok {
+		if nextPlan := m.planScan(oid, formatCode, nullable.NullableScanTarget()); nextPlan != nil {
+			if _, failed := nextPlan.(*scanPlanFail); !failed {
+				return &scanPlanNullable{next: nextPlan}
+			}
+		}
+	}
+
 	switch formatCode {
 	case BinaryFormatCode:
 		switch target.(type) {
@@ -1072,6 +1227,11 @@ func (m *Map) planScan(oid uint32, formatCode int16, target any) ScanPlan {
 			}
 		}
 	case TextFormatCode:
+		if oid == NameOID {
+			// NameCodec removes the padding some servers send with name values.
+			break
+		}
+
 		switch target.(type) {
 		case *string:
 			return scanPlanString{}
@@ -1170,6 +1330,18 @@ func codecDecodeToTextFormat(codec Codec, m *Map, oid uint32, format int16, src
 	}
 }
 
+// textValidator is implemented by codecs that may reject some text values.
+type textValidator interface {
+	validatesText() bool
+}
+
+// codecValidatesText returns true if codec rejects some text values. Such codecs cannot use the generic text format
+// encode plans.
+func codecValidatesText(codec Codec) bool {
+	v, ok := codec.(textValidator)
+	return ok && v.validatesText()
+}
+
 // PlanEncode returns an Encode plan for encoding value into PostgreSQL format for oid and format. If no plan can be
 // found then nil is returned.
 func (m *Map) PlanEncode(oid uint32, format int16, value any) EncodePlan {
@@ -1191,7 +1363,21 @@ func (m *Map) PlanEncode(oid uint32, format int16, value any) EncodePlan {
 }
 
 func (m *Map) planEncode(oid uint32, format int16, value any) EncodePlan {
-	if format == TextFormatCode {
+	if _, ok := value.(Undecoded); ok {
+		return encodePlanUndecoded{oid: oid, format: format}
+	}
+
+	if nullable, ok := value.(NullableValuer); ok {
+		v, _ := nullable.NullableValue()
+		if nextPlan := m.PlanEncode(oid, format, v); nextPlan != nil {
+			return &encodePlanNullable{next: nextPlan}
+		}
+	}
+
+	dataType, oidFound := m.TypeForOID(oid)
+
+	// Codecs that validate text values must see them.
+	if format == TextFormatCode && !(oidFound && codecValidatesText(dataType.Codec)) {
 		switch value.(type) {
 		case string:
 			return encodePlanStringToAnyTextFormat{}
@@ -1201,7 +1387,7 @@ func (m *Map) planEncode(oid uint32, format int16, value any) EncodePlan {
 	}
 
 	var dt *Type
-	if dataType, ok := m.TypeForOID(oid); ok {
+	if oidFound {
 		dt = dataType
 	} else {
 		// If no type for the OID was found, then either it is unknowable (e.g. the simple protocol) or it is an
@@ -1702,6 +1888,39 @@ func (plan *wrapByteSliceEncodePlan) Encode(value any, buf []byte) (newBuf []byt
 	return plan.next.Encode(byteSliceWrapper(value.([]byte)), buf)
 }
 
+// TryWrapBinaryMarshalerEncodePlan tries to wrap an encoding.BinaryMarshaler value as a BytesValuer. This allows a
+// type that already has a binary serialization to be encoded as bytea without implementing a pgtype interface. It runs
+// after TryWrapFindUnderlyingTypeEncodePlan so a named string or []byte type is still encoded as its underlying type.
+func TryWrapBinaryMarshalerEncodePlan(value any) (plan WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	if _, ok := value.(driver.Valuer); ok {
+		return nil, nil, false
+	}
+
+	if marshaler, ok := value.(encoding.BinaryMarshaler); ok {
+		return &wrapBinaryMarshalerEncodePlan{}, binaryMarshalerWrapper{marshaler}, true
+	}
+
+	return nil, nil, false
+}
+
+type wrapBinaryMarshalerEncodePlan struct {
+	next EncodePlan
+}
+
+func (plan *wrapBinaryMarshalerEncodePlan) SetNext(next EncodePlan) { plan.next = next }
+
+func (plan *wrapBinaryMarshalerEncodePlan) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return plan.next.Encode(binaryMarshalerWrapper{value.(encoding.BinaryMarshaler)}, buf)
+}
+
+type binaryMarshalerWrapper struct {
+	marshaler encoding.BinaryMarshaler
+}
+
+func (w binaryMarshalerWrapper) BytesValue() ([]byte, error) {
+	return w.marshaler.MarshalBinary()
+}
+
 type wrapFmtStringerEncodePlan struct {
 	next EncodePlan
 }
@@ -1712,22 +1931,19 @@ func (plan *wrapFmtStringerEncodePlan) Encode(value any, buf []byte) (newBuf []b
 	return plan.next.Encode(fmtStringerWrapper{value.(fmt.Stringer)}, buf)
 }
 
-// TryWrapStructPlan tries to wrap a struct with a wrapper that implements CompositeIndexGetter.
+// TryWrapStructEncodePlan tries to wrap a struct with a wrapper that implements CompositeIndexGetter. Fields are mapped
+// to composite fields as in TryWrapStructScanPlan.
 func TryWrapStructEncodePlan(value any) (plan WrappedEncodePlanNextSetter, nextValue any, ok bool) {
 	if _, ok := value.(driver.Valuer); ok {
 		return nil, nil, false
 	}
 
 	if valueType := reflect.TypeOf(value); valueType != nil && valueType.Kind() == reflect.Struct {
-		exportedFields := getExportedFieldValues(reflect.ValueOf(value))
-		if len(exportedFields) == 0 {
+		w := newStructWrapper(value, reflect.ValueOf(value))
+		if len(w.exportedFields) == 0 {
 			return nil, nil, false
 		}
 
-		w := structWrapper{
-			s:              value,
-			exportedFields: exportedFields,
-		}
 		return &wrapAnyStructEncodePlan{}, w, true
 	}
 
@@ -1741,10 +1957,7 @@ type wrapAnyStructEncodePlan struct {
 func (plan *wrapAnyStructEncodePlan) SetNext(next EncodePlan) { plan.next = next }
 
 func (plan *wrapAnyStructEncodePlan) Encode(value any, buf []byte) (newBuf []byte, err error) {
-	w := structWrapper{
-		s:              value,
-		exportedFields: getExportedFieldValues(reflect.ValueOf(value)),
-	}
+	w := newStructWrapper(value, reflect.ValueOf(value))
 
 	return plan.next.Encode(w, buf)
 }
@@ -1959,7 +2172,7 @@ func (w *sqlScannerWrapper) Scan(src any) error {
 		case []byte:
 			bufSrc = src
 		default:
-			bufSrc = []byte(fmt.Sprint(bufSrc))
+			bufSrc = []byte(fmt.Sprint(src))
 		}
 	}
 
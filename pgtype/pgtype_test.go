@@ -7,11 +7,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"net"
 	"os"
 	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -427,6 +430,64 @@ func TestMapEncodeNamedTypeOfByteSliceIntoTextTextFormat(t *testing.T) {
 }
 
 // https://github.com/jackc/pgx/issues/1326
+type accountID struct {
+	id int64
+}
+
+func (a accountID) Int64Value() (pgtype.Int8, error) {
+	return pgtype.Int8{Int64: a.id, Valid: true}, nil
+}
+
+func (a *accountID) ScanInt64(v pgtype.Int8) error {
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into *accountID")
+	}
+	a.id = v.Int64
+	return nil
+}
+
+func TestRegisterDefaultPgTypeVariants(t *testing.T) {
+	// An unregistered OID such as a domain over int8.
+	const domainOID = 999999
+
+	m := pgtype.NewMap()
+
+	_, err := m.Encode(domainOID, pgtype.BinaryFormatCode, accountID{id: 42}, nil)
+	require.Error(t, err)
+
+	pgtype.RegisterDefaultPgTypeVariants[accountID](m, "int8")
+
+	buf, err := m.Encode(domainOID, pgtype.BinaryFormatCode, accountID{id: 42}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 42}, buf)
+
+	var id accountID
+	err = m.Scan(domainOID, pgtype.BinaryFormatCode, buf, &id)
+	require.NoError(t, err)
+	require.Equal(t, accountID{id: 42}, id)
+
+	buf, err = m.Encode(pgtype.Int8ArrayOID, pgtype.BinaryFormatCode, []accountID{{id: 1}, {id: 2}}, nil)
+	require.NoError(t, err)
+
+	var ids []accountID
+	err = m.Scan(pgtype.Int8ArrayOID, pgtype.BinaryFormatCode, buf, &ids)
+	require.NoError(t, err)
+	require.Equal(t, []accountID{{id: 1}, {id: 2}}, ids)
+}
+
+func TestMapSetPreferredFormat(t *testing.T) {
+	m := pgtype.NewMap()
+	require.Equal(t, int16(pgtype.BinaryFormatCode), m.FormatCodeForOID(pgtype.NumericOID))
+
+	m.SetPreferredFormat(pgtype.NumericOID, pgtype.TextFormatCode)
+	require.Equal(t, int16(pgtype.TextFormatCode), m.FormatCodeForOID(pgtype.NumericOID))
+	require.Equal(t, int16(pgtype.BinaryFormatCode), m.FormatCodeForOID(pgtype.Int4OID))
+	require.Equal(t, int16(pgtype.BinaryFormatCode), pgtype.NewMap().FormatCodeForOID(pgtype.NumericOID))
+
+	m.RegisterType(&pgtype.Type{Name: "numeric", OID: pgtype.NumericOID, Codec: pgtype.NumericCodec{}})
+	require.Equal(t, int16(pgtype.BinaryFormatCode), m.FormatCodeForOID(pgtype.NumericOID))
+}
+
 func TestMapScanPointerToRenamedType(t *testing.T) {
 	srcBuf := []byte("foo")
 	m := pgtype.NewMap()
@@ -614,3 +675,73 @@ func isExpectedEq(a any) func(any) bool {
 		return a == v
 	}
 }
+
+func TestValueKey(t *testing.T) {
+	type keyer interface {
+		Key() string
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	for i, tt := range []struct {
+		a, b  keyer
+		equal bool
+	}{
+		{pgtype.Int4{Int32: 1, Valid: true}, pgtype.Int4{Int32: 1, Valid: true}, true},
+		{pgtype.Int4{Int32: 1, Valid: true}, pgtype.Int4{Int32: 2, Valid: true}, false},
+		{pgtype.Int4{Int32: 0, Valid: true}, pgtype.Int4{}, false},
+		{pgtype.Int4{Int32: 7}, pgtype.Int4{}, true},
+		{pgtype.Int2{Int16: -3, Valid: true}, pgtype.Int2{Int16: -3, Valid: true}, true},
+		{pgtype.Int8{Int64: math.MaxInt64, Valid: true}, pgtype.Int8{Int64: math.MaxInt64, Valid: true}, true},
+		{pgtype.Bool{Bool: true, Valid: true}, pgtype.Bool{Bool: false, Valid: true}, false},
+		{pgtype.Bool{Bool: false, Valid: true}, pgtype.Bool{}, false},
+		{pgtype.Float8{Float64: 0, Valid: true}, pgtype.Float8{Float64: math.Copysign(0, -1), Valid: true}, true},
+		{pgtype.Float8{Float64: math.NaN(), Valid: true}, pgtype.Float8{Float64: math.NaN(), Valid: true}, true},
+		{pgtype.Float4{Float32: 1.5, Valid: true}, pgtype.Float4{Float32: 1.25, Valid: true}, false},
+		{pgtype.Text{String: "", Valid: true}, pgtype.Text{}, false},
+		{pgtype.Text{String: "foo", Valid: true}, pgtype.Text{String: "foo", Valid: true}, true},
+		{pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, true},
+		{pgtype.UUID{Bytes: [16]byte{1}}, pgtype.UUID{}, true},
+		{pgtype.UUID{Valid: true}, pgtype.UUID{}, false},
+		{pgtype.Numeric{Int: big.NewInt(150), Exp: -2, Valid: true}, pgtype.Numeric{Int: big.NewInt(15), Exp: -1, Valid: true}, true},
+		{pgtype.Numeric{Int: big.NewInt(1), Exp: 3, Valid: true}, pgtype.Numeric{Int: big.NewInt(1000), Exp: 0, Valid: true}, true},
+		{pgtype.Numeric{Int: big.NewInt(1), Exp: 3, Valid: true}, pgtype.Numeric{Int: big.NewInt(1), Exp: 2, Valid: true}, false},
+		{pgtype.Numeric{Int: big.NewInt(0), Exp: 5, Valid: true}, pgtype.Numeric{Valid: true}, true},
+		{pgtype.Numeric{NaN: true, Valid: true}, pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, false},
+		{pgtype.Numeric{Int: big.NewInt(0), Valid: true}, pgtype.Numeric{}, false},
+		{
+			pgtype.Date{Time: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+			pgtype.Date{Time: time.Date(2020, 1, 2, 0, 0, 0, 0, newYork), Valid: true},
+			true,
+		},
+		{pgtype.Date{InfinityModifier: pgtype.Infinity, Valid: true}, pgtype.Date{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, false},
+		{
+			pgtype.Timestamptz{Time: time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC), Valid: true},
+			pgtype.Timestamptz{Time: time.Date(2020, 1, 2, 3, 0, 0, 0, newYork), Valid: true},
+			true,
+		},
+		{
+			pgtype.Timestamp{Time: time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC), Valid: true},
+			pgtype.Timestamp{Time: time.Date(2020, 1, 2, 3, 0, 0, 0, newYork), Valid: true},
+			false,
+		},
+		{
+			pgtype.Timestamp{Time: time.Date(2020, 1, 2, 8, 0, 0, 0, time.UTC), Valid: true},
+			pgtype.Timestamp{Time: time.Date(2020, 1, 2, 8, 0, 0, 0, newYork), Valid: true},
+			true,
+		},
+	} {
+		if tt.equal {
+			assert.Equalf(t, tt.a.Key(), tt.b.Key(), "%d", i)
+		} else {
+			assert.NotEqualf(t, tt.a.Key(), tt.b.Key(), "%d", i)
+		}
+	}
+
+	groups := map[string]int{}
+	for _, v := range []pgtype.Int4{{Int32: 1, Valid: true}, {}, {Int32: 1, Valid: true}, {Int32: 5}} {
+		groups[v.Key()]++
+	}
+	assert.Equal(t, map[string]int{"1": 2, "": 2}, groups)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -128,20 +129,39 @@ func (PointCodec) PreferredFormat() int16 {
 }
 
 func (PointCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
-	if _, ok := value.(PointValuer); !ok {
-		return nil
-	}
-
+	var plan EncodePlan
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanPointCodecBinary{}
+		plan = encodePlanPointCodecBinary{}
 	case TextFormatCode:
-		return encodePlanPointCodecText{}
+		plan = encodePlanPointCodecText{}
+	default:
+		return nil
+	}
+
+	if _, ok := value.(PointValuer); ok {
+		return plan
+	}
+
+	// Structs with the same fields as Vec2 such as struct{ X, Y float64 } can be used as a point.
+	if value != nil && reflect.TypeOf(value).ConvertibleTo(vec2Type) && reflect.TypeOf(value).Kind() == reflect.Struct {
+		return &encodePlanPointCodecVec2Convertible{next: plan}
 	}
 
 	return nil
 }
 
+var vec2Type = reflect.TypeOf(Vec2{})
+
+type encodePlanPointCodecVec2Convertible struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanPointCodecVec2Convertible) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	v := reflect.ValueOf(value).Convert(vec2Type).Interface().(Vec2)
+	return plan.next.Encode(Point{P: v, Valid: true}, buf)
+}
+
 type encodePlanPointCodecBinary struct{}
 
 func (encodePlanPointCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
@@ -178,23 +198,50 @@ func (encodePlanPointCodecText) Encode(value any, buf []byte) (newBuf []byte, er
 }
 
 func (PointCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
-
+	var plan ScanPlan
 	switch format {
 	case BinaryFormatCode:
-		switch target.(type) {
-		case PointScanner:
-			return scanPlanBinaryPointToPointScanner{}
-		}
+		plan = scanPlanBinaryPointToPointScanner{}
 	case TextFormatCode:
-		switch target.(type) {
-		case PointScanner:
-			return scanPlanTextAnyToPointScanner{}
+		plan = scanPlanTextAnyToPointScanner{}
+	default:
+		return nil
+	}
+
+	if _, ok := target.(PointScanner); ok {
+		return plan
+	}
+
+	// Pointers to structs with the same fields as Vec2 such as *struct{ X, Y float64 } can be scanned into.
+	if targetType := reflect.TypeOf(target); targetType != nil && targetType.Kind() == reflect.Pointer {
+		if elemType := targetType.Elem(); elemType.Kind() == reflect.Struct && vec2Type.ConvertibleTo(elemType) {
+			return &scanPlanPointToVec2Convertible{next: plan}
 		}
 	}
 
 	return nil
 }
 
+type scanPlanPointToVec2Convertible struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanPointToVec2Convertible) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dst)
+	}
+
+	var point Point
+	err := plan.next.Scan(src, &point)
+	if err != nil {
+		return err
+	}
+
+	dstValue := reflect.ValueOf(dst).Elem()
+	dstValue.Set(reflect.ValueOf(point.P).Convert(dstValue.Type()))
+	return nil
+}
+
 func (c PointCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
 	return codecDecodeToTextFormat(c, m, oid, format, src)
 }
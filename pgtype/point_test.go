@@ -24,11 +24,47 @@ func TestPointCodec(t *testing.T) {
 			new(pgtype.Point),
 			isExpectedEq(pgtype.Point{P: pgtype.Vec2{-1.234, -5.6789}, Valid: true}),
 		},
+		{
+			struct{ X, Y float64 }{1.5, -2.25},
+			new(struct{ X, Y float64 }),
+			isExpectedEq(struct{ X, Y float64 }{1.5, -2.25}),
+		},
 		{pgtype.Point{}, new(pgtype.Point), isExpectedEq(pgtype.Point{})},
 		{nil, new(pgtype.Point), isExpectedEq(pgtype.Point{})},
 	})
 }
 
+func TestPointCodecScanStruct(t *testing.T) {
+	type coordinates struct {
+		X float64
+		Y float64
+	}
+
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.PointOID, format, coordinates{X: 1.5, Y: -2.25}, nil)
+		require.NoError(t, err)
+
+		var c coordinates
+		err = m.Scan(pgtype.PointOID, format, buf, &c)
+		require.NoError(t, err)
+		require.Equal(t, coordinates{X: 1.5, Y: -2.25}, c)
+
+		var v pgtype.Vec2
+		err = m.Scan(pgtype.PointOID, format, buf, &v)
+		require.NoError(t, err)
+		require.Equal(t, pgtype.Vec2{X: 1.5, Y: -2.25}, v)
+
+		err = m.Scan(pgtype.PointOID, format, nil, &c)
+		require.Error(t, err)
+	}
+
+	var wrongFields struct{ A, B float64 }
+	err := m.Scan(pgtype.PointOID, pgtype.TextFormatCode, []byte("(1,2)"), &wrongFields)
+	require.Error(t, err)
+}
+
 func TestPoint_MarshalJSON(t *testing.T) {
 	tests := []struct {
 		name  string
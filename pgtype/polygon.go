@@ -73,20 +73,39 @@ func (PolygonCodec) PreferredFormat() int16 {
 }
 
 func (PolygonCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
-	if _, ok := value.(PolygonValuer); !ok {
-		return nil
-	}
-
+	var plan EncodePlan
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanPolygonCodecBinary{}
+		plan = encodePlanPolygonCodecBinary{}
 	case TextFormatCode:
-		return encodePlanPolygonCodecText{}
+		plan = encodePlanPolygonCodecText{}
+	default:
+		return nil
+	}
+
+	switch value.(type) {
+	case PolygonValuer:
+		return plan
+	case []Vec2:
+		return &encodePlanPolygonCodecVec2Slice{next: plan}
 	}
 
 	return nil
 }
 
+type encodePlanPolygonCodecVec2Slice struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanPolygonCodecVec2Slice) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	points := value.([]Vec2)
+	if points == nil {
+		return nil, nil
+	}
+
+	return plan.next.Encode(Polygon{P: points, Valid: true}, buf)
+}
+
 type encodePlanPolygonCodecBinary struct{}
 
 func (encodePlanPolygonCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
@@ -139,20 +158,38 @@ func (encodePlanPolygonCodecText) Encode(value any, buf []byte) (newBuf []byte,
 }
 
 func (PolygonCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
-
+	var plan ScanPlan
 	switch format {
 	case BinaryFormatCode:
-		switch target.(type) {
-		case PolygonScanner:
-			return scanPlanBinaryPolygonToPolygonScanner{}
-		}
+		plan = scanPlanBinaryPolygonToPolygonScanner{}
 	case TextFormatCode:
-		switch target.(type) {
-		case PolygonScanner:
-			return scanPlanTextAnyToPolygonScanner{}
-		}
+		plan = scanPlanTextAnyToPolygonScanner{}
+	default:
+		return nil
+	}
+
+	switch target.(type) {
+	case PolygonScanner:
+		return plan
+	case *[]Vec2:
+		return &scanPlanPolygonToVec2Slice{next: plan}
+	}
+
+	return nil
+}
+
+type scanPlanPolygonToVec2Slice struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanPolygonToVec2Slice) Scan(src []byte, dst any) error {
+	var polygon Polygon
+	err := plan.next.Scan(src, &polygon)
+	if err != nil {
+		return err
 	}
 
+	*(dst.(*[]Vec2)) = polygon.P
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
 )
 
 func isExpectedEqPolygon(a any) func(any) bool {
@@ -53,7 +54,32 @@ func TestPolygonTranscode(t *testing.T) {
 				Valid: true,
 			}),
 		},
+		{
+			[]pgtype.Vec2{{3.14, 1.678901234}, {7.1, 5.234}, {5.0, 3.234}},
+			new([]pgtype.Vec2),
+			isExpectedEq([]pgtype.Vec2{{3.14, 1.678901234}, {7.1, 5.234}, {5.0, 3.234}}),
+		},
 		{pgtype.Polygon{}, new(pgtype.Polygon), isExpectedEqPolygon(pgtype.Polygon{})},
 		{nil, new(pgtype.Polygon), isExpectedEqPolygon(pgtype.Polygon{})},
+		{nil, new([]pgtype.Vec2), isExpectedEq([]pgtype.Vec2(nil))},
 	})
 }
+
+func TestPolygonCodecVec2Slice(t *testing.T) {
+	m := pgtype.NewMap()
+	points := []pgtype.Vec2{{1, 2}, {3, 4}, {5, 6}}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.PolygonOID, format, points, nil)
+		require.NoError(t, err)
+
+		var got []pgtype.Vec2
+		err = m.Scan(pgtype.PolygonOID, format, buf, &got)
+		require.NoError(t, err)
+		require.Equal(t, points, got)
+
+		err = m.Scan(pgtype.PolygonOID, format, nil, &got)
+		require.NoError(t, err)
+		require.Nil(t, got)
+	}
+}
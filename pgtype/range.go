@@ -320,3 +320,28 @@ func (r *Range[T]) SetBoundTypes(lower, upper BoundType) error {
 	r.Valid = true
 	return nil
 }
+
+// LowerInclusive returns true if the lower bound is inclusive. It mirrors the PostgreSQL lower_inc function.
+func (r Range[T]) LowerInclusive() bool {
+	return r.LowerType == Inclusive
+}
+
+// UpperInclusive returns true if the upper bound is inclusive. It mirrors the PostgreSQL upper_inc function.
+func (r Range[T]) UpperInclusive() bool {
+	return r.UpperType == Inclusive
+}
+
+// LowerInf returns true if the range has no lower bound. It mirrors the PostgreSQL lower_inf function.
+func (r Range[T]) LowerInf() bool {
+	return r.LowerType == Unbounded
+}
+
+// UpperInf returns true if the range has no upper bound. It mirrors the PostgreSQL upper_inf function.
+func (r Range[T]) UpperInf() bool {
+	return r.UpperType == Unbounded
+}
+
+// IsEmpty returns true if the range is empty. It mirrors the PostgreSQL isempty function.
+func (r Range[T]) IsEmpty() bool {
+	return r.LowerType == Empty
+}
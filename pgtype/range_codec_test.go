@@ -3,6 +3,7 @@ package pgtype_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	pgx "github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -161,3 +162,44 @@ func TestRangeCodecDecodeValue(t *testing.T) {
 		}
 	})
 }
+
+func TestRangeCodecScanTstzrangeIntoRangeOfTime(t *testing.T) {
+	m := pgtype.NewMap()
+
+	lower := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	upper := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		src := pgtype.Range[time.Time]{Lower: lower, LowerType: pgtype.Inclusive, UpperType: pgtype.Unbounded, Valid: true}
+		buf, err := m.Encode(pgtype.TstzrangeOID, format, src, nil)
+		require.NoError(t, err)
+
+		var r pgtype.Range[time.Time]
+		err = m.Scan(pgtype.TstzrangeOID, format, buf, &r)
+		require.NoError(t, err)
+		require.True(t, r.Lower.Equal(lower))
+		require.True(t, r.LowerInclusive())
+		require.False(t, r.LowerInf())
+		require.False(t, r.UpperInclusive())
+		require.True(t, r.UpperInf())
+		require.False(t, r.IsEmpty())
+	}
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pgxtest.SkipCockroachDB(t, conn, "Server does not support range types (see https://github.com/cockroachdb/cockroach/issues/27791)")
+
+		var r pgtype.Range[time.Time]
+		err := conn.QueryRow(ctx, `select tstzrange($1, $2, '(]')`, lower, upper).Scan(&r)
+		require.NoError(t, err)
+		require.True(t, r.Lower.Equal(lower))
+		require.True(t, r.Upper.Equal(upper))
+		require.False(t, r.LowerInclusive())
+		require.True(t, r.UpperInclusive())
+		require.False(t, r.LowerInf())
+		require.False(t, r.UpperInf())
+
+		err = conn.QueryRow(ctx, `select 'empty'::tstzrange`).Scan(&r)
+		require.NoError(t, err)
+		require.True(t, r.IsEmpty())
+	})
+}
@@ -11,6 +11,10 @@ import (
 // only decode the binary format. The text format output format from PostgreSQL does not include type information and
 // is therefore impossible to decode. Encoding is impossible because PostgreSQL does not support input of generic
 // records.
+//
+// A record can be scanned into a CompositeIndexScanner such as CompositeFields when its fields are known in advance or
+// into a *[]any to decode each field with the type registered for its OID. The latter works for functions that return
+// an anonymous record without declaring a composite type.
 type RecordCodec struct{}
 
 func (RecordCodec) FormatSupported(format int16) bool {
@@ -30,6 +34,8 @@ func (RecordCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPl
 		switch target.(type) {
 		case CompositeIndexScanner:
 			return &scanPlanBinaryRecordToCompositeIndexScanner{m: m}
+		case *[]any:
+			return &scanPlanBinaryRecordToAnySlice{m: m}
 		}
 	}
 
@@ -70,6 +76,53 @@ func (plan *scanPlanBinaryRecordToCompositeIndexScanner) Scan(src []byte, target
 	return nil
 }
 
+type scanPlanBinaryRecordToAnySlice struct {
+	m *Map
+}
+
+func (plan *scanPlanBinaryRecordToAnySlice) Scan(src []byte, target any) error {
+	dst := target.(*[]any)
+
+	if src == nil {
+		*dst = nil
+		return nil
+	}
+
+	values, err := decodeBinaryRecord(plan.m, src)
+	if err != nil {
+		return err
+	}
+
+	*dst = values
+	return nil
+}
+
+// decodeBinaryRecord decodes each field of the binary record src with the type registered for the field's OID.
+func decodeBinaryRecord(m *Map, src []byte) ([]any, error) {
+	scanner := NewCompositeBinaryScanner(m, src)
+	values := make([]any, scanner.FieldCount())
+	for i := 0; scanner.Next(); i++ {
+		var v any
+		fieldPlan := m.PlanScan(scanner.OID(), BinaryFormatCode, &v)
+		if fieldPlan == nil {
+			return nil, fmt.Errorf("unable to scan OID %d in binary format into %v", scanner.OID(), v)
+		}
+
+		err := fieldPlan.Scan(scanner.Bytes(), &v)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = v
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
 func (RecordCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
 	if src == nil {
 		return nil, nil
@@ -96,30 +149,8 @@ func (RecordCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (an
 	case TextFormatCode:
 		return string(src), nil
 	case BinaryFormatCode:
-		scanner := NewCompositeBinaryScanner(m, src)
-		values := make([]any, scanner.FieldCount())
-		for i := 0; scanner.Next(); i++ {
-			var v any
-			fieldPlan := m.PlanScan(scanner.OID(), BinaryFormatCode, &v)
-			if fieldPlan == nil {
-				return nil, fmt.Errorf("unable to scan OID %d in binary format into %v", scanner.OID(), v)
-			}
-
-			err := fieldPlan.Scan(scanner.Bytes(), &v)
-			if err != nil {
-				return nil, err
-			}
-
-			values[i] = v
-		}
-
-		if err := scanner.Err(); err != nil {
-			return nil, err
-		}
-
-		return values, nil
+		return decodeBinaryRecord(m, src)
 	default:
 		return nil, fmt.Errorf("unknown format code %d", format)
 	}
-
 }
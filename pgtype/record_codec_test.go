@@ -21,6 +21,49 @@ func TestRecordCodec(t *testing.T) {
 	})
 }
 
+func TestRecordCodecScanAnySlice(t *testing.T) {
+	skipCockroachDB(t, "Server does not support functions returning record")
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Exec(ctx, `create function pg_temp.anonymous_record() returns record language plpgsql as $$
+declare
+	r record;
+begin
+	select 'foo'::text, 42::int4, null::int8 into r;
+	return r;
+end$$`)
+		require.NoError(t, err)
+
+		var fields []any
+		err = conn.QueryRow(ctx, `select pg_temp.anonymous_record()`).Scan(&fields)
+		require.NoError(t, err)
+		require.Equal(t, []any{"foo", int32(42), nil}, fields)
+
+		err = conn.QueryRow(ctx, `select null::record`).Scan(&fields)
+		require.NoError(t, err)
+		require.Nil(t, fields)
+	})
+}
+
+func TestRecordCodecScanAnySliceWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	b := pgtype.NewCompositeBinaryBuilder(m, nil)
+	b.AppendValue(pgtype.TextOID, "foo")
+	b.AppendValue(pgtype.Int4OID, int32(42))
+	b.AppendValue(pgtype.BoolOID, nil)
+	src, err := b.Finish()
+	require.NoError(t, err)
+
+	var fields []any
+	err = m.Scan(pgtype.RecordOID, pgtype.BinaryFormatCode, src, &fields)
+	require.NoError(t, err)
+	require.Equal(t, []any{"foo", int32(42), nil}, fields)
+
+	err = m.Scan(pgtype.RecordOID, pgtype.BinaryFormatCode, src[:len(src)-1], &fields)
+	require.Error(t, err)
+}
+
 func TestRecordCodecDecodeValue(t *testing.T) {
 	skipCockroachDB(t, "Server converts row int4 to int8")
 
@@ -55,6 +55,17 @@ func (src Text) Value() (driver.Value, error) {
 	return src.String, nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (t Text) Key() string {
+	if !t.Valid {
+		return ""
+	}
+
+	// The prefix distinguishes the empty string from NULL.
+	return "'" + t.String
+}
+
 func (src Text) MarshalJSON() ([]byte, error) {
 	if !src.Valid {
 		return []byte("null"), nil
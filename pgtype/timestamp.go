@@ -37,6 +37,44 @@ func (ts Timestamp) TimestampValue() (Timestamp, error) {
 	return ts, nil
 }
 
+// Unix returns ts as the number of seconds since the Unix epoch. ts is interpreted as UTC. It returns
+// ErrInfiniteTimestamp if ts is infinity or -infinity.
+func (ts Timestamp) Unix() (int64, error) {
+	if err := unixTimeCheck(ts.Valid, ts.InfinityModifier); err != nil {
+		return 0, err
+	}
+	return discardTimeZone(ts.Time).Unix(), nil
+}
+
+// UnixMilli returns ts as the number of milliseconds since the Unix epoch. ts is interpreted as UTC. It returns
+// ErrInfiniteTimestamp if ts is infinity or -infinity.
+func (ts Timestamp) UnixMilli() (int64, error) {
+	if err := unixTimeCheck(ts.Valid, ts.InfinityModifier); err != nil {
+		return 0, err
+	}
+	return discardTimeZone(ts.Time).UnixMilli(), nil
+}
+
+// ScanTimestamp implements the TimestampScanner interface.
+func (s *UnixSeconds) ScanTimestamp(v Timestamp) error {
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into %T", s)
+	}
+
+	n, err := v.Unix()
+	if err != nil {
+		return err
+	}
+
+	*s = UnixSeconds(n)
+	return nil
+}
+
+// TimestampValue implements the TimestampValuer interface.
+func (s UnixSeconds) TimestampValue() (Timestamp, error) {
+	return Timestamp{Time: time.Unix(int64(s), 0).UTC(), Valid: true}, nil
+}
+
 // Scan implements the database/sql Scanner interface.
 func (ts *Timestamp) Scan(src any) error {
 	if src == nil {
@@ -67,6 +105,21 @@ func (ts Timestamp) Value() (driver.Value, error) {
 	return ts.Time, nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (ts Timestamp) Key() string {
+	if !ts.Valid {
+		return ""
+	}
+
+	if ts.InfinityModifier != Finite {
+		return ts.InfinityModifier.String()
+	}
+
+	// Like the timestamp encoders only the wall clock time matters.
+	return discardTimeZone(ts.Time).Format("2006-01-02T15:04:05.999999999")
+}
+
 func (ts Timestamp) MarshalJSON() ([]byte, error) {
 	if !ts.Valid {
 		return []byte("null"), nil
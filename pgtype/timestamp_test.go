@@ -109,3 +109,41 @@ func TestTimestampUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestTimestampCodecUnixSeconds(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "timestamp", []pgxtest.ValueRoundTripTest{
+		{pgtype.UnixSeconds(1577934245), new(pgtype.UnixSeconds), isExpectedEq(pgtype.UnixSeconds(1577934245))},
+		{pgtype.UnixSeconds(1577934245), new(time.Time), isExpectedEqTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))},
+		{time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), new(pgtype.UnixSeconds), isExpectedEq(pgtype.UnixSeconds(1577934245))},
+		{nil, new(*pgtype.UnixSeconds), isExpectedEq((*pgtype.UnixSeconds)(nil))},
+	})
+}
+
+func TestTimestampUnix(t *testing.T) {
+	ts := pgtype.Timestamp{Time: time.Date(2020, 1, 2, 3, 4, 5, 6000000, time.FixedZone("", 3600)), Valid: true}
+
+	sec, err := ts.Unix()
+	require.NoError(t, err)
+	require.EqualValues(t, 1577934245, sec)
+
+	milli, err := ts.UnixMilli()
+	require.NoError(t, err)
+	require.EqualValues(t, 1577934245006, milli)
+
+	_, err = pgtype.Timestamp{InfinityModifier: pgtype.Infinity, Valid: true}.Unix()
+	require.ErrorIs(t, err, pgtype.ErrInfiniteTimestamp)
+
+	m := pgtype.NewMap()
+	buf, err := m.Encode(pgtype.TimestampOID, pgtype.TextFormatCode, pgtype.UnixSeconds(1577934245), nil)
+	require.NoError(t, err)
+	require.Equal(t, "2020-01-02 03:04:05", string(buf))
+
+	var sec2 pgtype.UnixSeconds
+	err = m.Scan(pgtype.TimestampOID, pgtype.TextFormatCode, buf, &sec2)
+	require.NoError(t, err)
+	require.EqualValues(t, 1577934245, sec2)
+
+	// Integers are not implicitly converted to Unix seconds.
+	_, err = m.Encode(pgtype.TimestampOID, pgtype.TextFormatCode, int64(1577934245), nil)
+	require.Error(t, err)
+}
@@ -4,7 +4,9 @@ import (
 	"database/sql/driver"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -21,6 +23,9 @@ const (
 	infinityMicrosecondOffset         = 9223372036854775807
 )
 
+// ErrInfiniteTimestamp is returned when an infinite timestamp is converted to a Unix time.
+var ErrInfiniteTimestamp = errors.New("infinite timestamp cannot be converted to Unix time")
+
 type TimestamptzScanner interface {
 	ScanTimestamptz(v Timestamptz) error
 }
@@ -45,6 +50,59 @@ func (tstz Timestamptz) TimestamptzValue() (Timestamptz, error) {
 	return tstz, nil
 }
 
+// Unix returns tstz as the number of seconds since the Unix epoch. It returns ErrInfiniteTimestamp if tstz is infinity
+// or -infinity.
+func (tstz Timestamptz) Unix() (int64, error) {
+	if err := unixTimeCheck(tstz.Valid, tstz.InfinityModifier); err != nil {
+		return 0, err
+	}
+	return tstz.Time.Unix(), nil
+}
+
+// UnixMilli returns tstz as the number of milliseconds since the Unix epoch. It returns ErrInfiniteTimestamp if tstz is
+// infinity or -infinity.
+func (tstz Timestamptz) UnixMilli() (int64, error) {
+	if err := unixTimeCheck(tstz.Valid, tstz.InfinityModifier); err != nil {
+		return 0, err
+	}
+	return tstz.Time.UnixMilli(), nil
+}
+
+// UnixSeconds is a number of seconds since the Unix epoch. It can be scanned from and encoded into a timestamptz or a
+// timestamp. A timestamp is interpreted as UTC. Scanning infinity or -infinity returns ErrInfiniteTimestamp. Use a
+// *UnixSeconds to scan a value that may be NULL.
+type UnixSeconds int64
+
+// ScanTimestamptz implements the TimestamptzScanner interface.
+func (s *UnixSeconds) ScanTimestamptz(v Timestamptz) error {
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into %T", s)
+	}
+
+	n, err := v.Unix()
+	if err != nil {
+		return err
+	}
+
+	*s = UnixSeconds(n)
+	return nil
+}
+
+// TimestamptzValue implements the TimestamptzValuer interface.
+func (s UnixSeconds) TimestamptzValue() (Timestamptz, error) {
+	return Timestamptz{Time: time.Unix(int64(s), 0).UTC(), Valid: true}, nil
+}
+
+func unixTimeCheck(valid bool, infinityModifier InfinityModifier) error {
+	if !valid {
+		return errors.New("cannot convert NULL timestamp to Unix time")
+	}
+	if infinityModifier != Finite {
+		return ErrInfiniteTimestamp
+	}
+	return nil
+}
+
 // Scan implements the database/sql Scanner interface.
 func (tstz *Timestamptz) Scan(src any) error {
 	if src == nil {
@@ -75,6 +133,21 @@ func (tstz Timestamptz) Value() (driver.Value, error) {
 	return tstz.Time, nil
 }
 
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (tstz Timestamptz) Key() string {
+	if !tstz.Valid {
+		return ""
+	}
+
+	if tstz.InfinityModifier != Finite {
+		return tstz.InfinityModifier.String()
+	}
+
+	// Times that are the same instant in different locations are equal.
+	return tstz.Time.UTC().Format("2006-01-02T15:04:05.999999999Z")
+}
+
 func (tstz Timestamptz) MarshalJSON() ([]byte, error) {
 	if !tstz.Valid {
 		return []byte("null"), nil
@@ -124,6 +197,64 @@ func (tstz *Timestamptz) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+type TimestamptzMicrosScanner interface {
+	ScanTimestamptzMicros(v TimestamptzMicros) error
+}
+
+type TimestamptzMicrosValuer interface {
+	TimestamptzMicrosValue() (TimestamptzMicros, error)
+}
+
+// TimestamptzMicros represents the PostgreSQL timestamptz type as the number of microseconds since the Unix epoch. This
+// is the precision PostgreSQL stores, so values round trip exactly without a time.Time. Custom types can receive or
+// provide this form by implementing TimestamptzMicrosScanner or TimestamptzMicrosValuer. These take precedence over
+// TimestamptzScanner and TimestamptzValuer.
+type TimestamptzMicros struct {
+	UnixMicro        int64
+	InfinityModifier InfinityModifier
+	Valid            bool
+}
+
+func (tstz *TimestamptzMicros) ScanTimestamptzMicros(v TimestamptzMicros) error {
+	*tstz = v
+	return nil
+}
+
+func (tstz TimestamptzMicros) TimestamptzMicrosValue() (TimestamptzMicros, error) {
+	return tstz, nil
+}
+
+// Scan implements the database/sql Scanner interface.
+func (tstz *TimestamptzMicros) Scan(src any) error {
+	var v Timestamptz
+	err := v.Scan(src)
+	if err != nil {
+		return err
+	}
+
+	*tstz = timestamptzMicrosFromTimestamptz(v)
+	return nil
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (tstz TimestamptzMicros) Value() (driver.Value, error) {
+	return tstz.timestamptz().Value()
+}
+
+func (tstz TimestamptzMicros) timestamptz() Timestamptz {
+	if !tstz.Valid || tstz.InfinityModifier != Finite {
+		return Timestamptz{InfinityModifier: tstz.InfinityModifier, Valid: tstz.Valid}
+	}
+	return Timestamptz{Time: time.UnixMicro(tstz.UnixMicro), Valid: true}
+}
+
+func timestamptzMicrosFromTimestamptz(tstz Timestamptz) TimestamptzMicros {
+	if !tstz.Valid || tstz.InfinityModifier != Finite {
+		return TimestamptzMicros{InfinityModifier: tstz.InfinityModifier, Valid: tstz.Valid}
+	}
+	return TimestamptzMicros{UnixMicro: tstz.Time.UnixMicro(), Valid: true}
+}
+
 type TimestamptzCodec struct{}
 
 func (TimestamptzCodec) FormatSupported(format int16) bool {
@@ -135,20 +266,77 @@ func (TimestamptzCodec) PreferredFormat() int16 {
 }
 
 func (TimestamptzCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
-	if _, ok := value.(TimestamptzValuer); !ok {
-		return nil
-	}
-
+	var plan EncodePlan
 	switch format {
 	case BinaryFormatCode:
-		return encodePlanTimestamptzCodecBinary{}
+		plan = encodePlanTimestamptzCodecBinary{}
 	case TextFormatCode:
-		return encodePlanTimestamptzCodecText{}
+		plan = encodePlanTimestamptzCodecText{}
+	default:
+		return nil
+	}
+
+	switch value.(type) {
+	case TimestamptzMicrosValuer:
+		if format == BinaryFormatCode {
+			return encodePlanTimestamptzCodecBinaryMicros{}
+		}
+		return &encodePlanTimestamptzCodecMicrosValuer{next: plan}
+	case TimestamptzValuer:
+		return plan
 	}
 
 	return nil
 }
 
+// encodePlanTimestamptzCodecBinaryMicros encodes a TimestamptzMicrosValuer without converting it to a time.Time.
+type encodePlanTimestamptzCodecBinaryMicros struct{}
+
+func (encodePlanTimestamptzCodecBinaryMicros) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	tstz, err := value.(TimestamptzMicrosValuer).TimestamptzMicrosValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !tstz.Valid {
+		return nil, nil
+	}
+
+	var microsecSinceY2K int64
+	switch tstz.InfinityModifier {
+	case Finite:
+		if tstz.UnixMicro < math.MinInt64+microsecFromUnixEpochToY2K {
+			return nil, fmt.Errorf("timestamptz %d microseconds since the Unix epoch is out of range", tstz.UnixMicro)
+		}
+		microsecSinceY2K = tstz.UnixMicro - microsecFromUnixEpochToY2K
+	case Infinity:
+		microsecSinceY2K = infinityMicrosecondOffset
+	case NegativeInfinity:
+		microsecSinceY2K = negativeInfinityMicrosecondOffset
+	}
+
+	return pgio.AppendInt64(buf, microsecSinceY2K), nil
+}
+
+// encodePlanTimestamptzCodecMicrosValuer encodes a TimestamptzMicrosValuer in the text format. Microseconds are exactly
+// representable by time.Time so nothing is lost.
+type encodePlanTimestamptzCodecMicrosValuer struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanTimestamptzCodecMicrosValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	tstz, err := value.(TimestamptzMicrosValuer).TimestamptzMicrosValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !tstz.Valid {
+		return nil, nil
+	}
+
+	return plan.next.Encode(tstz.timestamptz(), buf)
+}
+
 type encodePlanTimestamptzCodecBinary struct{}
 
 func (encodePlanTimestamptzCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
@@ -221,23 +409,84 @@ func (encodePlanTimestamptzCodecText) Encode(value any, buf []byte) (newBuf []by
 }
 
 func (TimestamptzCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
-
+	var plan ScanPlan
 	switch format {
 	case BinaryFormatCode:
-		switch target.(type) {
-		case TimestamptzScanner:
-			return scanPlanBinaryTimestamptzToTimestamptzScanner{}
-		}
+		plan = scanPlanBinaryTimestamptzToTimestamptzScanner{}
 	case TextFormatCode:
-		switch target.(type) {
-		case TimestamptzScanner:
-			return scanPlanTextTimestamptzToTimestamptzScanner{}
+		plan = scanPlanTextTimestamptzToTimestamptzScanner{}
+	default:
+		return nil
+	}
+
+	switch target.(type) {
+	case *time.Time:
+		if format == BinaryFormatCode {
+			return scanPlanBinaryTimestamptzToTime{}
 		}
+	case TimestamptzMicrosScanner:
+		if format == BinaryFormatCode {
+			return scanPlanBinaryTimestamptzToTimestamptzMicrosScanner{}
+		}
+		return &scanPlanTimestamptzToTimestamptzMicrosScanner{next: plan}
+	case TimestamptzScanner:
+		return plan
 	}
 
 	return nil
 }
 
+// scanPlanBinaryTimestamptzToTimestamptzMicrosScanner passes the microseconds of a binary timestamptz to the scanner
+// without converting them to a time.Time.
+type scanPlanBinaryTimestamptzToTimestamptzMicrosScanner struct{}
+
+func (scanPlanBinaryTimestamptzToTimestamptzMicrosScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TimestamptzMicrosScanner)
+
+	if src == nil {
+		return scanner.ScanTimestamptzMicros(TimestamptzMicros{})
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for timestamptz: %v", len(src))
+	}
+
+	var tstz TimestamptzMicros
+	microsecSinceY2K := int64(binary.BigEndian.Uint64(src))
+
+	switch microsecSinceY2K {
+	case infinityMicrosecondOffset:
+		tstz = TimestamptzMicros{Valid: true, InfinityModifier: Infinity}
+	case negativeInfinityMicrosecondOffset:
+		tstz = TimestamptzMicros{Valid: true, InfinityModifier: -Infinity}
+	default:
+		if microsecSinceY2K > math.MaxInt64-microsecFromUnixEpochToY2K {
+			return fmt.Errorf("timestamptz %d microseconds since 2000-01-01 cannot be represented as microseconds since the Unix epoch", microsecSinceY2K)
+		}
+		tstz = TimestamptzMicros{UnixMicro: microsecSinceY2K + microsecFromUnixEpochToY2K, Valid: true}
+	}
+
+	return scanner.ScanTimestamptzMicros(tstz)
+}
+
+// scanPlanTimestamptzToTimestamptzMicrosScanner scans a text timestamptz into a TimestamptzMicrosScanner. The text
+// format has microsecond precision so nothing is lost by parsing it into a time.Time first.
+type scanPlanTimestamptzToTimestamptzMicrosScanner struct {
+	next ScanPlan
+}
+
+func (plan *scanPlanTimestamptzToTimestamptzMicrosScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TimestamptzMicrosScanner)
+
+	var tstz Timestamptz
+	err := plan.next.Scan(src, &tstz)
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanTimestamptzMicros(timestamptzMicrosFromTimestamptz(tstz))
+}
+
 type scanPlanBinaryTimestamptzToTimestamptzScanner struct{}
 
 func (scanPlanBinaryTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) error {
@@ -260,16 +509,46 @@ func (scanPlanBinaryTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) e
 	case negativeInfinityMicrosecondOffset:
 		tstz = Timestamptz{Valid: true, InfinityModifier: -Infinity}
 	default:
-		tim := time.Unix(
-			microsecFromUnixEpochToY2K/1000000+microsecSinceY2K/1000000,
-			(microsecFromUnixEpochToY2K%1000000*1000)+(microsecSinceY2K%1000000*1000),
-		)
-		tstz = Timestamptz{Time: tim, Valid: true}
+		tstz = Timestamptz{Time: timeFromMicrosecSinceY2K(microsecSinceY2K), Valid: true}
 	}
 
 	return scanner.ScanTimestamptz(tstz)
 }
 
+func timeFromMicrosecSinceY2K(microsecSinceY2K int64) time.Time {
+	return time.Unix(
+		microsecFromUnixEpochToY2K/1000000+microsecSinceY2K/1000000,
+		(microsecFromUnixEpochToY2K%1000000*1000)+(microsecSinceY2K%1000000*1000),
+	)
+}
+
+// scanPlanBinaryTimestamptzToTime decodes directly into a *time.Time. It is equivalent to but faster than scanning
+// through the time.Time wrapper's TimestamptzScanner implementation because *time.Time is by far the most common
+// target.
+type scanPlanBinaryTimestamptzToTime struct{}
+
+func (scanPlanBinaryTimestamptzToTime) Scan(src []byte, dst any) error {
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into *time.Time")
+	}
+
+	if len(src) != 8 {
+		return fmt.Errorf("invalid length for timestamptz: %v", len(src))
+	}
+
+	microsecSinceY2K := int64(binary.BigEndian.Uint64(src))
+
+	switch microsecSinceY2K {
+	case infinityMicrosecondOffset:
+		return fmt.Errorf("cannot scan Infinity into *time.Time")
+	case negativeInfinityMicrosecondOffset:
+		return fmt.Errorf("cannot scan -Infinity into *time.Time")
+	}
+
+	*(dst.(*time.Time)) = timeFromMicrosecSinceY2K(microsecSinceY2K)
+	return nil
+}
+
 type scanPlanTextTimestamptzToTimestamptzScanner struct{}
 
 func (scanPlanTextTimestamptzToTimestamptzScanner) Scan(src []byte, dst any) error {
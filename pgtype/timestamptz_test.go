@@ -109,3 +109,189 @@ func TestTimestamptzUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestTimestamptzCodecUnixSeconds(t *testing.T) {
+	skipCockroachDB(t, "Server does not support infinite timestamps (see https://github.com/cockroachdb/cockroach/issues/41564)")
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "timestamptz", []pgxtest.ValueRoundTripTest{
+		{pgtype.UnixSeconds(1577934245), new(pgtype.UnixSeconds), isExpectedEq(pgtype.UnixSeconds(1577934245))},
+		{pgtype.UnixSeconds(-1), new(pgtype.UnixSeconds), isExpectedEq(pgtype.UnixSeconds(-1))},
+		{pgtype.UnixSeconds(1577934245), new(time.Time), isExpectedEqTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))},
+		{time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), new(pgtype.UnixSeconds), isExpectedEq(pgtype.UnixSeconds(1577934245))},
+	})
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var n pgtype.UnixSeconds
+		err := conn.QueryRow(ctx, "select 'infinity'::timestamptz").Scan(&n)
+		require.ErrorIs(t, err, pgtype.ErrInfiniteTimestamp)
+	})
+}
+
+func TestTimestamptzUnix(t *testing.T) {
+	tstz := pgtype.Timestamptz{Time: time.Date(2020, 1, 2, 3, 4, 5, 6000000, time.UTC), Valid: true}
+
+	sec, err := tstz.Unix()
+	require.NoError(t, err)
+	require.EqualValues(t, 1577934245, sec)
+
+	milli, err := tstz.UnixMilli()
+	require.NoError(t, err)
+	require.EqualValues(t, 1577934245006, milli)
+
+	_, err = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}.Unix()
+	require.ErrorIs(t, err, pgtype.ErrInfiniteTimestamp)
+
+	_, err = pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}.UnixMilli()
+	require.ErrorIs(t, err, pgtype.ErrInfiniteTimestamp)
+
+	_, err = pgtype.Timestamptz{}.Unix()
+	require.Error(t, err)
+
+	m := pgtype.NewMap()
+	var n pgtype.UnixSeconds
+	err = m.Scan(pgtype.TimestamptzOID, pgtype.TextFormatCode, []byte("2020-01-02 03:04:05Z"), &n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1577934245, n)
+
+	err = m.Scan(pgtype.TimestamptzOID, pgtype.TextFormatCode, []byte("-infinity"), &n)
+	require.ErrorIs(t, err, pgtype.ErrInfiniteTimestamp)
+
+	err = m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, nil, &n)
+	require.Error(t, err)
+
+	var pn *pgtype.UnixSeconds
+	err = m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, nil, &pn)
+	require.NoError(t, err)
+	require.Nil(t, pn)
+
+	// Integers are not implicitly converted to or from Unix seconds.
+	var i int64
+	err = m.Scan(pgtype.TimestamptzOID, pgtype.TextFormatCode, []byte("2020-01-02 03:04:05Z"), &i)
+	require.Error(t, err)
+}
+
+func TestTimestamptzCodecScanBinaryToTime(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, want := range []time.Time{
+		time.Date(2022, 6, 7, 8, 9, 10, 123456000, time.UTC),
+		time.Date(1999, 12, 31, 23, 59, 59, 999999000, time.UTC),
+		time.Date(-100, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		src, err := m.Encode(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, want, nil)
+		require.NoError(t, err)
+
+		var tm time.Time
+		err = m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, src, &tm)
+		require.NoError(t, err)
+		require.True(t, want.Equal(tm), "%v", want)
+
+		var tstz pgtype.Timestamptz
+		err = m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, src, &tstz)
+		require.NoError(t, err)
+		require.Equal(t, tstz.Time, tm)
+	}
+
+	for _, infinity := range []pgtype.InfinityModifier{pgtype.Infinity, pgtype.NegativeInfinity} {
+		src, err := m.Encode(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, pgtype.Timestamptz{InfinityModifier: infinity, Valid: true}, nil)
+		require.NoError(t, err)
+
+		var tm time.Time
+		err = m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, src, &tm)
+		require.Error(t, err)
+	}
+
+	var tm time.Time
+	err := m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, nil, &tm)
+	require.EqualError(t, err, "cannot scan NULL into *time.Time")
+
+	var ptm *time.Time
+	err = m.Scan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, nil, &ptm)
+	require.NoError(t, err)
+	require.Nil(t, ptm)
+}
+
+func BenchmarkTimestamptzCodecScanBinaryToTime(b *testing.B) {
+	m := pgtype.NewMap()
+	src, err := m.Encode(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, time.Date(2022, 6, 7, 8, 9, 10, 123456000, time.UTC), nil)
+	require.NoError(b, err)
+
+	var dst time.Time
+	plan := m.PlanScan(pgtype.TimestamptzOID, pgtype.BinaryFormatCode, &dst)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := plan.Scan(src, &dst)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// microTimestamp is a custom type that receives and provides a timestamptz as raw microseconds.
+type microTimestamp struct {
+	micros   int64
+	infinity pgtype.InfinityModifier
+	valid    bool
+}
+
+func (m *microTimestamp) ScanTimestamptzMicros(v pgtype.TimestamptzMicros) error {
+	*m = microTimestamp{micros: v.UnixMicro, infinity: v.InfinityModifier, valid: v.Valid}
+	return nil
+}
+
+func (m microTimestamp) TimestamptzMicrosValue() (pgtype.TimestamptzMicros, error) {
+	return pgtype.TimestamptzMicros{UnixMicro: m.micros, InfinityModifier: m.infinity, Valid: m.valid}, nil
+}
+
+func TestTimestamptzCodecMicros(t *testing.T) {
+	skipCockroachDB(t, "Server does not support infinite timestamps (see https://github.com/cockroachdb/cockroach/issues/41564)")
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, pgxtest.KnownOIDQueryExecModes, "timestamptz", []pgxtest.ValueRoundTripTest{
+		{microTimestamp{micros: 1700000000123456, valid: true}, new(microTimestamp), isExpectedEq(microTimestamp{micros: 1700000000123456, valid: true})},
+		{microTimestamp{micros: -62135596800000001, valid: true}, new(microTimestamp), isExpectedEq(microTimestamp{micros: -62135596800000001, valid: true})},
+		{microTimestamp{infinity: pgtype.Infinity, valid: true}, new(microTimestamp), isExpectedEq(microTimestamp{infinity: pgtype.Infinity, valid: true})},
+		{microTimestamp{infinity: pgtype.NegativeInfinity, valid: true}, new(microTimestamp), isExpectedEq(microTimestamp{infinity: pgtype.NegativeInfinity, valid: true})},
+		{microTimestamp{}, new(microTimestamp), isExpectedEq(microTimestamp{})},
+		{pgtype.TimestamptzMicros{UnixMicro: 1, Valid: true}, new(pgtype.TimestamptzMicros), isExpectedEq(pgtype.TimestamptzMicros{UnixMicro: 1, Valid: true})},
+		{nil, new(pgtype.TimestamptzMicros), isExpectedEq(pgtype.TimestamptzMicros{})},
+	})
+}
+
+func TestTimestamptzCodecMicrosWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		src, err := m.Encode(pgtype.TimestamptzOID, format, microTimestamp{micros: 1700000000123456, valid: true}, nil)
+		require.NoError(t, err)
+
+		var tm time.Time
+		err = m.Scan(pgtype.TimestamptzOID, format, src, &tm)
+		require.NoError(t, err)
+		require.Equal(t, int64(1700000000123456), tm.UnixMicro())
+
+		var mt microTimestamp
+		err = m.Scan(pgtype.TimestamptzOID, format, src, &mt)
+		require.NoError(t, err)
+		require.Equal(t, microTimestamp{micros: 1700000000123456, valid: true}, mt)
+
+		src, err = m.Encode(pgtype.TimestamptzOID, format, microTimestamp{infinity: pgtype.Infinity, valid: true}, nil)
+		require.NoError(t, err)
+		err = m.Scan(pgtype.TimestamptzOID, format, src, &mt)
+		require.NoError(t, err)
+		require.Equal(t, microTimestamp{infinity: pgtype.Infinity, valid: true}, mt)
+
+		err = m.Scan(pgtype.TimestamptzOID, format, nil, &mt)
+		require.NoError(t, err)
+		require.Equal(t, microTimestamp{}, mt)
+	}
+
+	var v pgtype.TimestamptzMicros
+	require.NoError(t, v.Scan(time.UnixMicro(42)))
+	require.Equal(t, pgtype.TimestamptzMicros{UnixMicro: 42, Valid: true}, v)
+
+	dv, err := v.Value()
+	require.NoError(t, err)
+	require.True(t, time.UnixMicro(42).Equal(dv.(time.Time)))
+}
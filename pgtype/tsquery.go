@@ -0,0 +1,691 @@
+package pgtype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/internal/pgio"
+)
+
+type TsqueryScanner interface {
+	ScanTsquery(v Tsquery) error
+}
+
+type TsqueryValuer interface {
+	TsqueryValue() (Tsquery, error)
+}
+
+// TsqueryOperator is the operator of a TsqueryNode. The values match the operator numbers PostgreSQL uses in the binary
+// format.
+type TsqueryOperator uint8
+
+const (
+	TsqueryOperand TsqueryOperator = 0 // Not an operator. The node is an operand.
+	TsqueryNot     TsqueryOperator = 1 // !
+	TsqueryAnd     TsqueryOperator = 2 // &
+	TsqueryOr      TsqueryOperator = 3 // |
+	TsqueryPhrase  TsqueryOperator = 4 // <-> or <N>
+)
+
+// TsqueryNode is a node of a tsquery expression tree.
+//
+// If Operator is TsqueryOperand then Operand is the lexeme, Prefix is true for a prefix match (:*), and Weights holds
+// the weights the lexeme is restricted to as a subset of "ABCD". Otherwise, Left and Right are the operands of the
+// operator. TsqueryNot only has Left. Distance is only used by TsqueryPhrase and is the N in <N>. <-> has a Distance of
+// 1.
+type TsqueryNode struct {
+	Operator TsqueryOperator
+
+	Operand string
+	Prefix  bool
+	Weights string
+
+	Distance uint16
+	Left     *TsqueryNode
+	Right    *TsqueryNode
+}
+
+// Tsquery represents a PostgreSQL tsquery. Root is nil for an empty tsquery.
+type Tsquery struct {
+	Root  *TsqueryNode
+	Valid bool
+}
+
+func (t *Tsquery) ScanTsquery(v Tsquery) error {
+	*t = v
+	return nil
+}
+
+func (t Tsquery) TsqueryValue() (Tsquery, error) {
+	return t, nil
+}
+
+// Scan implements the database/sql Scanner interface.
+func (t *Tsquery) Scan(src any) error {
+	if src == nil {
+		*t = Tsquery{}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case string:
+		return scanPlanTextAnyToTsqueryScanner{}.Scan([]byte(src), t)
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (t Tsquery) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+
+	buf, err := TsqueryCodec{}.PlanEncode(nil, 0, TextFormatCode, t).Encode(t, nil)
+	if err != nil {
+		return nil, err
+	}
+	return string(buf), err
+}
+
+// String returns the text format of t.
+func (t Tsquery) String() string {
+	if !t.Valid {
+		return ""
+	}
+
+	buf, err := appendTsqueryText(nil, t.Root, 0, false)
+	if err != nil {
+		return ""
+	}
+	return string(buf)
+}
+
+const (
+	tsqueryItemOperand  = 1
+	tsqueryItemOperator = 2
+)
+
+// tsqueryPriority returns the binding strength of op. The values are the same as PostgreSQL's.
+func tsqueryPriority(op TsqueryOperator) int {
+	switch op {
+	case TsqueryOr:
+		return 1
+	case TsqueryAnd:
+		return 2
+	case TsqueryPhrase:
+		return 3
+	case TsqueryNot:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// tsqueryWeightsToMask converts weights such as "AB" to the binary format bit mask.
+func tsqueryWeightsToMask(weights string) (uint8, error) {
+	var mask uint8
+	for i := 0; i < len(weights); i++ {
+		bits, err := tsvectorWeightBits(weights[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid tsquery weight %q", weights[i])
+		}
+		mask |= 1 << bits
+	}
+	return mask, nil
+}
+
+// tsqueryMaskToWeights converts a binary format bit mask to weights such as "AB".
+func tsqueryMaskToWeights(mask uint8) string {
+	var weights []byte
+	for bits := 3; bits >= 0; bits-- {
+		if mask&(1<<bits) != 0 {
+			weights = append(weights, tsvectorWeightsInOrder[bits])
+		}
+	}
+	return string(weights)
+}
+
+func (n *TsqueryNode) validate() error {
+	if n == nil {
+		return errors.New("tsquery operand or operator is missing")
+	}
+
+	switch n.Operator {
+	case TsqueryOperand:
+		if n.Operand == "" {
+			return errors.New("tsquery operand cannot be empty")
+		}
+		if strings.IndexByte(n.Operand, 0) != -1 {
+			return fmt.Errorf("tsquery operand %q cannot contain a NUL byte", n.Operand)
+		}
+	case TsqueryNot:
+		if n.Left == nil {
+			return errors.New("tsquery ! operator is missing its operand")
+		}
+	case TsqueryAnd, TsqueryOr, TsqueryPhrase:
+		if n.Left == nil || n.Right == nil {
+			return fmt.Errorf("tsquery operator %d is missing an operand", n.Operator)
+		}
+	default:
+		return fmt.Errorf("unknown tsquery operator %d", n.Operator)
+	}
+
+	return nil
+}
+
+type TsqueryCodec struct{}
+
+func (TsqueryCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (TsqueryCodec) PreferredFormat() int16 {
+	return BinaryFormatCode
+}
+
+func (TsqueryCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	if _, ok := value.(TsqueryValuer); !ok {
+		return nil
+	}
+
+	switch format {
+	case BinaryFormatCode:
+		return encodePlanTsqueryCodecBinary{}
+	case TextFormatCode:
+		return encodePlanTsqueryCodecText{}
+	}
+
+	return nil
+}
+
+type encodePlanTsqueryCodecBinary struct{}
+
+func (encodePlanTsqueryCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	tsquery, err := value.(TsqueryValuer).TsqueryValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !tsquery.Valid {
+		return nil, nil
+	}
+
+	sp := len(buf)
+	buf = pgio.AppendInt32(buf, -1)
+
+	var itemCount int32
+	if tsquery.Root != nil {
+		buf, err = appendTsqueryBinary(buf, tsquery.Root, &itemCount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pgio.SetInt32(buf[sp:], itemCount)
+	return buf, nil
+}
+
+// appendTsqueryBinary appends n in prefix order. PostgreSQL stores the right operand of a binary operator before the
+// left operand.
+func appendTsqueryBinary(buf []byte, n *TsqueryNode, itemCount *int32) ([]byte, error) {
+	if err := n.validate(); err != nil {
+		return nil, err
+	}
+	*itemCount++
+
+	if n.Operator == TsqueryOperand {
+		mask, err := tsqueryWeightsToMask(n.Weights)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, tsqueryItemOperand, mask)
+		if n.Prefix {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		buf = append(buf, n.Operand...)
+		return append(buf, 0), nil
+	}
+
+	buf = append(buf, tsqueryItemOperator, byte(n.Operator))
+	if n.Operator == TsqueryPhrase {
+		buf = pgio.AppendUint16(buf, n.Distance)
+	}
+
+	var err error
+	if n.Operator != TsqueryNot {
+		buf, err = appendTsqueryBinary(buf, n.Right, itemCount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return appendTsqueryBinary(buf, n.Left, itemCount)
+}
+
+type encodePlanTsqueryCodecText struct{}
+
+func (encodePlanTsqueryCodecText) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	tsquery, err := value.(TsqueryValuer).TsqueryValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !tsquery.Valid {
+		return nil, nil
+	}
+
+	// An empty tsquery is the empty string. Return a non-nil slice so it is not confused with NULL.
+	if buf == nil {
+		buf = []byte{}
+	}
+
+	if tsquery.Root == nil {
+		return buf, nil
+	}
+
+	return appendTsqueryText(buf, tsquery.Root, 0, false)
+}
+
+// appendTsqueryText appends n in the same format as PostgreSQL. Parentheses are added where n binds less tightly than
+// its parent and around a phrase that is the right operand of a phrase.
+func appendTsqueryText(buf []byte, n *TsqueryNode, parentPriority int, rightOfPhrase bool) ([]byte, error) {
+	if err := n.validate(); err != nil {
+		return nil, err
+	}
+
+	if n.Operator == TsqueryOperand {
+		buf = appendTsQuoted(buf, n.Operand)
+		if n.Prefix || n.Weights != "" {
+			mask, err := tsqueryWeightsToMask(n.Weights)
+			if err != nil {
+				return nil, err
+			}
+			buf = append(buf, ':')
+			if n.Prefix {
+				buf = append(buf, '*')
+			}
+			buf = append(buf, tsqueryMaskToWeights(mask)...)
+		}
+		return buf, nil
+	}
+
+	priority := tsqueryPriority(n.Operator)
+	needParens := priority < parentPriority || (n.Operator == TsqueryPhrase && rightOfPhrase)
+	if needParens {
+		buf = append(buf, "( "...)
+	}
+
+	var err error
+	if n.Operator == TsqueryNot {
+		buf = append(buf, '!')
+		buf, err = appendTsqueryText(buf, n.Left, priority, false)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		buf, err = appendTsqueryText(buf, n.Left, priority, false)
+		if err != nil {
+			return nil, err
+		}
+
+		switch n.Operator {
+		case TsqueryAnd:
+			buf = append(buf, " & "...)
+		case TsqueryOr:
+			buf = append(buf, " | "...)
+		case TsqueryPhrase:
+			if n.Distance == 1 {
+				buf = append(buf, " <-> "...)
+			} else {
+				buf = append(buf, " <"...)
+				buf = strconv.AppendUint(buf, uint64(n.Distance), 10)
+				buf = append(buf, "> "...)
+			}
+		}
+
+		buf, err = appendTsqueryText(buf, n.Right, priority, n.Operator == TsqueryPhrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if needParens {
+		buf = append(buf, " )"...)
+	}
+
+	return buf, nil
+}
+
+func (TsqueryCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	switch format {
+	case BinaryFormatCode:
+		switch target.(type) {
+		case TsqueryScanner:
+			return scanPlanBinaryTsqueryToTsqueryScanner{}
+		}
+	case TextFormatCode:
+		switch target.(type) {
+		case TsqueryScanner:
+			return scanPlanTextAnyToTsqueryScanner{}
+		}
+	}
+
+	return nil
+}
+
+func (c TsqueryCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return codecDecodeToTextFormat(c, m, oid, format, src)
+}
+
+func (c TsqueryCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var tsquery Tsquery
+	err := codecScan(c, m, oid, format, src, &tsquery)
+	if err != nil {
+		return nil, err
+	}
+	return tsquery, nil
+}
+
+type scanPlanBinaryTsqueryToTsqueryScanner struct{}
+
+func (scanPlanBinaryTsqueryToTsqueryScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TsqueryScanner)
+
+	if src == nil {
+		return scanner.ScanTsquery(Tsquery{})
+	}
+
+	if len(src) < 4 {
+		return fmt.Errorf("invalid length for tsquery: %v", len(src))
+	}
+	itemCount := int(int32(binary.BigEndian.Uint32(src)))
+	if itemCount < 0 {
+		return fmt.Errorf("invalid tsquery item count: %d", itemCount)
+	}
+
+	d := &tsqueryBinaryDecoder{src: src, rp: 4, remaining: itemCount}
+	tsquery := Tsquery{Valid: true}
+	if itemCount > 0 {
+		root, err := d.next()
+		if err != nil {
+			return err
+		}
+		tsquery.Root = root
+	}
+
+	if d.remaining != 0 || d.rp != len(src) {
+		return errors.New("tsquery has unexpected trailing data")
+	}
+
+	return scanner.ScanTsquery(tsquery)
+}
+
+type tsqueryBinaryDecoder struct {
+	src       []byte
+	rp        int
+	remaining int
+}
+
+func (d *tsqueryBinaryDecoder) next() (*TsqueryNode, error) {
+	if d.remaining == 0 {
+		return nil, errors.New("tsquery is missing an operand")
+	}
+	d.remaining--
+
+	if len(d.src)-d.rp < 2 {
+		return nil, errors.New("tsquery too short")
+	}
+	itemType := d.src[d.rp]
+	d.rp++
+
+	switch itemType {
+	case tsqueryItemOperand:
+		if len(d.src)-d.rp < 2 {
+			return nil, errors.New("tsquery too short")
+		}
+		n := &TsqueryNode{
+			Operator: TsqueryOperand,
+			Weights:  tsqueryMaskToWeights(d.src[d.rp]),
+			Prefix:   d.src[d.rp+1] != 0,
+		}
+		d.rp += 2
+
+		end := bytes.IndexByte(d.src[d.rp:], 0)
+		if end == -1 {
+			return nil, errors.New("tsquery operand is not terminated")
+		}
+		n.Operand = string(d.src[d.rp : d.rp+end])
+		d.rp += end + 1
+		return n, nil
+
+	case tsqueryItemOperator:
+		n := &TsqueryNode{Operator: TsqueryOperator(d.src[d.rp])}
+		d.rp++
+
+		var err error
+		switch n.Operator {
+		case TsqueryNot:
+			n.Left, err = d.next()
+			if err != nil {
+				return nil, err
+			}
+		case TsqueryPhrase, TsqueryAnd, TsqueryOr:
+			if n.Operator == TsqueryPhrase {
+				if len(d.src)-d.rp < 2 {
+					return nil, errors.New("tsquery too short")
+				}
+				n.Distance = binary.BigEndian.Uint16(d.src[d.rp:])
+				d.rp += 2
+			}
+
+			n.Right, err = d.next()
+			if err != nil {
+				return nil, err
+			}
+			n.Left, err = d.next()
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unknown tsquery operator %d", n.Operator)
+		}
+		return n, nil
+
+	default:
+		return nil, fmt.Errorf("unknown tsquery item type %d", itemType)
+	}
+}
+
+type scanPlanTextAnyToTsqueryScanner struct{}
+
+func (scanPlanTextAnyToTsqueryScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TsqueryScanner)
+
+	if src == nil {
+		return scanner.ScanTsquery(Tsquery{})
+	}
+
+	tsquery, err := parseTsquery(string(src))
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanTsquery(tsquery)
+}
+
+func parseTsquery(src string) (Tsquery, error) {
+	p := &tsParser{src: src}
+
+	p.skipSpace()
+	if p.atEnd() {
+		return Tsquery{Valid: true}, nil
+	}
+
+	root, err := p.tsqueryOr()
+	if err != nil {
+		return Tsquery{}, err
+	}
+
+	p.skipSpace()
+	if !p.atEnd() {
+		return Tsquery{}, fmt.Errorf("unexpected %q at position %d in %q", p.peek(), p.pos, src)
+	}
+
+	return Tsquery{Root: root, Valid: true}, nil
+}
+
+func (p *tsParser) tsqueryOr() (*TsqueryNode, error) {
+	left, err := p.tsqueryAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if p.peek() != '|' {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.tsqueryAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &TsqueryNode{Operator: TsqueryOr, Left: left, Right: right}
+	}
+}
+
+func (p *tsParser) tsqueryAnd() (*TsqueryNode, error) {
+	left, err := p.tsqueryPhrase()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if p.peek() != '&' {
+			return left, nil
+		}
+		p.pos++
+
+		right, err := p.tsqueryPhrase()
+		if err != nil {
+			return nil, err
+		}
+		left = &TsqueryNode{Operator: TsqueryAnd, Left: left, Right: right}
+	}
+}
+
+func (p *tsParser) tsqueryPhrase() (*TsqueryNode, error) {
+	left, err := p.tsqueryNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if p.peek() != '<' {
+			return left, nil
+		}
+		p.pos++
+
+		var distance uint16
+		if strings.HasPrefix(p.src[p.pos:], "->") {
+			distance = 1
+			p.pos += 2
+		} else {
+			end := strings.IndexByte(p.src[p.pos:], '>')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid tsquery phrase operator at position %d in %q", p.pos, p.src)
+			}
+			n, err := strconv.ParseUint(p.src[p.pos:p.pos+end], 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tsquery phrase distance in %q: %w", p.src, err)
+			}
+			distance = uint16(n)
+			p.pos += end + 1
+		}
+
+		right, err := p.tsqueryNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &TsqueryNode{Operator: TsqueryPhrase, Distance: distance, Left: left, Right: right}
+	}
+}
+
+func (p *tsParser) tsqueryNot() (*TsqueryNode, error) {
+	p.skipSpace()
+	if p.peek() == '!' {
+		p.pos++
+		operand, err := p.tsqueryNot()
+		if err != nil {
+			return nil, err
+		}
+		return &TsqueryNode{Operator: TsqueryNot, Left: operand}, nil
+	}
+
+	return p.tsqueryPrimary()
+}
+
+func (p *tsParser) tsqueryPrimary() (*TsqueryNode, error) {
+	p.skipSpace()
+	if p.atEnd() {
+		return nil, fmt.Errorf("unexpected end of tsquery %q", p.src)
+	}
+
+	if p.peek() == '(' {
+		p.pos++
+		n, err := p.tsqueryOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("expected ) at position %d in %q", p.pos, p.src)
+		}
+		p.pos++
+		return n, nil
+	}
+
+	operand, err := p.word(":&|!()<")
+	if err != nil {
+		return nil, err
+	}
+	n := &TsqueryNode{Operator: TsqueryOperand, Operand: operand}
+
+	if p.peek() == ':' {
+		p.pos++
+		var weights []byte
+		for !p.atEnd() {
+			b := p.src[p.pos]
+			if b == '*' {
+				n.Prefix = true
+			} else if _, err := tsvectorWeightBits(b); err == nil {
+				weights = append(weights, b&^0x20) // upper case
+			} else {
+				break
+			}
+			p.pos++
+		}
+
+		mask, err := tsqueryWeightsToMask(string(weights))
+		if err != nil {
+			return nil, err
+		}
+		n.Weights = tsqueryMaskToWeights(mask)
+	}
+
+	return n, nil
+}
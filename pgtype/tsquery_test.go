@@ -0,0 +1,108 @@
+package pgtype_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func isExpectedEqTsquery(a any) func(any) bool {
+	return func(v any) bool {
+		return reflect.DeepEqual(a, v)
+	}
+}
+
+func mustParseTsquery(t testing.TB, s string) pgtype.Tsquery {
+	var tsquery pgtype.Tsquery
+	err := tsquery.Scan(s)
+	require.NoError(t, err)
+	return tsquery
+}
+
+func TestTsqueryCodec(t *testing.T) {
+	skipCockroachDB(t, "Server does not support tsquery type")
+
+	var tests []pgxtest.ValueRoundTripTest
+	for _, s := range []string{
+		``,
+		`'fat'`,
+		`'fat' & 'rat'`,
+		`'fat' & ( 'rat' | 'cat' )`,
+		`!'fat' & 'rat':*AB`,
+		`'fat' <-> 'rat' <2> 'cat'`,
+		`'fat' <-> ( 'rat' <-> 'cat' )`,
+		`'it''s' | 'sl\\ash':*`,
+		`!( 'fat' | 'rat' )`,
+	} {
+		tests = append(tests, pgxtest.ValueRoundTripTest{
+			Param:  mustParseTsquery(t, s),
+			Result: new(pgtype.Tsquery),
+			Test:   isExpectedEqTsquery(mustParseTsquery(t, s)),
+		})
+	}
+	tests = append(tests,
+		pgxtest.ValueRoundTripTest{Param: pgtype.Tsquery{}, Result: new(pgtype.Tsquery), Test: isExpectedEqTsquery(pgtype.Tsquery{})},
+		pgxtest.ValueRoundTripTest{Param: nil, Result: new(pgtype.Tsquery), Test: isExpectedEqTsquery(pgtype.Tsquery{})},
+	)
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "tsquery", tests)
+}
+
+func TestTsqueryParse(t *testing.T) {
+	tsquery := mustParseTsquery(t, `fat & !(rat:*a | cat) <3> dog`)
+	require.Equal(t, pgtype.Tsquery{
+		Root: &pgtype.TsqueryNode{
+			Operator: pgtype.TsqueryAnd,
+			Left:     &pgtype.TsqueryNode{Operator: pgtype.TsqueryOperand, Operand: "fat"},
+			Right: &pgtype.TsqueryNode{
+				Operator: pgtype.TsqueryPhrase,
+				Distance: 3,
+				Left: &pgtype.TsqueryNode{
+					Operator: pgtype.TsqueryNot,
+					Left: &pgtype.TsqueryNode{
+						Operator: pgtype.TsqueryOr,
+						Left:     &pgtype.TsqueryNode{Operator: pgtype.TsqueryOperand, Operand: "rat", Prefix: true, Weights: "A"},
+						Right:    &pgtype.TsqueryNode{Operator: pgtype.TsqueryOperand, Operand: "cat"},
+					},
+				},
+				Right: &pgtype.TsqueryNode{Operator: pgtype.TsqueryOperand, Operand: "dog"},
+			},
+		},
+		Valid: true,
+	}, tsquery)
+	require.Equal(t, `'fat' & !( 'rat':*A | 'cat' ) <3> 'dog'`, tsquery.String())
+
+	for _, s := range []string{`fat &`, `(fat`, `fat <x> rat`, `fat rat`, `'fat`} {
+		var tsquery pgtype.Tsquery
+		err := tsquery.Scan(s)
+		require.Errorf(t, err, "%s", s)
+	}
+}
+
+func TestTsqueryCodecWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, s := range []string{
+		``,
+		`'fat' & ( 'rat' | 'cat' )`,
+		`!'fat' & 'rat':*AB`,
+		`'fat' <-> ( 'rat' <2> 'cat' )`,
+		`'it''s' | 'sl\\ash':*`,
+	} {
+		tsquery := mustParseTsquery(t, s)
+		for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+			buf, err := m.Encode(pgtype.TsqueryOID, format, tsquery, nil)
+			require.NoError(t, err)
+
+			var got pgtype.Tsquery
+			err = m.Scan(pgtype.TsqueryOID, format, buf, &got)
+			require.NoError(t, err)
+			require.Equal(t, tsquery, got)
+			require.Equal(t, s, got.String())
+		}
+	}
+}
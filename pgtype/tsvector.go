@@ -0,0 +1,504 @@
+package pgtype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/internal/pgio"
+)
+
+type TsvectorScanner interface {
+	ScanTsvector(v Tsvector) error
+}
+
+type TsvectorValuer interface {
+	TsvectorValue() (Tsvector, error)
+}
+
+// TsvectorLexeme is a lexeme of a tsvector. Positions are in ascending order. Weights is either empty or the same
+// length as Positions and holds the weight 'A', 'B', 'C', or 'D' of each position. An empty Weights means all positions
+// have the default weight 'D'.
+type TsvectorLexeme struct {
+	Lexeme    string
+	Positions []uint16
+	Weights   []byte
+}
+
+// Tsvector represents a PostgreSQL tsvector. Lexemes are in the order PostgreSQL keeps them, sorted and without
+// duplicates.
+type Tsvector struct {
+	Lexemes []TsvectorLexeme
+	Valid   bool
+}
+
+func (t *Tsvector) ScanTsvector(v Tsvector) error {
+	*t = v
+	return nil
+}
+
+func (t Tsvector) TsvectorValue() (Tsvector, error) {
+	return t, nil
+}
+
+// Scan implements the database/sql Scanner interface.
+func (t *Tsvector) Scan(src any) error {
+	if src == nil {
+		*t = Tsvector{}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case string:
+		return scanPlanTextAnyToTsvectorScanner{}.Scan([]byte(src), t)
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (t Tsvector) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+
+	buf, err := TsvectorCodec{}.PlanEncode(nil, 0, TextFormatCode, t).Encode(t, nil)
+	if err != nil {
+		return nil, err
+	}
+	return string(buf), err
+}
+
+// tsvector positions store the weight in the top 2 bits and the position in the low 14 bits.
+const (
+	tsvectorMaxPosition    = 1<<14 - 1
+	tsvectorPositionMask   = 1<<14 - 1
+	tsvectorWeightShift    = 14
+	tsvectorDefaultWeight  = 'D'
+	tsvectorWeightsInOrder = "DCBA" // Indexed by weight bits.
+)
+
+func tsvectorWeightBits(weight byte) (uint16, error) {
+	switch weight {
+	case 'A', 'a':
+		return 3, nil
+	case 'B', 'b':
+		return 2, nil
+	case 'C', 'c':
+		return 1, nil
+	case 'D', 'd':
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("invalid tsvector weight %q", weight)
+	}
+}
+
+func (l *TsvectorLexeme) weight(i int) byte {
+	if len(l.Weights) == 0 {
+		return tsvectorDefaultWeight
+	}
+	return l.Weights[i]
+}
+
+func (l *TsvectorLexeme) validate() error {
+	if l.Lexeme == "" {
+		return errors.New("tsvector lexeme cannot be empty")
+	}
+	if strings.IndexByte(l.Lexeme, 0) != -1 {
+		return fmt.Errorf("tsvector lexeme %q cannot contain a NUL byte", l.Lexeme)
+	}
+	if len(l.Weights) != 0 && len(l.Weights) != len(l.Positions) {
+		return fmt.Errorf("tsvector lexeme %q has %d positions but %d weights", l.Lexeme, len(l.Positions), len(l.Weights))
+	}
+	for _, p := range l.Positions {
+		if p == 0 || p > tsvectorMaxPosition {
+			return fmt.Errorf("tsvector lexeme %q position %d is out of range", l.Lexeme, p)
+		}
+	}
+	return nil
+}
+
+type TsvectorCodec struct{}
+
+func (TsvectorCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode || format == BinaryFormatCode
+}
+
+func (TsvectorCodec) PreferredFormat() int16 {
+	return BinaryFormatCode
+}
+
+func (TsvectorCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	if _, ok := value.(TsvectorValuer); !ok {
+		return nil
+	}
+
+	switch format {
+	case BinaryFormatCode:
+		return encodePlanTsvectorCodecBinary{}
+	case TextFormatCode:
+		return encodePlanTsvectorCodecText{}
+	}
+
+	return nil
+}
+
+type encodePlanTsvectorCodecBinary struct{}
+
+func (encodePlanTsvectorCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	tsvector, err := value.(TsvectorValuer).TsvectorValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !tsvector.Valid {
+		return nil, nil
+	}
+
+	buf = pgio.AppendInt32(buf, int32(len(tsvector.Lexemes)))
+	for i := range tsvector.Lexemes {
+		l := &tsvector.Lexemes[i]
+		if err := l.validate(); err != nil {
+			return nil, err
+		}
+
+		buf = append(buf, l.Lexeme...)
+		buf = append(buf, 0)
+		buf = pgio.AppendUint16(buf, uint16(len(l.Positions)))
+		for j, p := range l.Positions {
+			weightBits, err := tsvectorWeightBits(l.weight(j))
+			if err != nil {
+				return nil, err
+			}
+			buf = pgio.AppendUint16(buf, weightBits<<tsvectorWeightShift|p)
+		}
+	}
+
+	return buf, nil
+}
+
+type encodePlanTsvectorCodecText struct{}
+
+func (encodePlanTsvectorCodecText) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	tsvector, err := value.(TsvectorValuer).TsvectorValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !tsvector.Valid {
+		return nil, nil
+	}
+
+	// An empty tsvector is the empty string. Return a non-nil slice so it is not confused with NULL.
+	if buf == nil {
+		buf = []byte{}
+	}
+
+	for i := range tsvector.Lexemes {
+		l := &tsvector.Lexemes[i]
+		if err := l.validate(); err != nil {
+			return nil, err
+		}
+
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = appendTsQuoted(buf, l.Lexeme)
+
+		for j, p := range l.Positions {
+			if j == 0 {
+				buf = append(buf, ':')
+			} else {
+				buf = append(buf, ',')
+			}
+			buf = strconv.AppendUint(buf, uint64(p), 10)
+
+			weight := l.weight(j)
+			if _, err := tsvectorWeightBits(weight); err != nil {
+				return nil, err
+			}
+			if weight != 'D' && weight != 'd' {
+				buf = append(buf, weight&^0x20) // upper case
+			}
+		}
+	}
+
+	return buf, nil
+}
+
+// appendTsQuoted appends s to buf quoted the way PostgreSQL quotes tsvector lexemes and tsquery operands.
+func appendTsQuoted(buf []byte, s string) []byte {
+	buf = append(buf, '\'')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\'' || s[i] == '\\' {
+			buf = append(buf, s[i])
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, '\'')
+}
+
+func (TsvectorCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	switch format {
+	case BinaryFormatCode:
+		switch target.(type) {
+		case TsvectorScanner:
+			return scanPlanBinaryTsvectorToTsvectorScanner{}
+		}
+	case TextFormatCode:
+		switch target.(type) {
+		case TsvectorScanner:
+			return scanPlanTextAnyToTsvectorScanner{}
+		}
+	}
+
+	return nil
+}
+
+func (c TsvectorCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return codecDecodeToTextFormat(c, m, oid, format, src)
+}
+
+func (c TsvectorCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	var tsvector Tsvector
+	err := codecScan(c, m, oid, format, src, &tsvector)
+	if err != nil {
+		return nil, err
+	}
+	return tsvector, nil
+}
+
+type scanPlanBinaryTsvectorToTsvectorScanner struct{}
+
+func (scanPlanBinaryTsvectorToTsvectorScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TsvectorScanner)
+
+	if src == nil {
+		return scanner.ScanTsvector(Tsvector{})
+	}
+
+	if len(src) < 4 {
+		return fmt.Errorf("invalid length for tsvector: %v", len(src))
+	}
+	lexemeCount := int(int32(binary.BigEndian.Uint32(src)))
+	rp := 4
+
+	// Each lexeme is at least a 1 byte string, its terminator, and a 2 byte position count.
+	if lexemeCount < 0 || lexemeCount > (len(src)-rp)/4 {
+		return fmt.Errorf("tsvector too short for %d lexemes: %d", lexemeCount, len(src))
+	}
+
+	var lexemes []TsvectorLexeme
+	if lexemeCount > 0 {
+		lexemes = make([]TsvectorLexeme, lexemeCount)
+	}
+	for i := range lexemes {
+		end := bytes.IndexByte(src[rp:], 0)
+		if end == -1 {
+			return fmt.Errorf("tsvector lexeme %d is not terminated", i)
+		}
+		lexemes[i].Lexeme = string(src[rp : rp+end])
+		rp += end + 1
+
+		if len(src)-rp < 2 {
+			return fmt.Errorf("tsvector too short for lexeme %d", i)
+		}
+		positionCount := int(binary.BigEndian.Uint16(src[rp:]))
+		rp += 2
+
+		if len(src)-rp < positionCount*2 {
+			return fmt.Errorf("tsvector too short for lexeme %d positions", i)
+		}
+		if positionCount > 0 {
+			positions := make([]uint16, positionCount)
+			var weights []byte
+			for j := range positions {
+				wp := binary.BigEndian.Uint16(src[rp:])
+				rp += 2
+				positions[j] = wp & tsvectorPositionMask
+				weight := tsvectorWeightsInOrder[wp>>tsvectorWeightShift]
+				if weight != tsvectorDefaultWeight && weights == nil {
+					weights = bytes.Repeat([]byte{tsvectorDefaultWeight}, positionCount)
+				}
+				if weights != nil {
+					weights[j] = weight
+				}
+			}
+			lexemes[i].Positions = positions
+			lexemes[i].Weights = weights
+		}
+	}
+
+	if rp != len(src) {
+		return fmt.Errorf("tsvector has %d unexpected trailing bytes", len(src)-rp)
+	}
+
+	return scanner.ScanTsvector(Tsvector{Lexemes: lexemes, Valid: true})
+}
+
+type scanPlanTextAnyToTsvectorScanner struct{}
+
+func (scanPlanTextAnyToTsvectorScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(TsvectorScanner)
+
+	if src == nil {
+		return scanner.ScanTsvector(Tsvector{})
+	}
+
+	tsvector, err := parseTsvector(string(src))
+	if err != nil {
+		return err
+	}
+
+	return scanner.ScanTsvector(tsvector)
+}
+
+func isTsSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
+
+// tsParser parses the text format of tsvector and tsquery.
+type tsParser struct {
+	src string
+	pos int
+}
+
+func (p *tsParser) skipSpace() {
+	for p.pos < len(p.src) && isTsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *tsParser) atEnd() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tsParser) peek() byte {
+	if p.atEnd() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// word parses a quoted or unquoted lexeme or operand. Unquoted words end at whitespace or any byte in stop.
+func (p *tsParser) word(stop string) (string, error) {
+	if p.peek() == '\'' {
+		p.pos++
+		var sb strings.Builder
+		for {
+			if p.atEnd() {
+				return "", fmt.Errorf("unterminated quoted string in %q", p.src)
+			}
+			b := p.src[p.pos]
+			p.pos++
+			switch b {
+			case '\\':
+				if p.atEnd() {
+					return "", fmt.Errorf("unterminated quoted string in %q", p.src)
+				}
+				sb.WriteByte(p.src[p.pos])
+				p.pos++
+			case '\'':
+				if p.peek() == '\'' {
+					sb.WriteByte('\'')
+					p.pos++
+				} else {
+					return sb.String(), nil
+				}
+			default:
+				sb.WriteByte(b)
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for !p.atEnd() {
+		b := p.src[p.pos]
+		if isTsSpace(b) || strings.IndexByte(stop, b) != -1 {
+			break
+		}
+		if b == '\\' && p.pos+1 < len(p.src) {
+			p.pos++
+			b = p.src[p.pos]
+		}
+		sb.WriteByte(b)
+		p.pos++
+	}
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("expected word at position %d in %q", p.pos, p.src)
+	}
+	return sb.String(), nil
+}
+
+func parseTsvector(src string) (Tsvector, error) {
+	p := &tsParser{src: src}
+	tsvector := Tsvector{Valid: true}
+
+	for {
+		p.skipSpace()
+		if p.atEnd() {
+			break
+		}
+
+		lexeme, err := p.word(":")
+		if err != nil {
+			return Tsvector{}, err
+		}
+		l := TsvectorLexeme{Lexeme: lexeme}
+
+		if p.peek() == ':' {
+			p.pos++
+			for {
+				start := p.pos
+				for !p.atEnd() && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+					p.pos++
+				}
+				n, err := strconv.ParseUint(src[start:p.pos], 10, 16)
+				if err != nil {
+					return Tsvector{}, fmt.Errorf("invalid tsvector position in %q: %w", src, err)
+				}
+
+				weight := byte(tsvectorDefaultWeight)
+				if !p.atEnd() {
+					if _, err := tsvectorWeightBits(p.src[p.pos]); err == nil {
+						weight = p.src[p.pos] &^ 0x20 // upper case
+						p.pos++
+					}
+				}
+
+				// PostgreSQL clamps positions to the maximum.
+				if n > tsvectorMaxPosition {
+					n = tsvectorMaxPosition
+				}
+				l.Positions = append(l.Positions, uint16(n))
+				if weight != tsvectorDefaultWeight && l.Weights == nil {
+					l.Weights = bytes.Repeat([]byte{tsvectorDefaultWeight}, len(l.Positions)-1)
+				}
+				if l.Weights != nil {
+					l.Weights = append(l.Weights, weight)
+				}
+
+				if p.peek() != ',' {
+					break
+				}
+				p.pos++
+			}
+		}
+
+		if !p.atEnd() && !isTsSpace(p.peek()) {
+			return Tsvector{}, fmt.Errorf("unexpected %q at position %d in %q", p.peek(), p.pos, src)
+		}
+
+		tsvector.Lexemes = append(tsvector.Lexemes, l)
+	}
+
+	return tsvector, nil
+}
@@ -0,0 +1,105 @@
+package pgtype_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func isExpectedEqTsvector(a any) func(any) bool {
+	return func(v any) bool {
+		return reflect.DeepEqual(a, v)
+	}
+}
+
+func TestTsvectorCodec(t *testing.T) {
+	skipCockroachDB(t, "Server does not support tsvector type")
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "tsvector", []pgxtest.ValueRoundTripTest{
+		{
+			pgtype.Tsvector{Valid: true},
+			new(pgtype.Tsvector),
+			isExpectedEqTsvector(pgtype.Tsvector{Valid: true}),
+		},
+		{
+			pgtype.Tsvector{Lexemes: []pgtype.TsvectorLexeme{{Lexeme: "cat"}, {Lexeme: "fat"}}, Valid: true},
+			new(pgtype.Tsvector),
+			isExpectedEqTsvector(pgtype.Tsvector{Lexemes: []pgtype.TsvectorLexeme{{Lexeme: "cat"}, {Lexeme: "fat"}}, Valid: true}),
+		},
+		{
+			pgtype.Tsvector{
+				Lexemes: []pgtype.TsvectorLexeme{
+					{Lexeme: "a", Positions: []uint16{1, 6}, Weights: []byte{'A', 'D'}},
+					{Lexeme: "it's", Positions: []uint16{2}, Weights: []byte{'B'}},
+					{Lexeme: "rat", Positions: []uint16{3, 4}},
+					{Lexeme: `sl\ash`, Positions: []uint16{5}, Weights: []byte{'C'}},
+				},
+				Valid: true,
+			},
+			new(pgtype.Tsvector),
+			isExpectedEqTsvector(pgtype.Tsvector{
+				Lexemes: []pgtype.TsvectorLexeme{
+					{Lexeme: "a", Positions: []uint16{1, 6}, Weights: []byte{'A', 'D'}},
+					{Lexeme: "it's", Positions: []uint16{2}, Weights: []byte{'B'}},
+					{Lexeme: "rat", Positions: []uint16{3, 4}},
+					{Lexeme: `sl\ash`, Positions: []uint16{5}, Weights: []byte{'C'}},
+				},
+				Valid: true,
+			}),
+		},
+		{pgtype.Tsvector{}, new(pgtype.Tsvector), isExpectedEqTsvector(pgtype.Tsvector{})},
+		{nil, new(pgtype.Tsvector), isExpectedEqTsvector(pgtype.Tsvector{})},
+	})
+}
+
+func TestTsvectorCodecWithoutServer(t *testing.T) {
+	m := pgtype.NewMap()
+
+	tsvector := pgtype.Tsvector{
+		Lexemes: []pgtype.TsvectorLexeme{
+			{Lexeme: "a", Positions: []uint16{1, 6}, Weights: []byte{'A', 'D'}},
+			{Lexeme: "it's", Positions: []uint16{2}, Weights: []byte{'B'}},
+			{Lexeme: "rat"},
+			{Lexeme: `sl\ash`, Positions: []uint16{5}},
+		},
+		Valid: true,
+	}
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		buf, err := m.Encode(pgtype.TsvectorOID, format, tsvector, nil)
+		require.NoError(t, err)
+
+		var got pgtype.Tsvector
+		err = m.Scan(pgtype.TsvectorOID, format, buf, &got)
+		require.NoError(t, err)
+		require.Equal(t, tsvector, got)
+	}
+
+	buf, err := m.Encode(pgtype.TsvectorOID, pgtype.TextFormatCode, tsvector, nil)
+	require.NoError(t, err)
+	require.Equal(t, `'a':1A,6 'it''s':2B 'rat' 'sl\\ash':5`, string(buf))
+}
+
+func TestTsvectorCodecScanText(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var got pgtype.Tsvector
+	err := m.Scan(pgtype.TsvectorOID, pgtype.TextFormatCode, []byte(`a:1a,3  fat:2B   'it''s' x\ y:20000`), &got)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.Tsvector{
+		Lexemes: []pgtype.TsvectorLexeme{
+			{Lexeme: "a", Positions: []uint16{1, 3}, Weights: []byte{'A', 'D'}},
+			{Lexeme: "fat", Positions: []uint16{2}, Weights: []byte{'B'}},
+			{Lexeme: "it's"},
+			{Lexeme: "x y", Positions: []uint16{16383}},
+		},
+		Valid: true,
+	}, got)
+
+	err = m.Scan(pgtype.TsvectorOID, pgtype.TextFormatCode, []byte(`'unterminated`), &got)
+	require.Error(t, err)
+}
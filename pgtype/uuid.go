@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/hex"
 	"fmt"
+	"strings"
 )
 
 type UUIDScanner interface {
@@ -15,11 +16,38 @@ type UUIDValuer interface {
 	UUIDValue() (UUID, error)
 }
 
+// UUIDValuePreferBinary causes UUID.Value to return the 16 byte binary representation instead of the text
+// representation. This reduces the amount of data sent when UUIDs are written through a database/sql driver that
+// calls Value and sends 16 bytes as a uuid. The pgx stdlib driver passes UUID through directly and already uses the
+// binary format when the parameter type is known. It should be set during program initialization and must not be
+// changed while values are being encoded.
+var UUIDValuePreferBinary bool
+
+// UUIDEncodeUppercase causes the text representation produced by the text format encoder, UUID.Value,
+// UUID.MarshalJSON, and UUID.MarshalText to use uppercase hex digits. Parsing is always case-insensitive. It should be
+// set during program initialization and must not be changed while values are being encoded.
+var UUIDEncodeUppercase bool
+
 type UUID struct {
 	Bytes [16]byte
 	Valid bool
 }
 
+// NewUUID returns a valid UUID with the given bytes.
+func NewUUID(b [16]byte) UUID {
+	return UUID{Bytes: b, Valid: true}
+}
+
+// MustParseUUID parses s and returns a valid UUID. It accepts the same formats as Scan. It panics if s cannot be
+// parsed. It is intended for use with known good literals such as in tests and initialization of package variables.
+func MustParseUUID(s string) UUID {
+	buf, err := parseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return UUID{Bytes: buf, Valid: true}
+}
+
 func (b *UUID) ScanUUID(v UUID) error {
 	*b = v
 	return nil
@@ -29,11 +57,87 @@ func (b UUID) UUIDValue() (UUID, error) {
 	return b, nil
 }
 
-// parseUUID converts a string UUID in standard form to a byte array.
+// uuidURNPrefix is the RFC 4122 URN namespace prefix. It is matched case-insensitively.
+const uuidURNPrefix = "urn:uuid:"
+
+// trimUUIDURNPrefix removes a leading "urn:uuid:" from src if present.
+func trimUUIDURNPrefix(src string) string {
+	if len(src) > len(uuidURNPrefix) && strings.EqualFold(src[:len(uuidURNPrefix)], uuidURNPrefix) {
+		return src[len(uuidURNPrefix):]
+	}
+	return src
+}
+
+// Equal returns true if src and other have the same value. Two invalid UUIDs are equal.
+func (src UUID) Equal(other UUID) bool {
+	if !src.Valid || !other.Valid {
+		return src.Valid == other.Valid
+	}
+
+	return src.Bytes == other.Bytes
+}
+
+// Compare returns -1, 0, or 1 depending on whether src sorts before, the same as, or after other. UUIDs are ordered
+// by their bytes. An invalid UUID sorts before any valid UUID.
+func (src UUID) Compare(other UUID) int {
+	switch {
+	case !src.Valid && !other.Valid:
+		return 0
+	case !src.Valid:
+		return -1
+	case !other.Valid:
+		return 1
+	}
+
+	return bytes.Compare(src.Bytes[:], other.Bytes[:])
+}
+
+// Version returns the version number stored in the high nibble of byte 6 (e.g. 4 for a random UUID or 7 for a
+// time-ordered UUID). It returns 0 if src is not valid.
+func (src UUID) Version() int {
+	if !src.Valid {
+		return 0
+	}
+
+	return int(src.Bytes[6] >> 4)
+}
+
+// Variant returns the variant field stored in the high bits of byte 8 as defined by RFC 9562. The result is 0 for the
+// NCS variant (0xx), 2 for the RFC 4122 / RFC 9562 variant (10x), 6 for the Microsoft variant (110), and 7 for the
+// reserved future variant (111). It returns 0 if src is not valid.
+func (src UUID) Variant() int {
+	if !src.Valid {
+		return 0
+	}
+
+	b := src.Bytes[8]
+	switch {
+	case b&0x80 == 0:
+		return 0
+	case b&0x40 == 0:
+		return 2
+	case b&0x20 == 0:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// parseUUID converts a string UUID in standard form to a byte array. The RFC 4122 URN form (urn:uuid:...) and the
+// brace-wrapped form ({...}) are also accepted.
 func parseUUID(src string) (dst [16]byte, err error) {
-	switch len(src) {
+	s := trimUUIDURNPrefix(src)
+
+	if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+		s = s[1:37]
+	}
+
+	switch len(s) {
 	case 36:
-		src = src[0:8] + src[9:13] + src[14:18] + src[19:23] + src[24:]
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return dst, fmt.Errorf("cannot parse UUID %v: expected dashes at positions 8, 13, 18, and 23", src)
+		}
+		s = s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
 	case 32:
 		// dashes already stripped, assume valid
 	default:
@@ -41,7 +145,7 @@ func parseUUID(src string) (dst [16]byte, err error) {
 		return dst, fmt.Errorf("cannot parse UUID %v", src)
 	}
 
-	buf, err := hex.DecodeString(src)
+	buf, err := hex.DecodeString(s)
 	if err != nil {
 		return dst, err
 	}
@@ -55,6 +159,28 @@ func encodeUUID(src [16]byte) string {
 	return fmt.Sprintf("%x-%x-%x-%x-%x", src[0:4], src[4:6], src[6:8], src[8:10], src[10:16])
 }
 
+// encodeUUIDUpper converts a uuid byte array to UUID standard string form with uppercase hex digits.
+func encodeUUIDUpper(src [16]byte) string {
+	return fmt.Sprintf("%X-%X-%X-%X-%X", src[0:4], src[4:6], src[6:8], src[8:10], src[10:16])
+}
+
+// encodeText returns the string form of src honoring UUIDEncodeUppercase.
+func (src UUID) encodeText() string {
+	if UUIDEncodeUppercase {
+		return encodeUUIDUpper(src.Bytes)
+	}
+	return encodeUUID(src.Bytes)
+}
+
+// StringUpper returns the standard string form of src with uppercase hex digits. It returns an empty string if src is
+// not valid.
+func (src UUID) StringUpper() string {
+	if !src.Valid {
+		return ""
+	}
+	return encodeUUIDUpper(src.Bytes)
+}
+
 // Scan implements the database/sql Scanner interface.
 func (dst *UUID) Scan(src any) error {
 	if src == nil {
@@ -70,6 +196,22 @@ func (dst *UUID) Scan(src any) error {
 		}
 		*dst = UUID{Bytes: buf, Valid: true}
 		return nil
+	case []byte:
+		// A 16 byte slice is the binary format. Anything else is assumed to be text.
+		if len(src) == 16 {
+			*dst = UUID{Valid: true}
+			copy(dst.Bytes[:], src)
+			return nil
+		}
+		buf, err := parseUUID(string(src))
+		if err != nil {
+			return err
+		}
+		*dst = UUID{Bytes: buf, Valid: true}
+		return nil
+	case [16]byte:
+		*dst = UUID{Bytes: src, Valid: true}
+		return nil
 	}
 
 	return fmt.Errorf("cannot scan %T", src)
@@ -81,7 +223,21 @@ func (src UUID) Value() (driver.Value, error) {
 		return nil, nil
 	}
 
-	return encodeUUID(src.Bytes), nil
+	if UUIDValuePreferBinary {
+		return src.Bytes[:], nil
+	}
+
+	return src.encodeText(), nil
+}
+
+// Key returns a string that identifies the value for use as a map key. Equal values have the same key. NULL has the key
+// "" which no valid value has.
+func (src UUID) Key() string {
+	if !src.Valid {
+		return ""
+	}
+
+	return encodeUUID(src.Bytes)
 }
 
 func (src UUID) MarshalJSON() ([]byte, error) {
@@ -91,7 +247,7 @@ func (src UUID) MarshalJSON() ([]byte, error) {
 
 	var buff bytes.Buffer
 	buff.WriteByte('"')
-	buff.WriteString(encodeUUID(src.Bytes))
+	buff.WriteString(src.encodeText())
 	buff.WriteByte('"')
 	return buff.Bytes(), nil
 }
@@ -101,10 +257,14 @@ func (dst *UUID) UnmarshalJSON(src []byte) error {
 		*dst = UUID{}
 		return nil
 	}
-	if len(src) != 38 {
+	if len(src) < 2 || src[0] != '"' || src[len(src)-1] != '"' {
 		return fmt.Errorf("invalid length for UUID: %v", len(src))
 	}
-	buf, err := parseUUID(string(src[1 : len(src)-1]))
+	s := trimUUIDURNPrefix(string(src[1 : len(src)-1]))
+	if len(s) != 36 && len(s) != 32 {
+		return fmt.Errorf("invalid length for UUID: %v", len(src))
+	}
+	buf, err := parseUUID(s)
 	if err != nil {
 		return err
 	}
@@ -112,6 +272,25 @@ func (dst *UUID) UnmarshalJSON(src []byte) error {
 	return nil
 }
 
+// MarshalText implements the encoding.TextMarshaler interface. An invalid UUID is marshaled as empty text.
+func (src UUID) MarshalText() ([]byte, error) {
+	if !src.Valid {
+		return []byte{}, nil
+	}
+
+	return []byte(src.encodeText()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. Empty text unmarshals to an invalid UUID.
+func (dst *UUID) UnmarshalText(src []byte) error {
+	if len(src) == 0 {
+		*dst = UUID{}
+		return nil
+	}
+
+	return dst.Scan(string(src))
+}
+
 type UUIDCodec struct{}
 
 func (UUIDCodec) FormatSupported(format int16) bool {
@@ -164,7 +343,7 @@ func (encodePlanUUIDCodecTextUUIDValuer) Encode(value any, buf []byte) (newBuf [
 		return nil, nil
 	}
 
-	return append(buf, encodeUUID(uuid.Bytes)...), nil
+	return append(buf, uuid.encodeText()...), nil
 }
 
 func (UUIDCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
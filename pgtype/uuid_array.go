@@ -0,0 +1,259 @@
+package pgtype
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/internal/pgio"
+)
+
+// UUIDArray is a one dimensional uuid[]. A nil UUIDArray is NULL. It implements the ArrayGetter and ArraySetter
+// interfaces so it can be used with any array codec, but UUIDArrayCodec encodes and scans it in the binary format
+// without per element planning.
+type UUIDArray []UUID
+
+func (a UUIDArray) Dimensions() []ArrayDimension {
+	if a == nil {
+		return nil
+	}
+
+	return []ArrayDimension{{Length: int32(len(a)), LowerBound: 1}}
+}
+
+func (a UUIDArray) Index(i int) any {
+	return a[i]
+}
+
+func (a UUIDArray) IndexType() any {
+	return UUID{}
+}
+
+func (a *UUIDArray) SetDimensions(dimensions []ArrayDimension) error {
+	if dimensions == nil {
+		*a = nil
+		return nil
+	}
+
+	*a = make(UUIDArray, cardinality(dimensions))
+	return nil
+}
+
+func (a UUIDArray) ScanIndex(i int) any {
+	return &a[i]
+}
+
+func (a UUIDArray) ScanIndexType() any {
+	return new(UUID)
+}
+
+// UUIDArrayCodec is a codec for uuid[]. In the binary format [][16]byte, []UUID, UUIDArray, and []string are encoded
+// and *[][16]byte, *[]UUID, and *UUIDArray are scanned directly. All other values and the text format are handled by
+// the embedded ArrayCodec.
+type UUIDArrayCodec struct {
+	*ArrayCodec
+}
+
+func (c *UUIDArrayCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	if format == BinaryFormatCode {
+		switch value.(type) {
+		case [][16]byte, []UUID, UUIDArray, []string:
+			return encodePlanUUIDArrayCodecBinary{}
+		}
+	}
+
+	return c.ArrayCodec.PlanEncode(m, oid, format, value)
+}
+
+type encodePlanUUIDArrayCodecBinary struct{}
+
+func (encodePlanUUIDArrayCodecBinary) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	switch value := value.(type) {
+	case [][16]byte:
+		if value == nil {
+			return nil, nil
+		}
+
+		buf = appendUUIDArrayHeader(buf, len(value), false)
+		for i := range value {
+			buf = pgio.AppendInt32(buf, 16)
+			buf = append(buf, value[i][:]...)
+		}
+		return buf, nil
+	case []UUID:
+		return appendUUIDArrayBinary(buf, value), nil
+	case UUIDArray:
+		return appendUUIDArrayBinary(buf, value), nil
+	case []string:
+		if value == nil {
+			return nil, nil
+		}
+
+		buf = appendUUIDArrayHeader(buf, len(value), false)
+		for i := range value {
+			uuid, err := parseUUID(value[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode array element %d: %w", i, err)
+			}
+			buf = pgio.AppendInt32(buf, 16)
+			buf = append(buf, uuid[:]...)
+		}
+		return buf, nil
+	default:
+		return nil, fmt.Errorf("unable to encode %T as uuid[]", value)
+	}
+}
+
+// appendUUIDArrayHeader appends a one dimensional uuid[] header for n elements to buf. buf is grown once for the
+// header and all elements.
+func appendUUIDArrayHeader(buf []byte, n int, containsNull bool) []byte {
+	need := 20 + n*20
+	if cap(buf)-len(buf) < need {
+		newBuf := make([]byte, len(buf), len(buf)+need)
+		copy(newBuf, buf)
+		buf = newBuf
+	}
+
+	header := arrayHeader{
+		ContainsNull: containsNull,
+		ElementOID:   UUIDOID,
+		Dimensions:   []ArrayDimension{{Length: int32(n), LowerBound: 1}},
+	}
+	return header.EncodeBinary(buf)
+}
+
+func appendUUIDArrayBinary(buf []byte, src []UUID) []byte {
+	if src == nil {
+		return nil
+	}
+
+	containsNull := false
+	for i := range src {
+		if !src[i].Valid {
+			containsNull = true
+			break
+		}
+	}
+
+	buf = appendUUIDArrayHeader(buf, len(src), containsNull)
+	for i := range src {
+		if !src[i].Valid {
+			buf = pgio.AppendInt32(buf, -1)
+			continue
+		}
+		buf = pgio.AppendInt32(buf, 16)
+		buf = append(buf, src[i].Bytes[:]...)
+	}
+	return buf
+}
+
+func (c *UUIDArrayCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	if format == BinaryFormatCode {
+		switch target.(type) {
+		case *[][16]byte, *[]UUID, *UUIDArray:
+			return &scanPlanBinaryUUIDArray{m: m}
+		}
+	}
+
+	return c.ArrayCodec.PlanScan(m, oid, format, target)
+}
+
+type scanPlanBinaryUUIDArray struct {
+	m *Map
+}
+
+func (p *scanPlanBinaryUUIDArray) Scan(src []byte, dst any) error {
+	if src == nil {
+		switch dst := dst.(type) {
+		case *[][16]byte:
+			*dst = nil
+		case *[]UUID:
+			*dst = nil
+		case *UUIDArray:
+			*dst = nil
+		}
+		return nil
+	}
+
+	var header arrayHeader
+	rp, err := header.DecodeBinary(p.m, src)
+	if err != nil {
+		return err
+	}
+
+	// Multidimensional arrays are flattened the same as FlatArray.
+	elementCount := cardinality(header.Dimensions)
+	if elementCount < 0 || elementCount > (len(src)-rp)/4 {
+		return fmt.Errorf("array too short for %d elements: %d", elementCount, len(src))
+	}
+
+	switch dst := dst.(type) {
+	case *[][16]byte:
+		a := make([][16]byte, elementCount)
+		for i := range a {
+			var elemSrc []byte
+			elemSrc, rp, err = nextUUIDArrayElement(src, rp, i)
+			if err != nil {
+				return err
+			}
+			if elemSrc == nil {
+				return fmt.Errorf("failed to scan array element %d: cannot scan NULL into *[16]byte", i)
+			}
+			copy(a[i][:], elemSrc)
+		}
+		*dst = a
+	case *[]UUID:
+		a, err := scanUUIDArrayBinaryElements(src, rp, elementCount)
+		if err != nil {
+			return err
+		}
+		*dst = a
+	case *UUIDArray:
+		a, err := scanUUIDArrayBinaryElements(src, rp, elementCount)
+		if err != nil {
+			return err
+		}
+		*dst = a
+	default:
+		return fmt.Errorf("cannot scan uuid[] into %T", dst)
+	}
+
+	return nil
+}
+
+func scanUUIDArrayBinaryElements(src []byte, rp int, elementCount int) ([]UUID, error) {
+	a := make([]UUID, elementCount)
+	for i := range a {
+		var elemSrc []byte
+		var err error
+		elemSrc, rp, err = nextUUIDArrayElement(src, rp, i)
+		if err != nil {
+			return nil, err
+		}
+		if elemSrc != nil {
+			a[i].Valid = true
+			copy(a[i].Bytes[:], elemSrc)
+		}
+	}
+	return a, nil
+}
+
+// nextUUIDArrayElement reads the binary element i starting at rp. It returns a nil elemSrc for a NULL element.
+func nextUUIDArrayElement(src []byte, rp int, i int) (elemSrc []byte, newRP int, err error) {
+	if len(src)-rp < 4 {
+		return nil, 0, fmt.Errorf("failed to scan array element %d: array too short", i)
+	}
+	elemLen := int(int32(binary.BigEndian.Uint32(src[rp:])))
+	rp += 4
+
+	if elemLen == -1 {
+		return nil, rp, nil
+	}
+	if elemLen != 16 {
+		return nil, 0, fmt.Errorf("failed to scan array element %d: invalid length for UUID: %v", i, elemLen)
+	}
+	if len(src)-rp < 16 {
+		return nil, 0, fmt.Errorf("failed to scan array element %d: array too short", i)
+	}
+
+	return src[rp : rp+16], rp + 16, nil
+}
@@ -0,0 +1,125 @@
+package pgtype_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUIDArrayCodec(t *testing.T) {
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "uuid[]", []pgxtest.ValueRoundTripTest{
+		{
+			[][16]byte{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, {15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+			new([][16]byte),
+			isExpectedEq([][16]byte{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, {15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}}),
+		},
+		{
+			pgtype.UUIDArray{{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}, {}},
+			new(pgtype.UUIDArray),
+			isExpectedEq(pgtype.UUIDArray{{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}, {}}),
+		},
+		{
+			[]string{"00010203-0405-0607-0809-0a0b0c0d0e0f"},
+			new([]pgtype.UUID),
+			isExpectedEq([]pgtype.UUID{{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}}),
+		},
+		{[][16]byte{}, new([][16]byte), isExpectedEq([][16]byte{})},
+		{pgtype.UUIDArray(nil), new(pgtype.UUIDArray), isExpectedEq(pgtype.UUIDArray(nil))},
+		{nil, new([][16]byte), isExpectedEq([][16]byte(nil))},
+	})
+}
+
+func TestUUIDArrayCodecEncodeBinary(t *testing.T) {
+	expected := []byte{
+		0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0xb, 0x86, 0, 0, 0, 2, 0, 0, 0, 1,
+		0, 0, 0, 16,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		0, 0, 0, 16,
+		15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+
+	m := pgtype.NewMap()
+
+	for i, v := range []any{
+		[][16]byte{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, {15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+		[]pgtype.UUID{
+			{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true},
+			{Bytes: [16]byte{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, Valid: true},
+		},
+		pgtype.UUIDArray{
+			{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true},
+			{Bytes: [16]byte{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, Valid: true},
+		},
+		[]string{"00010203-0405-0607-0809-0a0b0c0d0e0f", "0f0e0d0c-0b0a-0908-0706-050403020100"},
+		pgtype.FlatArray[[16]byte]{{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, {15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}},
+	} {
+		buf, err := m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, v, nil)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, expected, buf, "%d", i)
+	}
+
+	buf, err := m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, []pgtype.UUID{{}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0xb, 0x86, 0, 0, 0, 1, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff}, buf)
+
+	buf, err = m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, [][16]byte(nil), nil)
+	require.NoError(t, err)
+	require.Nil(t, buf)
+
+	_, err = m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, []string{"invalid"}, nil)
+	require.Error(t, err)
+}
+
+func TestUUIDArrayCodecScanBinary(t *testing.T) {
+	src := []byte{
+		0, 0, 0, 1, 0, 0, 0, 1, 0, 0, 0xb, 0x86, 0, 0, 0, 2, 0, 0, 0, 1,
+		0, 0, 0, 16,
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		0xff, 0xff, 0xff, 0xff}
+
+	m := pgtype.NewMap()
+
+	var uuids []pgtype.UUID
+	err := m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, src, &uuids)
+	require.NoError(t, err)
+	require.Equal(t, []pgtype.UUID{{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}, {}}, uuids)
+
+	var uuidArray pgtype.UUIDArray
+	err = m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, src, &uuidArray)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.UUIDArray(uuids), uuidArray)
+
+	var byteArrays [][16]byte
+	err = m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, src, &byteArrays)
+	require.ErrorContains(t, err, "cannot scan NULL into *[16]byte")
+
+	err = m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, src[:len(src)-4], &byteArrays)
+	require.ErrorContains(t, err, "array too short")
+
+	err = m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, nil, &uuidArray)
+	require.NoError(t, err)
+	require.Nil(t, uuidArray)
+}
+
+func BenchmarkUUIDArrayCodecScanBinaryIntoByteArrays(b *testing.B) {
+	m := pgtype.NewMap()
+	uuids := make([][16]byte, 1000)
+	for i := range uuids {
+		uuids[i][15] = byte(i)
+	}
+	src, err := m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, uuids, nil)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var dst [][16]byte
+		err := m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, src, &dst)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -117,6 +117,33 @@ func TestUUID_UnmarshalJSON(t *testing.T) {
 			src:     []byte("1d485a7a-6d18-4599-8c6c-34425616887a"),
 			wantErr: true,
 		},
+		{
+			name: "urn",
+			want: &pgtype.UUID{
+				Bytes: [16]byte{29, 72, 90, 122, 109, 24, 69, 153, 140, 108, 52, 66, 86, 22, 136, 122},
+				Valid: true,
+			},
+			src:     []byte(`"URN:UUID:1d485a7a-6d18-4599-8c6c-34425616887a"`),
+			wantErr: false,
+		},
+		{
+			name: "without dashes",
+			want: &pgtype.UUID{
+				Bytes: [16]byte{29, 72, 90, 122, 109, 24, 69, 153, 140, 108, 52, 66, 86, 22, 136, 122},
+				Valid: true,
+			},
+			src:     []byte(`"1d485a7a6d1845998c6c34425616887a"`),
+			wantErr: false,
+		},
+		{
+			name: "wrong length",
+			want: &pgtype.UUID{
+				Bytes: [16]byte{},
+				Valid: false,
+			},
+			src:     []byte(`"1d485a7a6d1845998c6c34425616887"`),
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -130,3 +157,267 @@ func TestUUID_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestUUIDScanURN(t *testing.T) {
+	var uuid pgtype.UUID
+	err := uuid.Scan("urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	require.NoError(t, err)
+	require.Equal(t, pgtype.UUID{Bytes: [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, Valid: true}, uuid)
+
+	v, err := uuid.Value()
+	require.NoError(t, err)
+	require.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", v)
+
+	m := pgtype.NewMap()
+	err = m.Scan(pgtype.UUIDOID, pgtype.TextFormatCode, []byte("Urn:Uuid:6BA7B8109DAD11D180B400C04FD430C8"), &uuid)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.UUID{Bytes: [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, Valid: true}, uuid)
+
+	err = uuid.Scan("urn:uuid:")
+	require.Error(t, err)
+}
+
+func TestUUIDScanBraces(t *testing.T) {
+	expected := pgtype.UUID{Bytes: [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, Valid: true}
+
+	var uuid pgtype.UUID
+	err := uuid.Scan("{6ba7b810-9dad-11d1-80b4-00c04fd430c8}")
+	require.NoError(t, err)
+	require.Equal(t, expected, uuid)
+
+	m := pgtype.NewMap()
+	uuid = pgtype.UUID{}
+	err = m.Scan(pgtype.UUIDOID, pgtype.TextFormatCode, []byte("{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"), &uuid)
+	require.NoError(t, err)
+	require.Equal(t, expected, uuid)
+
+	for _, s := range []string{
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8]",
+		"(6ba7b810-9dad-11d1-80b4-00c04fd430c8)",
+		"{6ba7b8109dad11d180b400c04fd430c8}",
+	} {
+		err = uuid.Scan(s)
+		require.Errorf(t, err, "%s", s)
+	}
+}
+
+func TestUUIDMarshalText(t *testing.T) {
+	uuid := pgtype.UUID{Bytes: [16]byte{29, 72, 90, 122, 109, 24, 69, 153, 140, 108, 52, 66, 86, 22, 136, 122}, Valid: true}
+	buf, err := uuid.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("1d485a7a-6d18-4599-8c6c-34425616887a"), buf)
+
+	buf, err = pgtype.UUID{}.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte{}, buf)
+}
+
+func TestUUIDUnmarshalText(t *testing.T) {
+	var uuid pgtype.UUID
+	err := uuid.UnmarshalText([]byte("1d485a7a-6d18-4599-8c6c-34425616887a"))
+	require.NoError(t, err)
+	require.Equal(t, pgtype.UUID{Bytes: [16]byte{29, 72, 90, 122, 109, 24, 69, 153, 140, 108, 52, 66, 86, 22, 136, 122}, Valid: true}, uuid)
+
+	err = uuid.UnmarshalText([]byte{})
+	require.NoError(t, err)
+	require.Equal(t, pgtype.UUID{}, uuid)
+
+	err = uuid.UnmarshalText([]byte("invalid"))
+	require.Error(t, err)
+}
+
+func TestUUIDScanInvalidDashPositions(t *testing.T) {
+	for _, s := range []string{
+		"6ba7b810X9dadX11d1X80b4X00c04fd430c8",
+		"6ba7b8109-dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b400-c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4000c04fd430c8}",
+	} {
+		var uuid pgtype.UUID
+		err := uuid.Scan(s)
+		require.ErrorContainsf(t, err, "expected dashes", "%s", s)
+		require.Equal(t, pgtype.UUID{}, uuid)
+	}
+}
+
+func TestUUIDValue(t *testing.T) {
+	uuid := pgtype.UUID{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}
+	v, err := uuid.Value()
+	require.NoError(t, err)
+	require.Equal(t, "00010203-0405-0607-0809-0a0b0c0d0e0f", v)
+
+}
+
+func TestUUIDValuePreferBinary(t *testing.T) {
+	pgtype.UUIDValuePreferBinary = true
+	defer func() { pgtype.UUIDValuePreferBinary = false }()
+
+	uuid := pgtype.UUID{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}
+	v, err := uuid.Value()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, v)
+
+	var scanned pgtype.UUID
+	err = scanned.Scan(v)
+	require.NoError(t, err)
+	require.Equal(t, uuid, scanned)
+
+	v, err = pgtype.UUID{}.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func TestUUIDScan(t *testing.T) {
+	expected := pgtype.UUID{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}
+
+	for i, src := range []any{
+		"00010203-0405-0607-0809-0a0b0c0d0e0f",
+		[]byte("00010203-0405-0607-0809-0a0b0c0d0e0f"),
+		[]byte("000102030405060708090a0b0c0d0e0f"),
+		[]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		[16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	} {
+		var uuid pgtype.UUID
+		err := uuid.Scan(src)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, expected, uuid, "%d", i)
+	}
+
+	uuid := expected
+	err := uuid.Scan(nil)
+	require.NoError(t, err)
+	require.Equal(t, pgtype.UUID{}, uuid)
+
+	err = uuid.Scan([]byte{0, 1, 2})
+	require.Error(t, err)
+
+	err = uuid.Scan(42)
+	require.Error(t, err)
+}
+
+func TestUUIDEqual(t *testing.T) {
+	a := pgtype.UUID{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}
+	b := pgtype.UUID{Bytes: [16]byte{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, Valid: true}
+
+	require.True(t, a.Equal(a))
+	require.False(t, a.Equal(b))
+	require.True(t, pgtype.UUID{}.Equal(pgtype.UUID{Bytes: a.Bytes}))
+	require.False(t, a.Equal(pgtype.UUID{Bytes: a.Bytes}))
+	require.False(t, pgtype.UUID{Bytes: a.Bytes}.Equal(a))
+}
+
+func TestUUIDCompare(t *testing.T) {
+	a := pgtype.UUID{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}
+	b := pgtype.UUID{Bytes: [16]byte{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, Valid: true}
+
+	require.Equal(t, 0, a.Compare(a))
+	require.Equal(t, -1, a.Compare(b))
+	require.Equal(t, 1, b.Compare(a))
+	require.Equal(t, 0, pgtype.UUID{}.Compare(pgtype.UUID{Bytes: b.Bytes}))
+	require.Equal(t, -1, pgtype.UUID{}.Compare(a))
+	require.Equal(t, 1, a.Compare(pgtype.UUID{}))
+}
+
+func TestUUIDVersionAndVariant(t *testing.T) {
+	for i, tt := range []struct {
+		src     string
+		version int
+		variant int
+	}{
+		{src: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", version: 1, variant: 2},
+		{src: "1d485a7a-6d18-4599-8c6c-34425616887a", version: 4, variant: 2},
+		{src: "01890a5d-ac96-774b-bcce-b302099a8057", version: 7, variant: 2},
+		{src: "00000000-0000-0000-0000-000000000000", version: 0, variant: 0},
+		{src: "00000000-0000-0000-c000-000000000000", version: 0, variant: 6},
+		{src: "00000000-0000-0000-ffff-000000000000", version: 0, variant: 7},
+	} {
+		var uuid pgtype.UUID
+		err := uuid.Scan(tt.src)
+		require.NoErrorf(t, err, "%d", i)
+		require.Equalf(t, tt.version, uuid.Version(), "%d", i)
+		require.Equalf(t, tt.variant, uuid.Variant(), "%d", i)
+	}
+
+	invalid := pgtype.UUID{Bytes: [16]byte{6: 0x40, 8: 0x80}}
+	require.Equal(t, 0, invalid.Version())
+	require.Equal(t, 0, invalid.Variant())
+}
+
+func TestNewUUID(t *testing.T) {
+	uuid := pgtype.NewUUID([16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+	require.Equal(t, pgtype.UUID{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}, uuid)
+}
+
+func TestMustParseUUID(t *testing.T) {
+	uuid := pgtype.MustParseUUID("00010203-0405-0607-0809-0a0b0c0d0e0f")
+	require.Equal(t, pgtype.UUID{Bytes: [16]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}, Valid: true}, uuid)
+
+	require.Panics(t, func() { pgtype.MustParseUUID("invalid") })
+}
+
+func TestUUIDEncodeUppercase(t *testing.T) {
+	uuid := pgtype.UUID{Bytes: [16]byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, Valid: true}
+	require.Equal(t, "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", uuid.StringUpper())
+	require.Equal(t, "", pgtype.UUID{}.StringUpper())
+
+	pgtype.UUIDEncodeUppercase = true
+	defer func() { pgtype.UUIDEncodeUppercase = false }()
+
+	buf, err := uuid.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, []byte(`"6BA7B810-9DAD-11D1-80B4-00C04FD430C8"`), buf)
+
+	buf, err = uuid.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, []byte("6BA7B810-9DAD-11D1-80B4-00C04FD430C8"), buf)
+
+	v, err := uuid.Value()
+	require.NoError(t, err)
+	require.Equal(t, "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", v)
+
+	m := pgtype.NewMap()
+	buf, err = m.Encode(pgtype.UUIDOID, pgtype.TextFormatCode, uuid, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("6BA7B810-9DAD-11D1-80B4-00C04FD430C8"), buf)
+
+	var decoded pgtype.UUID
+	err = m.Scan(pgtype.UUIDOID, pgtype.TextFormatCode, buf, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, uuid, decoded)
+}
+
+type uuidWrapper struct {
+	pgtype.UUID
+}
+
+func TestUUIDCodecScanNull(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, format := range []int16{pgtype.TextFormatCode, pgtype.BinaryFormatCode} {
+		bs := []byte{1}
+		err := m.Scan(pgtype.UUIDOID, format, nil, &bs)
+		require.NoError(t, err)
+		require.Nil(t, bs)
+
+		s := new(string)
+		err = m.Scan(pgtype.UUIDOID, format, nil, &s)
+		require.NoError(t, err)
+		require.Nil(t, s)
+
+		b := new([16]byte)
+		err = m.Scan(pgtype.UUIDOID, format, nil, &b)
+		require.NoError(t, err)
+		require.Nil(t, b)
+
+		w := uuidWrapper{pgtype.UUID{Bytes: [16]byte{1}, Valid: true}}
+		err = m.Scan(pgtype.UUIDOID, format, nil, &w)
+		require.NoError(t, err)
+		require.Equal(t, uuidWrapper{}, w)
+
+		var arr [16]byte
+		err = m.Scan(pgtype.UUIDOID, format, nil, &arr)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,244 @@
+package pgtype
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+type XMLScanner interface {
+	ScanXML(v XML) error
+}
+
+type XMLValuer interface {
+	XMLValue() (XML, error)
+}
+
+// XML represents the PostgreSQL xml type. Bytes holds the XML document or content fragment as text.
+//
+// An xml value can also be scanned into or encoded from a string or []byte.
+type XML struct {
+	Bytes []byte
+	Valid bool
+}
+
+func (x *XML) ScanXML(v XML) error {
+	*x = v
+	return nil
+}
+
+func (x XML) XMLValue() (XML, error) {
+	return x, nil
+}
+
+// String returns the XML as a string.
+func (x XML) String() string {
+	return string(x.Bytes)
+}
+
+// Scan implements the database/sql Scanner interface.
+func (x *XML) Scan(src any) error {
+	if src == nil {
+		*x = XML{}
+		return nil
+	}
+
+	switch src := src.(type) {
+	case string:
+		*x = XML{Bytes: []byte(src), Valid: true}
+		return nil
+	case []byte:
+		*x = XML{Bytes: append([]byte{}, src...), Valid: true}
+		return nil
+	}
+
+	return fmt.Errorf("cannot scan %T", src)
+}
+
+// Value implements the database/sql/driver Valuer interface.
+func (x XML) Value() (driver.Value, error) {
+	if !x.Valid {
+		return nil, nil
+	}
+
+	return string(x.Bytes), nil
+}
+
+// XMLCodec is a codec for the PostgreSQL xml type. Only the text format is supported because the binary format depends
+// on the encoding declared by the document.
+//
+// xml values decode to the same text whatever they are scanned into. DecodeValue and DecodeDatabaseSQLValue return
+// that text as a string, the same as scanning into a *string. So Rows.Values, scanning into *any, and database/sql
+// still return a string, as they did before xml was registered by default. Scan into an XML to keep the value typed.
+type XMLCodec struct {
+	// ValidateOnEncode checks that values are well-formed XML documents or content fragments before they are sent
+	// instead of relying on the server to reject them.
+	ValidateOnEncode bool
+}
+
+func (XMLCodec) FormatSupported(format int16) bool {
+	return format == TextFormatCode
+}
+
+func (XMLCodec) PreferredFormat() int16 {
+	return TextFormatCode
+}
+
+func (c XMLCodec) validatesText() bool {
+	return c.ValidateOnEncode
+}
+
+func (c XMLCodec) PlanEncode(m *Map, oid uint32, format int16, value any) EncodePlan {
+	if format != TextFormatCode {
+		return nil
+	}
+
+	var plan EncodePlan
+	switch value.(type) {
+	case XMLValuer:
+		plan = encodePlanXMLCodecXMLValuer{}
+	case string:
+		plan = encodePlanXMLCodecString{}
+	case []byte:
+		plan = encodePlanXMLCodecByteSlice{}
+	case TextValuer:
+		plan = encodePlanXMLCodecTextValuer{}
+	default:
+		return nil
+	}
+
+	if c.ValidateOnEncode {
+		return &encodePlanXMLCodecValidate{next: plan}
+	}
+
+	return plan
+}
+
+type encodePlanXMLCodecXMLValuer struct{}
+
+func (encodePlanXMLCodecXMLValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	x, err := value.(XMLValuer).XMLValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !x.Valid {
+		return nil, nil
+	}
+
+	return append(buf, x.Bytes...), nil
+}
+
+type encodePlanXMLCodecString struct{}
+
+func (encodePlanXMLCodecString) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	return append(buf, value.(string)...), nil
+}
+
+type encodePlanXMLCodecByteSlice struct{}
+
+func (encodePlanXMLCodecByteSlice) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	b := value.([]byte)
+	if b == nil {
+		return nil, nil
+	}
+
+	return append(buf, b...), nil
+}
+
+type encodePlanXMLCodecTextValuer struct{}
+
+func (encodePlanXMLCodecTextValuer) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	text, err := value.(TextValuer).TextValue()
+	if err != nil {
+		return nil, err
+	}
+
+	if !text.Valid {
+		return nil, nil
+	}
+
+	return append(buf, text.String...), nil
+}
+
+// encodePlanXMLCodecValidate checks that the XML written by next is well-formed.
+type encodePlanXMLCodecValidate struct {
+	next EncodePlan
+}
+
+func (plan *encodePlanXMLCodecValidate) Encode(value any, buf []byte) (newBuf []byte, err error) {
+	sp := len(buf)
+	newBuf, err = plan.next.Encode(value, buf)
+	if err != nil || newBuf == nil {
+		return newBuf, err
+	}
+
+	if err := validateXML(newBuf[sp:]); err != nil {
+		return nil, err
+	}
+
+	return newBuf, nil
+}
+
+// validateXML returns an error if src is not a well-formed XML document or content fragment.
+func validateXML(src []byte) error {
+	decoder := xml.NewDecoder(bytes.NewReader(src))
+	// Text format values are always in the client encoding regardless of the encoding the document declares.
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	for {
+		_, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid xml: %w", err)
+		}
+	}
+}
+
+func (XMLCodec) PlanScan(m *Map, oid uint32, format int16, target any) ScanPlan {
+	if format != TextFormatCode {
+		return nil
+	}
+
+	switch target.(type) {
+	case XMLScanner:
+		return scanPlanTextAnyToXMLScanner{}
+	case *string:
+		return scanPlanString{}
+	case *[]byte:
+		return scanPlanAnyTextToBytes{}
+	case TextScanner:
+		return scanPlanTextAnyToTextScanner{}
+	}
+
+	return nil
+}
+
+func (c XMLCodec) DecodeDatabaseSQLValue(m *Map, oid uint32, format int16, src []byte) (driver.Value, error) {
+	return c.DecodeValue(m, oid, format, src)
+}
+
+func (c XMLCodec) DecodeValue(m *Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	return string(src), nil
+}
+
+type scanPlanTextAnyToXMLScanner struct{}
+
+func (scanPlanTextAnyToXMLScanner) Scan(src []byte, dst any) error {
+	scanner := (dst).(XMLScanner)
+
+	if src == nil {
+		return scanner.ScanXML(XML{})
+	}
+
+	return scanner.ScanXML(XML{Bytes: append([]byte{}, src...), Valid: true})
+}
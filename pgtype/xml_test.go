@@ -0,0 +1,103 @@
+package pgtype_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxtest"
+	"github.com/stretchr/testify/require"
+)
+
+func isExpectedEqXML(a any) func(any) bool {
+	return func(v any) bool {
+		return reflect.DeepEqual(a, v)
+	}
+}
+
+func TestXMLCodec(t *testing.T) {
+	skipCockroachDB(t, "Server does not support xml")
+
+	pgxtest.RunValueRoundTripTests(context.Background(), t, defaultConnTestRunner, nil, "xml", []pgxtest.ValueRoundTripTest{
+		{
+			pgtype.XML{Bytes: []byte("<doc><title>Hello</title></doc>"), Valid: true},
+			new(pgtype.XML),
+			isExpectedEqXML(pgtype.XML{Bytes: []byte("<doc><title>Hello</title></doc>"), Valid: true}),
+		},
+		{"text <b>fragment</b>", new(string), isExpectedEq("text <b>fragment</b>")},
+		{[]byte("<a/>"), new([]byte), isExpectedEqBytes([]byte("<a/>"))},
+		{"<a/>", new(pgtype.XML), isExpectedEqXML(pgtype.XML{Bytes: []byte("<a/>"), Valid: true})},
+		{pgtype.XML{}, new(pgtype.XML), isExpectedEqXML(pgtype.XML{})},
+		{nil, new(*string), isExpectedEq((*string)(nil))},
+	})
+}
+
+func TestXMLCodecValidateOnEncode(t *testing.T) {
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "xml", OID: pgtype.XMLOID, Codec: pgtype.XMLCodec{ValidateOnEncode: true}})
+
+	for _, value := range []any{
+		"<doc><title>Hello</title></doc>",
+		`<?xml version="1.0" encoding="ISO-8859-1"?><doc/>`,
+		"text <b>fragment</b> <i>more</i>",
+		[]byte("<a/>"),
+		pgtype.XML{Bytes: []byte("<a>b</a>"), Valid: true},
+		pgtype.Text{String: "<a/>", Valid: true},
+	} {
+		_, err := m.Encode(pgtype.XMLOID, pgtype.TextFormatCode, value, nil)
+		require.NoErrorf(t, err, "%v", value)
+	}
+
+	for _, value := range []any{
+		"<doc><title>Hello</doc>",
+		"<a>",
+		[]byte("<a></b>"),
+		pgtype.XML{Bytes: []byte("<a b=c/>"), Valid: true},
+	} {
+		_, err := m.Encode(pgtype.XMLOID, pgtype.TextFormatCode, value, nil)
+		require.Errorf(t, err, "%v", value)
+	}
+
+	buf, err := m.Encode(pgtype.XMLOID, pgtype.TextFormatCode, pgtype.XML{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, buf)
+
+	// Without validation malformed values are sent as is for the server to reject.
+	buf, err = pgtype.NewMap().Encode(pgtype.XMLOID, pgtype.TextFormatCode, "<a>", nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("<a>"), buf)
+}
+
+func TestXMLCodecDecodeValueMatchesScan(t *testing.T) {
+	m := pgtype.NewMap()
+	src := []byte("<doc><title>Hello</title></doc>")
+
+	var s string
+	err := m.Scan(pgtype.XMLOID, pgtype.TextFormatCode, src, &s)
+	require.NoError(t, err)
+
+	var x pgtype.XML
+	err = m.Scan(pgtype.XMLOID, pgtype.TextFormatCode, src, &x)
+	require.NoError(t, err)
+	require.Equal(t, s, x.String())
+
+	var a any
+	err = m.Scan(pgtype.XMLOID, pgtype.TextFormatCode, src, &a)
+	require.NoError(t, err)
+	require.Equal(t, s, a)
+
+	dt, ok := m.TypeForOID(pgtype.XMLOID)
+	require.True(t, ok)
+	v, err := dt.Codec.DecodeValue(m, pgtype.XMLOID, pgtype.TextFormatCode, src)
+	require.NoError(t, err)
+	require.Equal(t, s, v)
+
+	dv, err := dt.Codec.DecodeDatabaseSQLValue(m, pgtype.XMLOID, pgtype.TextFormatCode, src)
+	require.NoError(t, err)
+	require.Equal(t, s, dv)
+
+	v, err = dt.Codec.DecodeValue(m, pgtype.XMLOID, pgtype.TextFormatCode, nil)
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
@@ -83,6 +83,10 @@ func (c *Conn) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.C
 	return c.Conn().Exec(ctx, sql, arguments...)
 }
 
+func (c *Conn) ExecMulti(ctx context.Context, sql string, arguments ...any) ([]pgconn.CommandTag, error) {
+	return c.Conn().ExecMulti(ctx, sql, arguments...)
+}
+
 func (c *Conn) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
 	return c.Conn().Query(ctx, sql, args...)
 }
@@ -27,6 +27,25 @@ type connResource struct {
 	poolRows   []poolRow
 	poolRowss  []poolRows
 	maxAgeTime time.Time
+
+	// prepareStatements are the ConnConfig.PrepareStatements that have not been prepared yet. They are prepared when the
+	// connection is first acquired so AfterConnect and PrepareConn run first.
+	prepareStatements []string
+}
+
+// prepare prepares cr.prepareStatements the first time the connection is acquired.
+func (cr *connResource) prepare(ctx context.Context) error {
+	if len(cr.prepareStatements) == 0 {
+		return nil
+	}
+
+	err := cr.conn.PrepareStatements(ctx, cr.prepareStatements)
+	if err != nil {
+		return err
+	}
+	cr.prepareStatements = nil
+
+	return nil
 }
 
 func (cr *connResource) getConn(p *Pool, res *puddle.Resource[*connResource]) *Conn {
@@ -84,8 +103,10 @@ type Pool struct {
 	beforeConnect         func(context.Context, *pgx.ConnConfig) error
 	afterConnect          func(context.Context, *pgx.Conn) error
 	beforeAcquire         func(context.Context, *pgx.Conn) bool
+	prepareConn           func(context.Context, *pgx.Conn) (bool, error)
 	afterRelease          func(*pgx.Conn) bool
 	beforeClose           func(*pgx.Conn)
+	onNotification        pgconn.NotificationHandler
 	minConns              int32
 	maxConns              int32
 	maxConnLifetime       time.Duration
@@ -116,13 +137,30 @@ type Config struct {
 	// acquired.
 	BeforeAcquire func(context.Context, *pgx.Conn) bool
 
+	// PrepareConn is called before a connection is acquired from the pool, after BeforeAcquire. It is passed the context
+	// given to Acquire, so it can be used to configure a connection based on request scoped values. If it returns false
+	// the connection is destroyed and a different connection is acquired. If it returns a non-nil error Acquire fails
+	// with that error. The connection is returned to the pool if it also returned true and destroyed otherwise.
+	PrepareConn func(context.Context, *pgx.Conn) (bool, error)
+
 	// AfterRelease is called after a connection is released, but before it is returned to the pool. It must return true to
 	// return the connection to the pool or false to destroy the connection.
+	//
+	// AfterRelease runs in its own goroutine so Release does not block. The connection cannot be acquired again until
+	// AfterRelease returns. This makes it the place to reset session state once per use instead of before every acquire.
+	// No context is passed, so any query it runs should use a context with a timeout. DISCARD ALL also deallocates the
+	// prepared statements that pgx caches. Follow it with Conn.DeallocateAll to clear those caches as well.
 	AfterRelease func(*pgx.Conn) bool
 
 	// BeforeClose is called right before a connection is closed and removed from the pool.
 	BeforeClose func(*pgx.Conn)
 
+	// OnNotification is called when a LISTEN/NOTIFY notification is received on any connection of the pool. It overrides
+	// ConnConfig.OnNotification. When it is set the health check also reads the notifications that arrived on idle
+	// connections so they are delivered within HealthCheckPeriod instead of when the connection is next used or not at
+	// all if the connection is closed first.
+	OnNotification pgconn.NotificationHandler
+
 	// MaxConnLifetime is the duration since creation after which a connection will be automatically closed.
 	MaxConnLifetime time.Duration
 
@@ -171,6 +209,11 @@ func New(ctx context.Context, connString string) (*Pool, error) {
 }
 
 // NewWithConfig creates a new Pool. config must have been created by [ParseConfig].
+//
+// Connections are normally established in the background. If config.ConnConfig.PrepareStatements is set, one
+// connection is established and acquired before NewWithConfig returns so an error preparing the statements is returned
+// immediately. The statements of each connection are prepared when it is first acquired, after AfterConnect and
+// PrepareConn.
 func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 	// Default values are set in ParseConfig. Enforce initial creation by ParseConfig rather than setting defaults from
 	// zero values.
@@ -183,8 +226,10 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 		beforeConnect:         config.BeforeConnect,
 		afterConnect:          config.AfterConnect,
 		beforeAcquire:         config.BeforeAcquire,
+		prepareConn:           config.PrepareConn,
 		afterRelease:          config.AfterRelease,
 		beforeClose:           config.BeforeClose,
+		onNotification:        config.OnNotification,
 		minConns:              config.MinConns,
 		maxConns:              config.MaxConns,
 		maxConnLifetime:       config.MaxConnLifetime,
@@ -207,12 +252,19 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 					connConfig.ConnectTimeout = 2 * time.Minute
 				}
 
+				if p.onNotification != nil {
+					connConfig.OnNotification = p.onNotification
+				}
+
 				if p.beforeConnect != nil {
 					if err := p.beforeConnect(ctx, connConfig); err != nil {
 						return nil, err
 					}
 				}
 
+				prepareStatements := connConfig.PrepareStatements
+				connConfig.PrepareStatements = nil
+
 				conn, err := pgx.ConnectConfig(ctx, connConfig)
 				if err != nil {
 					return nil, err
@@ -235,6 +287,8 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 					poolRows:   make([]poolRow, 64),
 					poolRowss:  make([]poolRows, 64),
 					maxAgeTime: maxAgeTime,
+
+					prepareStatements: prepareStatements,
 				}
 
 				return cr, nil
@@ -259,6 +313,15 @@ func NewWithConfig(ctx context.Context, config *Config) (*Pool, error) {
 		return nil, err
 	}
 
+	if len(config.ConnConfig.PrepareStatements) > 0 {
+		conn, err := p.Acquire(ctx)
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		conn.Release()
+	}
+
 	go func() {
 		p.createIdleResources(ctx, int(p.minConns))
 		p.backgroundHealthCheck()
@@ -437,6 +500,15 @@ func (p *Pool) checkConnsHealth() bool {
 	totalConns := p.Stat().TotalConns()
 	resources := p.p.AcquireAllIdle()
 	for _, res := range resources {
+		if p.onNotification != nil {
+			if err := receiveIdleNotifications(res.Value().conn); err != nil {
+				res.Destroy()
+				destroyed = true
+				totalConns--
+				continue
+			}
+		}
+
 		// We're okay going under minConns if the lifetime is up
 		if p.isExpired(res) && totalConns >= p.minConns {
 			atomic.AddInt64(&p.lifetimeDestroyCount, 1)
@@ -457,6 +529,30 @@ func (p *Pool) checkConnsHealth() bool {
 	return destroyed
 }
 
+// receiveIdleNotifications reads the messages that have already arrived on an idle connection. Notifications among them
+// are passed to the OnNotification handler by pgconn. An error is returned if the connection is broken.
+//
+// The read is bounded by a deadline on the net.Conn rather than a context. A context deadline would make pgconn send
+// a cancel request to the server every time the read times out.
+func receiveIdleNotifications(conn *pgx.Conn) error {
+	pgConn := conn.PgConn()
+	netConn := pgConn.Conn()
+	if err := netConn.SetReadDeadline(time.Now().Add(time.Millisecond)); err != nil {
+		return err
+	}
+	defer netConn.SetReadDeadline(time.Time{})
+
+	for {
+		_, err := pgConn.ReceiveMessage(context.Background())
+		if err != nil {
+			if pgconn.Timeout(err) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
 func (p *Pool) checkMinConns() error {
 	// TotalConns can include ones that are being destroyed but we should have
 	// sleep(500ms) around all of the destroys to help prevent that from throwing
@@ -515,11 +611,34 @@ func (p *Pool) Acquire(ctx context.Context) (*Conn, error) {
 			}
 		}
 
-		if p.beforeAcquire == nil || p.beforeAcquire(ctx, cr.conn) {
-			return cr.getConn(p, res), nil
+		if p.beforeAcquire != nil && !p.beforeAcquire(ctx, cr.conn) {
+			res.Destroy()
+			continue
+		}
+
+		if p.prepareConn != nil {
+			ok, err := p.prepareConn(ctx, cr.conn)
+			if err != nil {
+				if ok {
+					res.Release()
+				} else {
+					res.Destroy()
+				}
+				return nil, err
+			}
+			if !ok {
+				res.Destroy()
+				continue
+			}
+		}
+
+		err = cr.prepare(ctx)
+		if err != nil {
+			res.Destroy()
+			return nil, err
 		}
 
-		res.Destroy()
+		return cr.getConn(p, res), nil
 	}
 }
 
@@ -537,17 +656,36 @@ func (p *Pool) AcquireFunc(ctx context.Context, f func(*Conn) error) error {
 }
 
 // AcquireAllIdle atomically acquires all currently idle connections. Its intended use is for health check and
-// keep-alive functionality. It does not update pool statistics.
+// keep-alive functionality. It does not update pool statistics. Connections for which PrepareConn returns an error are
+// skipped. Connections that fail to prepare ConnConfig.PrepareStatements are destroyed.
 func (p *Pool) AcquireAllIdle(ctx context.Context) []*Conn {
 	resources := p.p.AcquireAllIdle()
 	conns := make([]*Conn, 0, len(resources))
 	for _, res := range resources {
 		cr := res.Value()
-		if p.beforeAcquire == nil || p.beforeAcquire(ctx, cr.conn) {
-			conns = append(conns, cr.getConn(p, res))
-		} else {
+		if p.beforeAcquire != nil && !p.beforeAcquire(ctx, cr.conn) {
+			res.Destroy()
+			continue
+		}
+
+		if p.prepareConn != nil {
+			ok, err := p.prepareConn(ctx, cr.conn)
+			if !ok {
+				res.Destroy()
+				continue
+			}
+			if err != nil {
+				res.Release()
+				continue
+			}
+		}
+
+		if err := cr.prepare(ctx); err != nil {
 			res.Destroy()
+			continue
 		}
+
+		conns = append(conns, cr.getConn(p, res))
 	}
 
 	return conns
@@ -589,6 +727,18 @@ func (p *Pool) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.C
 	return c.Exec(ctx, sql, arguments...)
 }
 
+// ExecMulti acquires a connection from the Pool and executes the given multi-statement SQL. See pgx.Conn.ExecMulti for
+// details. The acquired connection is returned to the pool when the ExecMulti function returns.
+func (p *Pool) ExecMulti(ctx context.Context, sql string, arguments ...any) ([]pgconn.CommandTag, error) {
+	c, err := p.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Release()
+
+	return c.ExecMulti(ctx, sql, arguments...)
+}
+
 // Query acquires a connection and executes a query that returns pgx.Rows.
 // Arguments should be referenced positionally from the SQL string as $1, $2, etc.
 // See pgx.Rows documentation to close the returned Rows and return the acquired connection to the Pool.
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/assert"
@@ -122,6 +123,45 @@ func TestPoolAcquireAndConnRelease(t *testing.T) {
 	c.Release()
 }
 
+func TestPoolStatAcquireWaits(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.MaxConns = 1
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	c, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	before := pool.Stat()
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	_, err = pool.Acquire(timeoutCtx)
+	timeoutCancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		c.Release()
+	}()
+
+	c, err = pool.Acquire(ctx)
+	require.NoError(t, err)
+	c.Release()
+
+	after := pool.Stat()
+	assert.EqualValues(t, 1, after.AcquireCount()-before.AcquireCount())
+	assert.EqualValues(t, 1, after.EmptyAcquireCount()-before.EmptyAcquireCount())
+	assert.EqualValues(t, 1, after.CanceledAcquireCount()-before.CanceledAcquireCount())
+	assert.GreaterOrEqual(t, after.AcquireDuration()-before.AcquireDuration(), 40*time.Millisecond)
+}
+
 func TestPoolAcquireAndConnHijack(t *testing.T) {
 	t.Parallel()
 
@@ -285,6 +325,82 @@ func TestPoolAfterConnect(t *testing.T) {
 	assert.EqualValues(t, 1, n)
 }
 
+func TestPoolPrepareStatements(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.ConnConfig.PrepareStatements = []string{"select $1::int4 + 1"}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	stat := db.Stat()
+	require.EqualValues(t, 1, stat.TotalConns())
+
+	var n int32
+	err = db.QueryRow(ctx, "select $1::int4 + 1", 41).Scan(&n)
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, n)
+
+	config, err = pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.ConnConfig.PrepareStatements = []string{"select * from table_that_does_not_exist"}
+
+	db, err = pgxpool.NewWithConfig(ctx, config)
+	require.Error(t, err)
+	require.Nil(t, db)
+
+	// Statements are prepared after AfterConnect so they can depend on it.
+	config, err = pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		_, err := conn.Exec(ctx, "create temporary table prepare_statements_after_connect(n int4)")
+		return err
+	}
+	config.ConnConfig.PrepareStatements = []string{"select n from prepare_statements_after_connect"}
+
+	db, err = pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(ctx, "select n from prepare_statements_after_connect")
+	require.NoError(t, err)
+}
+
+func TestPoolPrepareStatementsAcquireAllIdle(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.MinConns = 3
+	config.ConnConfig.PrepareStatements = []string{"select $1::int4 + 1"}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.Eventually(t, func() bool { return db.Stat().IdleConns() == 3 }, 30*time.Second, 10*time.Millisecond)
+
+	// Connections that were never acquired with Acquire are prepared too.
+	conns := db.AcquireAllIdle(ctx)
+	require.Len(t, conns, 3)
+	for _, c := range conns {
+		var n int32
+		err = c.QueryRow(ctx, "select count(*) from pg_prepared_statements where statement = 'select $1::int4 + 1'", pgx.QueryExecModeSimpleProtocol).Scan(&n)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, n)
+		c.Release()
+	}
+}
+
 func TestPoolBeforeAcquire(t *testing.T) {
 	t.Parallel()
 
@@ -329,6 +445,80 @@ func TestPoolBeforeAcquire(t *testing.T) {
 	assert.EqualValues(t, 12, acquireAttempts)
 }
 
+func TestPoolPrepareConn(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	type ctxKey struct{}
+	acquireAttempts := 0
+
+	config.PrepareConn = func(ctx context.Context, c *pgx.Conn) (bool, error) {
+		acquireAttempts++
+		if acquireAttempts%2 == 1 {
+			return false, nil
+		}
+
+		appName, _ := ctx.Value(ctxKey{}).(string)
+		_, err := c.Exec(ctx, "select set_config('application_name', $1, false)", appName)
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c, err := db.Acquire(context.WithValue(ctx, ctxKey{}, "pgxpool-prepare-conn"))
+	require.NoError(t, err)
+	defer c.Release()
+
+	assert.EqualValues(t, 2, acquireAttempts)
+
+	var appName string
+	err = c.QueryRow(ctx, "show application_name").Scan(&appName)
+	require.NoError(t, err)
+	assert.Equal(t, "pgxpool-prepare-conn", appName)
+}
+
+func TestPoolPrepareConnError(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	prepareErr := errors.New("prepare failed")
+	config.PrepareConn = func(ctx context.Context, c *pgx.Conn) (bool, error) {
+		return true, prepareErr
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c, err := db.Acquire(ctx)
+	require.ErrorIs(t, err, prepareErr)
+	require.Nil(t, c)
+
+	err = db.Ping(ctx)
+	require.ErrorIs(t, err, prepareErr)
+
+	waitForReleaseToComplete()
+
+	// The connection was returned to the pool rather than destroyed because PrepareConn returned true.
+	assert.EqualValues(t, 1, db.Stat().TotalConns())
+	assert.EqualValues(t, 1, db.Stat().IdleConns())
+}
+
 func TestPoolAfterRelease(t *testing.T) {
 	t.Parallel()
 
@@ -368,6 +558,51 @@ func TestPoolAfterRelease(t *testing.T) {
 	assert.EqualValues(t, 5, len(connPIDs))
 }
 
+func TestPoolAfterReleaseResetsSessionState(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	config.MaxConns = 1
+	config.AfterRelease = func(c *pgx.Conn) bool {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if _, err := c.Exec(ctx, "discard all"); err != nil {
+			return false
+		}
+		return c.DeallocateAll(ctx) == nil
+	}
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var pid uint32
+	for i := 0; i < 3; i++ {
+		err = db.AcquireFunc(ctx, func(c *pgxpool.Conn) error {
+			if i == 0 {
+				pid = c.Conn().PgConn().PID()
+			} else {
+				require.Equal(t, pid, c.Conn().PgConn().PID())
+			}
+
+			var appName string
+			err := c.QueryRow(ctx, "select current_setting('application_name')").Scan(&appName)
+			require.NoError(t, err)
+			require.NotEqual(t, "after_release_test", appName)
+
+			_, err = c.Exec(ctx, "set application_name = 'after_release_test'")
+			return err
+		})
+		require.NoError(t, err)
+		waitForReleaseToComplete()
+	}
+}
+
 func TestPoolBeforeClose(t *testing.T) {
 	t.Parallel()
 
@@ -587,6 +822,50 @@ func TestPoolBackgroundChecksMaxConnIdleTime(t *testing.T) {
 	assert.EqualValues(t, 1, stats.NewConnsCount())
 }
 
+func TestPoolOnNotificationReceivesFromIdleConns(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	config, err := pgxpool.ParseConfig(os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+
+	notifications := make(chan *pgconn.Notification, 1)
+	config.OnNotification = func(_ *pgconn.PgConn, n *pgconn.Notification) {
+		notifications <- n
+	}
+	config.HealthCheckPeriod = 100 * time.Millisecond
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer db.Close()
+
+	c, err := db.Acquire(ctx)
+	require.NoError(t, err)
+	_, err = c.Exec(ctx, "listen pool_idle_notification")
+	require.NoError(t, err)
+	c.Release()
+
+	notifier, err := pgx.Connect(ctx, os.Getenv("PGX_TEST_DATABASE"))
+	require.NoError(t, err)
+	defer notifier.Close(ctx)
+
+	_, err = notifier.Exec(ctx, "notify pool_idle_notification, 'hello'")
+	require.NoError(t, err)
+
+	select {
+	case n := <-notifications:
+		require.Equal(t, "pool_idle_notification", n.Channel)
+		require.Equal(t, "hello", n.Payload)
+	case <-ctx.Done():
+		t.Fatal("notification was not received")
+	}
+
+	// The connection that received the notification is still usable.
+	require.EqualValues(t, 1, db.Stat().TotalConns())
+}
+
 func TestPoolBackgroundChecksMinConns(t *testing.T) {
 	t.Parallel()
 
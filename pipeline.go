@@ -0,0 +1,368 @@
+package pgx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Pipeline sends queries to the server without waiting for the results of earlier queries. Unlike a Batch, queries can
+// be sent and results read in any interleaving so a pipeline can be kept full while results are processed. Create a
+// Pipeline with Conn.StartPipeline.
+//
+// Queries are divided into segments by Sync. If a query fails the server skips the remaining queries of its segment.
+// Reading the result of a skipped query returns an error that wraps the error of the failed query. Queries after the next
+// Sync are executed normally. Reading the result of a query that has not been followed by a Sync calls Sync.
+//
+// Arguments are encoded with the connection's type map. Statements prepared with Conn.Prepare are executed by name and
+// may reference their arguments by PostgreSQL type. Other queries are sent as with QueryExecModeExec because their
+// statement descriptions cannot be fetched while the pipeline is in progress. For the same reason domain types are only
+// registered automatically for statements prepared with Conn.Prepare before the pipeline was started.
+//
+// Queries are traced with the connection's QueryTracer. TraceQueryStart is called when a query is sent and
+// TraceQueryEnd when its Rows are closed, so the traces of queries in a pipeline overlap. Queries whose results are
+// discarded by Close are traced as well.
+//
+// The context passed to StartPipeline is in effect for the entire life of the Pipeline. If it is canceled while results
+// are pending the underlying connection will be closed and all further operations return an error.
+//
+// The connection cannot be used for anything else until the Pipeline is closed.
+type Pipeline struct {
+	ctx      context.Context
+	conn     *Conn
+	pipeline *pgconn.Pipeline
+
+	// queue holds the queries and syncs that have been sent and whose results have not been read in the order they were
+	// sent.
+	queue       []*pipelineQueueItem
+	queuedSyncs int
+	unsynced    bool
+
+	lastRows *baseRows
+
+	// segmentErr is the error that caused the server to skip the rest of the queries until the next sync.
+	segmentErr error
+
+	err    error
+	closed bool
+}
+
+type pipelineQueueItem struct {
+	ctx  context.Context // ctx is the context returned by QueryTracer.TraceQueryStart.
+	sql  string
+	args []any
+	err  error // err is set if the query could not be sent. e.g. Because the arguments could not be encoded.
+	sync bool
+}
+
+// errPipelineNoResults is returned when the results of a query are read but all sent queries have been read.
+var errPipelineNoResults = errors.New("no pipeline results to read")
+
+// StartPipeline switches the connection to pipeline mode and returns a *Pipeline. Close must be called on the returned
+// *Pipeline to return the connection to normal mode.
+func (c *Conn) StartPipeline(ctx context.Context) *Pipeline {
+	p := &Pipeline{ctx: ctx, conn: c}
+
+	if err := c.deallocateInvalidatedCachedStatements(ctx); err != nil {
+		p.err = err
+		p.closed = true
+		return p
+	}
+
+	p.pipeline = c.pgConn.StartPipeline(ctx)
+	return p
+}
+
+// SendQuery queues sql with arguments to be sent to the server. A QueryRewriter may be passed as the first element of
+// arguments. Queued queries are sent when Flush or Sync is called or when the connection's write buffer is full. Any
+// error encoding the query is returned when its results are read.
+func (p *Pipeline) SendQuery(sql string, arguments ...any) {
+	if p.closed {
+		return
+	}
+
+	item := &pipelineQueueItem{ctx: p.ctx, sql: sql, args: arguments}
+	p.queue = append(p.queue, item)
+
+	c := p.conn
+	if c.queryTracer != nil {
+		item.ctx = c.queryTracer.TraceQueryStart(p.ctx, c, TraceQueryStartData{SQL: sql, Args: arguments})
+	}
+
+	var queryRewriter QueryRewriter
+	if len(arguments) > 0 {
+		if qr, ok := arguments[0].(QueryRewriter); ok {
+			queryRewriter = qr
+			arguments = arguments[1:]
+		}
+	}
+
+	if queryRewriter != nil || c.config.QueryRewriter != nil {
+		var err error
+		sql, arguments, err = c.rewriteQuery(p.ctx, queryRewriter, sql, arguments)
+		if err != nil {
+			item.err = err
+			return
+		}
+		item.sql = sql
+		item.args = arguments
+	}
+
+	if sd := c.preparedStatements[sql]; sd != nil {
+		if len(sd.ParamOIDs) != len(arguments) {
+			item.err = fmt.Errorf("expected %d arguments, got %d", len(sd.ParamOIDs), len(arguments))
+			return
+		}
+
+		err := c.eqb.Build(c.typeMap, sd, arguments)
+		if err != nil {
+			item.err = err
+			return
+		}
+
+		p.pipeline.SendQueryPrepared(sd.Name, c.eqb.ParamValues, c.eqb.ParamFormats, c.eqb.ResultFormats)
+	} else {
+		err := c.eqb.Build(c.typeMap, nil, arguments)
+		if err != nil {
+			item.err = err
+			return
+		}
+
+		p.pipeline.SendQueryParams(sql, c.eqb.ParamValues, nil, c.eqb.ParamFormats, c.eqb.ResultFormats)
+	}
+	c.eqb.reset() // Allow c.eqb internal memory to be GC'ed as soon as possible.
+
+	p.unsynced = true
+}
+
+// Flush sends the queued queries without establishing a synchronization point.
+func (p *Pipeline) Flush() error {
+	if p.closed {
+		return p.closedErr()
+	}
+
+	err := p.pipeline.Flush()
+	if err != nil {
+		p.err = err
+	}
+	return err
+}
+
+// Sync establishes a synchronization point and sends the queued queries. If a query before the synchronization point
+// fails the queries after it are still executed.
+func (p *Pipeline) Sync() error {
+	if p.closed {
+		return p.closedErr()
+	}
+
+	err := p.pipeline.Sync()
+	if err != nil {
+		p.err = err
+		return err
+	}
+
+	p.queue = append(p.queue, &pipelineQueueItem{sync: true})
+	p.queuedSyncs++
+	p.unsynced = false
+
+	return nil
+}
+
+// Exec reads the results of the next query as if it had been sent with Exec.
+func (p *Pipeline) Exec() (pgconn.CommandTag, error) {
+	rows, err := p.Query()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	rows.Close()
+	return rows.CommandTag(), rows.Err()
+}
+
+// Query reads the results of the next query as if it had been sent with Query. The returned Rows is closed
+// automatically when the results of the next query are read.
+func (p *Pipeline) Query() (Rows, error) {
+	if p.closed {
+		err := p.closedErr()
+		return &baseRows{err: err, closed: true}, err
+	}
+
+	item, err := p.nextQuery()
+	if err == nil && item == nil {
+		err = errPipelineNoResults
+	}
+	if err != nil {
+		return &baseRows{err: err, closed: true}, err
+	}
+
+	rows := p.conn.getRows(item.ctx, item.sql, item.args)
+
+	rr, err := p.readResult(item)
+	if err != nil {
+		rows.fatal(err)
+		return rows, err
+	}
+
+	rows.resultReader = rr
+	p.lastRows = rows
+	return rows, nil
+}
+
+// QueryRow reads the results of the next query as if it had been sent with QueryRow.
+func (p *Pipeline) QueryRow() Row {
+	rows, _ := p.Query()
+	return (*connRow)(rows.(*baseRows))
+}
+
+// Close reads and discards the results of any queries that have not been read and returns the connection to normal
+// mode. It returns the first error that prevented the pipeline from completing or, if there is none, the first error of
+// a query whose results were discarded.
+func (p *Pipeline) Close() error {
+	if p.closed {
+		return p.err
+	}
+
+	var firstQueryErr error
+	for p.err == nil {
+		item, err := p.nextQuery()
+		if err != nil || item == nil {
+			break
+		}
+
+		rows := p.conn.getRows(item.ctx, item.sql, item.args)
+		rr, err := p.readResult(item)
+		if err == nil {
+			rows.resultReader = rr
+			rows.Close()
+			err = rows.err
+			p.handleQueryError(err)
+		} else {
+			rows.fatal(err)
+		}
+		if err != nil && firstQueryErr == nil {
+			firstQueryErr = err
+		}
+	}
+
+	// The results of any queries still queued cannot be read because the pipeline failed.
+	for _, item := range p.queue {
+		if !item.sync {
+			p.conn.getRows(item.ctx, item.sql, item.args).fatal(p.err)
+		}
+	}
+	p.queue = nil
+
+	p.closed = true
+
+	if p.pipeline != nil {
+		err := p.pipeline.Close()
+		if p.err == nil {
+			p.err = err
+		}
+	}
+	if p.err == nil {
+		return firstQueryErr
+	}
+
+	return p.err
+}
+
+func (p *Pipeline) closedErr() error {
+	if p.err != nil {
+		return p.err
+	}
+	return errors.New("pipeline closed")
+}
+
+// nextQuery returns the next query whose results have not been read. It closes the Rows of the previous query and
+// reads any syncs before the query. It returns nil if there are no more queries.
+func (p *Pipeline) nextQuery() (*pipelineQueueItem, error) {
+	if p.lastRows != nil {
+		p.lastRows.Close()
+		p.handleQueryError(p.lastRows.err)
+		p.lastRows = nil
+	}
+
+	for {
+		if p.err != nil {
+			return nil, p.err
+		}
+
+		if len(p.queue) == 0 {
+			return nil, nil
+		}
+
+		item := p.queue[0]
+		if !item.sync {
+			if p.queuedSyncs == 0 && p.unsynced {
+				if err := p.Sync(); err != nil {
+					return nil, err
+				}
+			}
+
+			p.queue = p.queue[1:]
+			return item, nil
+		}
+
+		p.queue = p.queue[1:]
+		p.queuedSyncs--
+
+		results, err := p.pipeline.GetResults()
+		if err != nil {
+			p.err = err
+			return nil, err
+		}
+		if _, ok := results.(*pgconn.PipelineSync); !ok {
+			p.err = fmt.Errorf("expected sync, got %T", results)
+			return nil, p.err
+		}
+		p.segmentErr = nil
+	}
+}
+
+// readResult reads the start of the result of item from the server.
+func (p *Pipeline) readResult(item *pipelineQueueItem) (*pgconn.ResultReader, error) {
+	if item.err != nil {
+		return nil, item.err
+	}
+
+	if p.segmentErr != nil {
+		return nil, fmt.Errorf("query skipped because an earlier query in the pipeline failed: %w", p.segmentErr)
+	}
+
+	results, err := p.pipeline.GetResults()
+	if err != nil {
+		p.handleQueryError(err)
+		return nil, err
+	}
+
+	rr, ok := results.(*pgconn.ResultReader)
+	if !ok {
+		p.err = fmt.Errorf("unexpected pipeline result: %T", results)
+		return nil, p.err
+	}
+
+	return rr, nil
+}
+
+// handleQueryError records the effect of an error returned while reading the result of a query. An error from the
+// server causes the rest of the segment to be skipped. Any other error that closed the connection ends the pipeline.
+func (p *Pipeline) handleQueryError(err error) {
+	if err == nil {
+		return
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if p.segmentErr == nil {
+			p.segmentErr = err
+		}
+		return
+	}
+
+	if p.err == nil && p.conn.pgConn.IsClosed() {
+		p.err = err
+	}
+}
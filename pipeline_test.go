@@ -2,10 +2,13 @@ package pgx_test
 
 import (
 	"context"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/require"
 )
 
@@ -77,3 +80,171 @@ func TestPipelineWithoutPreparedOrDescribedStatements(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestConnPipeline(t *testing.T) {
+	t.Parallel()
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		_, err := conn.Prepare(ctx, "ps1", "select $1::text")
+		require.NoError(t, err)
+
+		pipeline := conn.StartPipeline(ctx)
+		pipeline.SendQuery("select $1::int8 + $2::int8", 1, 2)
+		pipeline.SendQuery("ps1", "foo")
+		pipeline.SendQuery("select n from generate_series(1, $1::int4) n", 3)
+		err = pipeline.Sync()
+		require.NoError(t, err)
+
+		var n int64
+		err = pipeline.QueryRow().Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, n)
+
+		// Results can be read while more queries are sent.
+		pipeline.SendQuery("select @a::int8 * 2", pgx.NamedArgs{"a": 21})
+
+		var s string
+		err = pipeline.QueryRow().Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "foo", s)
+
+		rows, err := pipeline.Query()
+		require.NoError(t, err)
+		numbers, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+		require.NoError(t, err)
+		require.Equal(t, []int32{1, 2, 3}, numbers)
+
+		// The last query was not followed by a sync. Reading its results sends one.
+		err = pipeline.QueryRow().Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 42, n)
+
+		_, err = pipeline.Exec()
+		require.Error(t, err)
+
+		err = pipeline.Close()
+		require.NoError(t, err)
+
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestConnPipelineQueryErrorSkipsRestOfSegment(t *testing.T) {
+	t.Parallel()
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		pipeline := conn.StartPipeline(ctx)
+		pipeline.SendQuery("select 1/$1::int4", 0)
+		pipeline.SendQuery("select 1")
+		err := pipeline.Sync()
+		require.NoError(t, err)
+		pipeline.SendQuery("select 2")
+		pipeline.SendQuery("select $1::int4", "not a number")
+		pipeline.SendQuery("select 3")
+
+		var pgErr *pgconn.PgError
+
+		var n int32
+		err = pipeline.QueryRow().Scan(&n)
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "22012", pgErr.Code)
+
+		_, err = pipeline.Exec()
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "22012", pgErr.Code)
+		require.ErrorContains(t, err, "skipped")
+
+		err = pipeline.QueryRow().Scan(&n)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n)
+
+		err = pipeline.Close()
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, "22P02", pgErr.Code)
+
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestConnPipelineTracesQueries(t *testing.T) {
+	t.Parallel()
+
+	tracer := &testTracer{}
+
+	ctr := defaultConnTestRunner
+	ctr.CreateConfig = func(ctx context.Context, t testing.TB) *pgx.ConnConfig {
+		config := defaultConnTestRunner.CreateConfig(ctx, t)
+		config.Tracer = tracer
+		return config
+	}
+
+	ctr.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var started []string
+		var ended []string
+		var endErrs []error
+		tracer.traceQueryStart = func(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+			started = append(started, data.SQL)
+			return context.WithValue(ctx, ctxKey("sql"), data.SQL)
+		}
+		tracer.traceQueryEnd = func(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+			ended = append(ended, ctx.Value(ctxKey("sql")).(string))
+			endErrs = append(endErrs, data.Err)
+		}
+
+		pipeline := conn.StartPipeline(ctx)
+		pipeline.SendQuery("select 1")
+		pipeline.SendQuery("select 1/$1::int4", 0)
+		pipeline.SendQuery("select 2")
+		err := pipeline.Sync()
+		require.NoError(t, err)
+		require.Equal(t, []string{"select 1", "select 1/$1::int4", "select 2"}, started)
+		require.Empty(t, ended)
+
+		_, err = pipeline.Exec()
+		require.NoError(t, err)
+		require.Equal(t, []string{"select 1"}, ended)
+
+		// The remaining queries are traced when Close discards their results.
+		err = pipeline.Close()
+		require.Error(t, err)
+		require.Equal(t, []string{"select 1", "select 1/$1::int4", "select 2"}, ended)
+		require.NoError(t, endErrs[0])
+		require.Error(t, endErrs[1])
+		require.Error(t, endErrs[2])
+
+		tracer.traceQueryStart = nil
+		tracer.traceQueryEnd = nil
+		ensureConnValid(t, conn)
+	})
+}
+
+func TestConnPipelineContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+	pgxtest.SkipCockroachDB(t, conn, "Server does not support pg_sleep")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	pipeline := conn.StartPipeline(ctx)
+	pipeline.SendQuery("select 1")
+	pipeline.SendQuery("select pg_sleep(5)")
+	pipeline.SendQuery("select 2")
+	err := pipeline.Sync()
+	require.NoError(t, err)
+
+	_, err = pipeline.Exec()
+	require.NoError(t, err)
+
+	_, err = pipeline.Exec()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = pipeline.Exec()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = pipeline.Close()
+	require.Error(t, err)
+	require.True(t, conn.IsClosed())
+}
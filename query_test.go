@@ -190,6 +190,36 @@ func TestConnQueryValuesWhenUnableToDecode(t *testing.T) {
 	require.Equal(t, "({1},)", values[0])
 }
 
+func TestConnQueryTypeMapPreferredFormat(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	conn.TypeMap().SetPreferredFormat(pgtype.NumericOID, pgx.TextFormatCode)
+
+	rows, err := conn.Query(context.Background(), "select 1.5::numeric, 2::int4", pgx.QueryExecModeCacheStatement)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	require.True(t, rows.Next())
+	require.Equal(t, int16(pgx.TextFormatCode), rows.FieldDescriptions()[0].Format)
+	require.Equal(t, int16(pgx.BinaryFormatCode), rows.FieldDescriptions()[1].Format)
+
+	var n pgtype.Numeric
+	var i int32
+	err = rows.Scan(&n, &i)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), i)
+
+	f, err := n.Float64Value()
+	require.NoError(t, err)
+	require.Equal(t, 1.5, f.Float64)
+
+	rows.Close()
+	require.NoError(t, rows.Err())
+}
+
 func TestConnQueryValuesWithUnregisteredOID(t *testing.T) {
 	t.Parallel()
 
@@ -1023,6 +1053,34 @@ func TestQueryRowNoResults(t *testing.T) {
 	ensureConnValid(t, conn)
 }
 
+func TestScanRowOK(t *testing.T) {
+	t.Parallel()
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var n int32
+		found, err := pgx.ScanRowOK(conn.QueryRow(ctx, "select 42"), &n)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 42, n)
+
+		n = 0
+		found, err = pgx.ScanRowOK(conn.QueryRow(ctx, "select 1 where 1=0"), &n)
+		require.NoError(t, err)
+		require.False(t, found)
+		require.EqualValues(t, 0, n)
+
+		found, err = pgx.ScanRowOK(conn.QueryRow(ctx, "select 'foo'"), &n)
+		require.Error(t, err)
+		require.False(t, errors.Is(err, pgx.ErrNoRows))
+		require.False(t, found)
+
+		found, err = pgx.ScanRowOK(conn.QueryRow(ctx, "select 1/0"), &n)
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.False(t, found)
+	})
+}
+
 func TestQueryRowEmptyQuery(t *testing.T) {
 	t.Parallel()
 
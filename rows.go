@@ -120,6 +120,22 @@ func (r *connRow) Scan(dest ...any) (err error) {
 	return rows.Err()
 }
 
+// ScanRowOK scans row into dest. It is useful for optional lookups. If no rows were found it returns found false and a
+// nil error. Any other error is returned with found false. For example:
+//
+//	found, err := pgx.ScanRowOK(conn.QueryRow(ctx, "select name from users where id=$1", id), &name)
+func ScanRowOK(row Row, dest ...any) (found bool, err error) {
+	err = row.Scan(dest...)
+	if err != nil {
+		if errors.Is(err, ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
 // baseRows implements the Rows interface for Conn.Query.
 type baseRows struct {
 	typeMap      *pgtype.Map
@@ -136,6 +152,7 @@ type baseRows struct {
 
 	conn              *Conn
 	multiResultReader *pgconn.MultiResultReader
+	pipeline          *pgconn.Pipeline
 
 	queryTracer QueryTracer
 	batchTracer BatchTracer
@@ -147,6 +164,10 @@ type baseRows struct {
 }
 
 func (rows *baseRows) FieldDescriptions() []pgconn.FieldDescription {
+	// resultReader is nil when the query failed before it was sent.
+	if rows.resultReader == nil {
+		return nil
+	}
 	return rows.resultReader.FieldDescriptions()
 }
 
@@ -172,6 +193,13 @@ func (rows *baseRows) Close() {
 		}
 	}
 
+	if rows.pipeline != nil {
+		closeErr := rows.pipeline.Close()
+		if rows.err == nil {
+			rows.err = closeErr
+		}
+	}
+
 	if rows.err != nil && rows.conn != nil && rows.sql != "" {
 		if sc := rows.conn.statementCache; sc != nil {
 			sc.Invalidate(rows.sql)
@@ -438,6 +466,119 @@ func CollectRows[T any](rows Rows, fn RowToFunc[T]) ([]T, error) {
 	return slice, nil
 }
 
+// CollectColumn returns the values of the only column of rows as a slice of T. e.g.
+//
+//	rows, _ := conn.Query(ctx, "select id from widgets")
+//	ids, err := pgx.CollectColumn[int64](rows)
+//
+// An error is returned if rows does not have exactly one column.
+func CollectColumn[T any](rows Rows) ([]T, error) {
+	defer rows.Close()
+
+	if fields := rows.FieldDescriptions(); fields != nil && len(fields) != 1 {
+		return nil, fmt.Errorf("expected 1 column, got %d", len(fields))
+	}
+
+	return CollectRows(rows, RowTo[T])
+}
+
+// ForEachRowTo iterates through rows, converting each row to a T with rowTo and calling fn with the result. It stops at
+// the first error returned by rowTo or fn. Unlike CollectRows, the results are not buffered in a slice. e.g.
+//
+//	_, err := pgx.ForEachRowTo(rows, pgx.RowToStructByName[Widget], func(w Widget) error { ... })
+//
+// ForEachRowTo closes rows and returns the command tag of the query.
+func ForEachRowTo[T any](rows Rows, rowTo RowToFunc[T], fn func(T) error) (pgconn.CommandTag, error) {
+	defer rows.Close()
+
+	for rows.Next() {
+		value, err := rowTo(rows)
+		if err != nil {
+			return pgconn.CommandTag{}, err
+		}
+
+		err = fn(value)
+		if err != nil {
+			return pgconn.CommandTag{}, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	return rows.CommandTag(), nil
+}
+
+// ForEachColumnBatch iterates through rows in batches of up to batchSize rows. Each element of columns must be a
+// pointer to a slice, one per result column, e.g. *[]int32 or *[]pgtype.Text. The value of each column is scanned into
+// a new element appended to its slice. When batchSize rows have been read, or the last rows have been read, fn is
+// called with the slices holding the batch. The slices are truncated before the next batch and their memory is reused,
+// so fn must copy out any elements it wants to retain.
+//
+// Values are decoded by the codec of each column as with Scan, so the binary format is used for all types that support
+// it. If any row fails to scan or fn returns an error the query will be aborted and the error will be returned. Rows
+// will be closed when ForEachColumnBatch returns.
+func ForEachColumnBatch(rows Rows, batchSize int, columns []any, fn func() error) (pgconn.CommandTag, error) {
+	defer rows.Close()
+
+	if batchSize < 1 {
+		return pgconn.CommandTag{}, fmt.Errorf("batch size must be at least 1, got %d", batchSize)
+	}
+
+	slices := make([]reflect.Value, len(columns))
+	zeros := make([]reflect.Value, len(columns))
+	for i, column := range columns {
+		v := reflect.ValueOf(column)
+		if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+			return pgconn.CommandTag{}, fmt.Errorf("columns[%d] must be a pointer to a slice, got %T", i, column)
+		}
+		slices[i] = v.Elem()
+		slices[i].SetLen(0)
+		zeros[i] = reflect.Zero(slices[i].Type().Elem())
+	}
+
+	scans := make([]any, len(columns))
+	n := 0
+	for rows.Next() {
+		for i, s := range slices {
+			s.Set(reflect.Append(s, zeros[i]))
+			scans[i] = s.Index(n).Addr().Interface()
+		}
+
+		err := rows.Scan(scans...)
+		if err != nil {
+			return pgconn.CommandTag{}, err
+		}
+
+		n++
+		if n == batchSize {
+			err = fn()
+			if err != nil {
+				return pgconn.CommandTag{}, err
+			}
+
+			for _, s := range slices {
+				s.SetLen(0)
+			}
+			n = 0
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	if n > 0 {
+		err := fn()
+		if err != nil {
+			return pgconn.CommandTag{}, err
+		}
+	}
+
+	return rows.CommandTag(), nil
+}
+
 // CollectOneRow calls fn for the first row in rows and returns the result. If no rows are found returns an error where errors.Is(ErrNoRows) is true.
 // CollectOneRow is to CollectRows as QueryRow is to Query.
 func CollectOneRow[T any](rows Rows, fn RowToFunc[T]) (T, error) {
@@ -509,7 +650,9 @@ func RowToAddrOf[T any](row CollectableRow) (*T, error) {
 	return &value, err
 }
 
-// RowToMap returns a map scanned from row.
+// RowToMap returns a map scanned from row. The map is keyed by column name and each value is decoded into its default
+// Go type as in Rows.Values. NULL values are present in the map as nil. An error is returned if row has more than one
+// column with the same name.
 func RowToMap(row CollectableRow) (map[string]any, error) {
 	var value map[string]any
 	err := row.Scan((*mapRowScanner)(&value))
@@ -524,16 +667,48 @@ func (rs *mapRowScanner) ScanRow(rows Rows) error {
 		return err
 	}
 
-	*rs = make(mapRowScanner, len(values))
+	fieldDescriptions := rows.FieldDescriptions()
+	m := make(mapRowScanner, len(values))
 
 	for i := range values {
-		(*rs)[string(rows.FieldDescriptions()[i].Name)] = values[i]
+		name := fieldDescriptions[i].Name
+		if _, exists := m[name]; exists {
+			return fmt.Errorf("duplicate column name %s", name)
+		}
+		m[name] = values[i]
 	}
 
+	*rs = m
+
 	return nil
 }
 
-// RowToStructByPos returns a T scanned from row. T must be a struct. T must have the same number a public fields as row
+// RowToTextValues returns the text representation of each column of row as produced by the server. A NULL column has
+// Valid set to false, which distinguishes it from an empty string. No codec is used, so columns of types pgx does not
+// support are returned as is.
+//
+// Every column must be in the text format. The simple protocol always returns text. With the extended protocol pass
+// QueryResultFormats{TextFormatCode} as the first query argument to request the text format for all columns. An error
+// is returned if any column is in the binary format.
+func RowToTextValues(row CollectableRow) ([]pgtype.Text, error) {
+	fieldDescriptions := row.FieldDescriptions()
+	rawValues := row.RawValues()
+
+	values := make([]pgtype.Text, len(rawValues))
+	for i, buf := range rawValues {
+		if fieldDescriptions[i].Format != TextFormatCode {
+			return nil, fmt.Errorf("column %s is not in the text format", fieldDescriptions[i].Name)
+		}
+
+		if buf != nil {
+			values[i] = pgtype.Text{String: string(buf), Valid: true}
+		}
+	}
+
+	return values, nil
+}
+
+// RowToStructByPos returns a T scanned from row. T must be a struct. T must have the same number of public fields as row
 // has fields. The row and T fields will be matched by position. If the "db" struct tag is "-" then the field will be
 // ignored.
 func RowToStructByPos[T any](row CollectableRow) (T, error) {
@@ -542,7 +717,7 @@ func RowToStructByPos[T any](row CollectableRow) (T, error) {
 	return value, err
 }
 
-// RowToAddrOfStructByPos returns the address of a T scanned from row. T must be a struct. T must have the same number a
+// RowToAddrOfStructByPos returns the address of a T scanned from row. T must be a struct. T must have the same number of
 // public fields as row has fields. The row and T fields will be matched by position. If the "db" struct tag is "-" then
 // the field will be ignored.
 func RowToAddrOfStructByPos[T any](row CollectableRow) (*T, error) {
@@ -568,6 +743,9 @@ func (rs *positionalStructRowScanner) ScanRow(rows Rows) error {
 	if len(rows.RawValues()) > len(scanTargets) {
 		return fmt.Errorf("got %d values, but dst struct has only %d fields", len(rows.RawValues()), len(scanTargets))
 	}
+	if len(rows.RawValues()) < len(scanTargets) {
+		return fmt.Errorf("got %d values, but dst struct has %d fields", len(rows.RawValues()), len(scanTargets))
+	}
 
 	return rows.Scan(scanTargets...)
 }
@@ -600,6 +778,8 @@ func (rs *positionalStructRowScanner) appendScanTargets(dstElemValue reflect.Val
 // RowToStructByName returns a T scanned from row. T must be a struct. T must have the same number of named public
 // fields as row has fields. The row and T fields will be matched by name. The match is case-insensitive. The database
 // column name can be overridden with a "db" struct tag. If the "db" struct tag is "-" then the field will be ignored.
+// The fields of anonymous embedded structs are matched as if they were fields of T. A "db" struct tag with the prefix
+// option on an embedded struct, e.g. `db:"addr_,prefix"`, is a prefix for the column names of its fields.
 func RowToStructByName[T any](row CollectableRow) (T, error) {
 	var value T
 	err := row.Scan(&namedStructRowScanner{ptrToStruct: &value})
@@ -609,7 +789,9 @@ func RowToStructByName[T any](row CollectableRow) (T, error) {
 // RowToAddrOfStructByName returns the address of a T scanned from row. T must be a struct. T must have the same number
 // of named public fields as row has fields. The row and T fields will be matched by name. The match is
 // case-insensitive. The database column name can be overridden with a "db" struct tag. If the "db" struct tag is "-"
-// then the field will be ignored.
+// then the field will be ignored. The fields of anonymous embedded structs are matched as if they were fields of T. A
+// "db" struct tag with the prefix option on an embedded struct, e.g. `db:"addr_,prefix"`, is a prefix for the column
+// names of its fields.
 func RowToAddrOfStructByName[T any](row CollectableRow) (*T, error) {
 	var value T
 	err := row.Scan(&namedStructRowScanner{ptrToStruct: &value})
@@ -619,6 +801,8 @@ func RowToAddrOfStructByName[T any](row CollectableRow) (*T, error) {
 // RowToStructByNameLax returns a T scanned from row. T must be a struct. T must have greater than or equal number of named public
 // fields as row has fields. The row and T fields will be matched by name. The match is case-insensitive. The database
 // column name can be overridden with a "db" struct tag. If the "db" struct tag is "-" then the field will be ignored.
+// The fields of anonymous embedded structs are matched as if they were fields of T. A "db" struct tag with the prefix
+// option on an embedded struct, e.g. `db:"addr_,prefix"`, is a prefix for the column names of its fields.
 func RowToStructByNameLax[T any](row CollectableRow) (T, error) {
 	var value T
 	err := row.Scan(&namedStructRowScanner{ptrToStruct: &value, lax: true})
@@ -628,7 +812,9 @@ func RowToStructByNameLax[T any](row CollectableRow) (T, error) {
 // RowToAddrOfStructByNameLax returns the address of a T scanned from row. T must be a struct. T must have greater than or
 // equal number of named public fields as row has fields. The row and T fields will be matched by name. The match is
 // case-insensitive. The database column name can be overridden with a "db" struct tag. If the "db" struct tag is "-"
-// then the field will be ignored.
+// then the field will be ignored. The fields of anonymous embedded structs are matched as if they were fields of T. A
+// "db" struct tag with the prefix option on an embedded struct, e.g. `db:"addr_,prefix"`, is a prefix for the column
+// names of its fields.
 func RowToAddrOfStructByNameLax[T any](row CollectableRow) (*T, error) {
 	var value T
 	err := row.Scan(&namedStructRowScanner{ptrToStruct: &value, lax: true})
@@ -648,7 +834,7 @@ func (rs *namedStructRowScanner) ScanRow(rows Rows) error {
 	}
 
 	dstElemValue := dstValue.Elem()
-	scanTargets, err := rs.appendScanTargets(dstElemValue, nil, rows.FieldDescriptions())
+	scanTargets, err := rs.appendScanTargets(dstElemValue, nil, rows.FieldDescriptions(), "")
 	if err != nil {
 		return err
 	}
@@ -664,6 +850,18 @@ func (rs *namedStructRowScanner) ScanRow(rows Rows) error {
 
 const structTagKey = "db"
 
+// hasStructTagOption reports whether the comma separated struct tag options opts include option.
+func hasStructTagOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
 func fieldPosByName(fldDescs []pgconn.FieldDescription, field string) (i int) {
 	i = -1
 	for i, desc := range fldDescs {
@@ -674,7 +872,9 @@ func fieldPosByName(fldDescs []pgconn.FieldDescription, field string) (i int) {
 	return
 }
 
-func (rs *namedStructRowScanner) appendScanTargets(dstElemValue reflect.Value, scanTargets []any, fldDescs []pgconn.FieldDescription) ([]any, error) {
+// appendScanTargets matches the fields of dstElemValue to fldDescs. prefix is prepended to the column name of every
+// field. It is the concatenation of the "db" struct tag prefixes of the embedded structs that contain dstElemValue.
+func (rs *namedStructRowScanner) appendScanTargets(dstElemValue reflect.Value, scanTargets []any, fldDescs []pgconn.FieldDescription, prefix string) ([]any, error) {
 	var err error
 	dstElemType := dstElemValue.Type()
 
@@ -690,7 +890,14 @@ func (rs *namedStructRowScanner) appendScanTargets(dstElemValue reflect.Value, s
 		}
 		// Handle anonymous struct embedding, but do not try to handle embedded pointers.
 		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
-			scanTargets, err = rs.appendScanTargets(dstElemValue.Field(i), scanTargets, fldDescs)
+			// The "db" struct tag of an embedded struct is only used when it has the prefix option so existing tags keep
+			// their meaning.
+			var embeddedPrefix string
+			dbTag, _ := sf.Tag.Lookup(structTagKey)
+			if name, opts, _ := strings.Cut(dbTag, ","); hasStructTagOption(opts, "prefix") {
+				embeddedPrefix = name
+			}
+			scanTargets, err = rs.appendScanTargets(dstElemValue.Field(i), scanTargets, fldDescs, prefix+embeddedPrefix)
 			if err != nil {
 				return nil, err
 			}
@@ -707,6 +914,7 @@ func (rs *namedStructRowScanner) appendScanTargets(dstElemValue reflect.Value, s
 			if !dbTagPresent {
 				colName = sf.Name
 			}
+			colName = prefix + colName
 			fpos := fieldPosByName(fldDescs, colName)
 			if fpos == -1 {
 				if rs.lax {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -129,6 +130,96 @@ func TestForEachRowAbort(t *testing.T) {
 	})
 }
 
+func TestForEachRowTo(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	type row struct {
+		N       int32
+		Doubled int32
+	}
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var actualResults []row
+
+		rows, _ := conn.Query(ctx, "select n, n * 2 as doubled from generate_series(1, $1) n", 3)
+		ct, err := pgx.ForEachRowTo(rows, pgx.RowToStructByName[row], func(r row) error {
+			actualResults = append(actualResults, r)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []row{{1, 2}, {2, 4}, {3, 6}}, actualResults)
+		require.EqualValues(t, 3, ct.RowsAffected())
+
+		calls := 0
+		rows, _ = conn.Query(ctx, "select n, n * 2 as doubled from generate_series(1, $1) n", 3)
+		ct, err = pgx.ForEachRowTo(rows, pgx.RowToStructByName[row], func(r row) error {
+			calls++
+			return errors.New("abort")
+		})
+		require.EqualError(t, err, "abort")
+		require.Equal(t, pgconn.CommandTag{}, ct)
+		require.Equal(t, 1, calls)
+
+		rows, _ = conn.Query(ctx, "select n from generate_series(1, $1) n", 3)
+		_, err = pgx.ForEachRowTo(rows, pgx.RowToStructByName[row], func(r row) error {
+			t.Fatal("fn must not be called when rowTo fails")
+			return nil
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestForEachColumnBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	pgxtest.RunWithQueryExecModes(ctx, t, defaultConnTestRunner, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		var ns []int32
+		var names []pgtype.Text
+		var batches [][]int32
+		var batchNames [][]pgtype.Text
+
+		rows, _ := conn.Query(ctx, "select n, case when n % 2 = 0 then n::text end from generate_series(1, $1) n", 5)
+		ct, err := pgx.ForEachColumnBatch(rows, 2, []any{&ns, &names}, func() error {
+			batches = append(batches, append([]int32(nil), ns...))
+			batchNames = append(batchNames, append([]pgtype.Text(nil), names...))
+			return nil
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, 5, ct.RowsAffected())
+		require.Equal(t, [][]int32{{1, 2}, {3, 4}, {5}}, batches)
+		require.Equal(t, [][]pgtype.Text{
+			{{}, {String: "2", Valid: true}},
+			{{}, {String: "4", Valid: true}},
+			{{}},
+		}, batchNames)
+
+		calls := 0
+		rows, _ = conn.Query(ctx, "select n from generate_series(1, $1) n", 5)
+		_, err = pgx.ForEachColumnBatch(rows, 2, []any{&ns}, func() error {
+			calls++
+			return errors.New("abort")
+		})
+		require.EqualError(t, err, "abort")
+		require.Equal(t, 1, calls)
+
+		rows, _ = conn.Query(ctx, "select n from generate_series(1, $1) n", 5)
+		_, err = pgx.ForEachColumnBatch(rows, 2, []any{ns}, func() error { return nil })
+		require.ErrorContains(t, err, "columns[0] must be a pointer to a slice")
+
+		rows, _ = conn.Query(ctx, "select n from generate_series(1, $1) n", 5)
+		_, err = pgx.ForEachColumnBatch(rows, 0, []any{&ns}, func() error { return nil })
+		require.ErrorContains(t, err, "batch size must be at least 1")
+
+		ensureConnValid(t, conn)
+	})
+}
+
 func ExampleForEachRow() {
 	conn, err := pgx.Connect(context.Background(), os.Getenv("PGX_TEST_DATABASE"))
 	if err != nil {
@@ -174,6 +265,36 @@ func TestCollectRows(t *testing.T) {
 	})
 }
 
+func TestCollectColumn(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select n from generate_series(0, 99) n`)
+		numbers, err := pgx.CollectColumn[int64](rows)
+		require.NoError(t, err)
+
+		assert.Len(t, numbers, 100)
+		for i := range numbers {
+			assert.Equal(t, int64(i), numbers[i])
+		}
+
+		rows, _ = conn.Query(ctx, `select n from generate_series(1, 0) n`)
+		numbers, err = pgx.CollectColumn[int64](rows)
+		require.NoError(t, err)
+		assert.Empty(t, numbers)
+
+		rows, _ = conn.Query(ctx, `select n, n from generate_series(0, 99) n`)
+		numbers, err = pgx.CollectColumn[int64](rows)
+		require.EqualError(t, err, "expected 1 column, got 2")
+		assert.Nil(t, numbers)
+
+		rows, _ = conn.Query(ctx, `select n from generate_series(0, 99) n where n = 'abc'`)
+		_, err = pgx.CollectColumn[int64](rows)
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+
+		ensureConnValid(t, conn)
+	})
+}
+
 // This example uses CollectRows with a manually written collector function. In most cases RowTo, RowToAddrOf,
 // RowToStructByPos, RowToAddrOfStructByPos, or another generic function would be used.
 func ExampleCollectRows() {
@@ -405,6 +526,53 @@ func TestRowToMap(t *testing.T) {
 	})
 }
 
+func TestRowToMapNull(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select 'Joe' as name, null::int4 as age`)
+		m, err := pgx.CollectOneRow(rows, pgx.RowToMap)
+		require.NoError(t, err)
+
+		require.Len(t, m, 2)
+		age, ok := m["age"]
+		assert.True(t, ok)
+		assert.Nil(t, age)
+	})
+}
+
+func TestRowToMapDuplicateColumnName(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select 1 as id, 2 as id`)
+		_, err := pgx.CollectRows(rows, pgx.RowToMap)
+		require.ErrorContains(t, err, "duplicate column name id")
+	})
+}
+
+func TestRowToTextValues(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select 'Joe' as name, 42::int4 as age, ''::text as empty, null::int4 as missing, '(1,2)'::point as location`,
+			pgx.QueryResultFormats{pgx.TextFormatCode},
+		)
+		values, err := pgx.CollectOneRow(rows, pgx.RowToTextValues)
+		require.NoError(t, err)
+
+		require.Equal(t, []pgtype.Text{
+			{String: "Joe", Valid: true},
+			{String: "42", Valid: true},
+			{String: "", Valid: true},
+			{},
+			{String: "(1,2)", Valid: true},
+		}, values)
+	})
+}
+
+func TestRowToTextValuesBinaryFormat(t *testing.T) {
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select 42::int4 as age`, pgx.QueryResultFormats{pgx.BinaryFormatCode})
+		_, err := pgx.CollectRows(rows, pgx.RowToTextValues)
+		require.ErrorContains(t, err, "column age is not in the text format")
+	})
+}
+
 func TestRowToStructByPos(t *testing.T) {
 	type person struct {
 		Name string
@@ -442,6 +610,44 @@ func TestRowToStructByPosIgnoredField(t *testing.T) {
 	})
 }
 
+func TestRowToStructByPosUnexportedField(t *testing.T) {
+	type person struct {
+		Name string
+		age  int32
+		Zip  string
+	}
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select 'Joe' as name, '12345' as zip from generate_series(0, 9) n`)
+		slice, err := pgx.CollectRows(rows, pgx.RowToStructByPos[person])
+		require.NoError(t, err)
+
+		assert.Len(t, slice, 10)
+		for i := range slice {
+			assert.Equal(t, "Joe", slice[i].Name)
+			assert.EqualValues(t, 0, slice[i].age)
+			assert.Equal(t, "12345", slice[i].Zip)
+		}
+	})
+}
+
+func TestRowToStructByPosFieldCountMismatch(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int32
+	}
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select 'Joe' as name`)
+		_, err := pgx.CollectRows(rows, pgx.RowToStructByPos[person])
+		require.EqualError(t, err, "got 1 values, but dst struct has 2 fields")
+
+		rows, _ = conn.Query(ctx, `select 'Joe' as name, 42 as age, 'extra' as extra`)
+		_, err = pgx.CollectRows(rows, pgx.RowToStructByPos[person])
+		require.EqualError(t, err, "got 3 values, but dst struct has only 2 fields")
+	})
+}
+
 func TestRowToStructByPosEmbeddedStruct(t *testing.T) {
 	type Name struct {
 		First string
@@ -685,6 +891,48 @@ func TestRowToStructByNameEmbeddedStruct(t *testing.T) {
 	})
 }
 
+func TestRowToStructByNameEmbeddedStructPrefix(t *testing.T) {
+	type User struct {
+		ID   int32  `db:"id"`
+		Name string `db:"name"`
+	}
+
+	type Address struct {
+		City string
+		Zip  string `db:"zip"`
+	}
+
+	type Audit struct {
+		Note string `db:"note"`
+	}
+
+	type userWithAddress struct {
+		User    `db:"user_,prefix"`
+		Address `db:"addr_,prefix"`
+		Audit   `db:"audit_"` // Without the prefix option the tag is ignored as before.
+	}
+
+	defaultConnTestRunner.RunTest(context.Background(), t, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		rows, _ := conn.Query(ctx, `select n as user_id, 'John' as user_name, 'Dallas' as addr_city, '75001' as addr_zip, 'ok' as note from generate_series(0, 9) n`)
+		slice, err := pgx.CollectRows(rows, pgx.RowToStructByName[userWithAddress])
+		require.NoError(t, err)
+
+		require.Len(t, slice, 10)
+		for i := range slice {
+			assert.EqualValues(t, i, slice[i].User.ID)
+			assert.Equal(t, "John", slice[i].User.Name)
+			assert.Equal(t, "Dallas", slice[i].Address.City)
+			assert.Equal(t, "75001", slice[i].Address.Zip)
+			assert.Equal(t, "ok", slice[i].Audit.Note)
+		}
+
+		// check unprefixed column names are not matched
+		rows, _ = conn.Query(ctx, `select 1 as user_id, 'John' as user_name, 'Dallas' as city, '75001' as addr_zip, 'ok' as note`)
+		_, err = pgx.CollectRows(rows, pgx.RowToStructByName[userWithAddress])
+		assert.ErrorContains(t, err, "cannot find field addr_City in returned row")
+	})
+}
+
 func ExampleRowToStructByName() {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
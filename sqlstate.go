@@ -0,0 +1,46 @@
+package pgx
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// The following functions report whether err or any error it wraps is a *pgconn.PgError with a particular SQLSTATE.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html for the full list of codes. Use errors.As and
+// PgError.Code to check for codes that do not have a function.
+
+// IsUniqueViolation reports whether err is a unique_violation (23505).
+func IsUniqueViolation(err error) bool {
+	return hasSQLState(err, "23505")
+}
+
+// IsForeignKeyViolation reports whether err is a foreign_key_violation (23503).
+func IsForeignKeyViolation(err error) bool {
+	return hasSQLState(err, "23503")
+}
+
+// IsCheckViolation reports whether err is a check_violation (23514).
+func IsCheckViolation(err error) bool {
+	return hasSQLState(err, "23514")
+}
+
+// IsNotNullViolation reports whether err is a not_null_violation (23502).
+func IsNotNullViolation(err error) bool {
+	return hasSQLState(err, "23502")
+}
+
+// IsSerializationFailure reports whether err is a serialization_failure (40001).
+func IsSerializationFailure(err error) bool {
+	return hasSQLState(err, "40001")
+}
+
+// IsDeadlockDetected reports whether err is a deadlock_detected (40P01).
+func IsDeadlockDetected(err error) bool {
+	return hasSQLState(err, "40P01")
+}
+
+func hasSQLState(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,59 @@
+package pgx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLStatePredicates(t *testing.T) {
+	t.Parallel()
+
+	predicates := map[string]func(error) bool{
+		"23505": pgx.IsUniqueViolation,
+		"23503": pgx.IsForeignKeyViolation,
+		"23514": pgx.IsCheckViolation,
+		"23502": pgx.IsNotNullViolation,
+		"40001": pgx.IsSerializationFailure,
+		"40P01": pgx.IsDeadlockDetected,
+	}
+
+	for code, predicate := range predicates {
+		pgErr := &pgconn.PgError{Code: code}
+		assert.True(t, predicate(pgErr), code)
+		assert.True(t, predicate(fmt.Errorf("wrapped: %w", pgErr)), code)
+		assert.False(t, predicate(&pgconn.PgError{Code: "42601"}), code)
+		assert.False(t, predicate(errors.New(code)), code)
+		assert.False(t, predicate(nil), code)
+	}
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	_, err := conn.Exec(context.Background(), "create temporary table t(id int primary key, n int not null check (n > 0))")
+	require.NoError(t, err)
+
+	_, err = conn.Exec(context.Background(), "insert into t(id, n) values (1, 1)")
+	require.NoError(t, err)
+
+	_, err = conn.Exec(context.Background(), "insert into t(id, n) values (1, 1)")
+	require.True(t, pgx.IsUniqueViolation(err))
+	require.False(t, pgx.IsCheckViolation(err))
+
+	_, err = conn.Exec(context.Background(), "insert into t(id, n) values (2, 0)")
+	require.True(t, pgx.IsCheckViolation(err))
+
+	_, err = conn.Exec(context.Background(), "insert into t(id, n) values (3, null)")
+	require.True(t, pgx.IsNotNullViolation(err))
+}
@@ -0,0 +1,106 @@
+package pgx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// QueryStatementTimeout sets the PostgreSQL statement_timeout of a single query when used as the first argument to
+// a query method. It overrides ConnConfig.DefaultStatementTimeout. A value of 0 or less sends the query without
+// changing statement_timeout.
+//
+// The timeout is set with set_config(..., true) in the same implicit transaction as the query, so it ends with that
+// transaction even if the query fails or is canceled and is never left on a server connection shared through a
+// transaction pooling proxy. Because the query runs in a transaction, statements that cannot run inside a transaction
+// block, such as VACUUM or CREATE INDEX CONCURRENTLY, cannot be combined with QueryStatementTimeout. Inside an
+// explicit transaction the timeout lasts until that transaction ends. Unlike a context deadline, the server cancels the
+// query itself and the connection remains usable.
+//
+// With QueryExecModeSimpleProtocol and ExecMulti the timeout is set in the same query string. PostgreSQL runs a query
+// string with several statements as a single implicit transaction, so the same limitations apply.
+type QueryStatementTimeout time.Duration
+
+// statementTimeoutSetSQL sets statement_timeout until the end of the current transaction.
+const statementTimeoutSetSQL = "select set_config('statement_timeout', $1, true)"
+
+// statementTimeoutMilliseconds formats d as the integer number of milliseconds statement_timeout expects. d is rounded
+// up so a positive timeout never becomes 0, which would disable the timeout.
+func statementTimeoutMilliseconds(d time.Duration) string {
+	return strconv.FormatInt(int64((d+time.Millisecond-1)/time.Millisecond), 10)
+}
+
+// statementTimeoutSimpleProtocolSQL returns sql preceded by a statement that sets statement_timeout. PostgreSQL runs
+// the statements of a simple protocol query in a single implicit transaction. set_config is used instead of SET LOCAL
+// because SET LOCAL warns when it is not inside a transaction block.
+func statementTimeoutSimpleProtocolSQL(timeout time.Duration, sql string) string {
+	return fmt.Sprintf("select set_config('statement_timeout', '%s', true);", statementTimeoutMilliseconds(timeout)) + sql
+}
+
+// queryWithStatementTimeout pipelines statementTimeoutSetSQL and the query sent by sendQuery before a single Sync so
+// they share an implicit transaction. It returns the ResultReader of the query and the pipeline that must be closed
+// after the ResultReader. The pipeline is closed on error.
+func (c *Conn) queryWithStatementTimeout(ctx context.Context, timeout time.Duration, sendQuery func(*pgconn.Pipeline)) (*pgconn.Pipeline, *pgconn.ResultReader, error) {
+	pipeline := c.pgConn.StartPipeline(ctx)
+	rr, err := c.sendWithStatementTimeout(pipeline, timeout, sendQuery)
+	if err != nil {
+		pipeline.Close()
+		return nil, nil, err
+	}
+
+	return pipeline, rr, nil
+}
+
+func (c *Conn) sendWithStatementTimeout(pipeline *pgconn.Pipeline, timeout time.Duration, sendQuery func(*pgconn.Pipeline)) (*pgconn.ResultReader, error) {
+	pipeline.SendQueryParams(statementTimeoutSetSQL, [][]byte{[]byte(statementTimeoutMilliseconds(timeout))}, nil, nil, nil)
+	sendQuery(pipeline)
+	err := pipeline.Sync()
+	if err != nil {
+		return nil, err
+	}
+
+	rr, err := nextPipelineResultReader(pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = rr.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return nextPipelineResultReader(pipeline)
+}
+
+func nextPipelineResultReader(pipeline *pgconn.Pipeline) (*pgconn.ResultReader, error) {
+	results, err := pipeline.GetResults()
+	if err != nil {
+		return nil, err
+	}
+
+	rr, ok := results.(*pgconn.ResultReader)
+	if !ok {
+		return nil, fmt.Errorf("expected statement result, got %T", results)
+	}
+
+	return rr, nil
+}
+
+// execWithStatementTimeout is the exec version of queryWithStatementTimeout.
+func (c *Conn) execWithStatementTimeout(ctx context.Context, timeout time.Duration, sendQuery func(*pgconn.Pipeline)) (pgconn.CommandTag, error) {
+	pipeline, rr, err := c.queryWithStatementTimeout(ctx, timeout, sendQuery)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	result := rr.Read()
+	closeErr := pipeline.Close()
+	if result.Err != nil {
+		return result.CommandTag, result.Err
+	}
+
+	return result.CommandTag, closeErr
+}
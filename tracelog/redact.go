@@ -0,0 +1,203 @@
+package tracelog
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RedactedValue replaces query arguments matched by a RedactionRule.
+const RedactedValue = "***"
+
+// RedactionRule reports whether the query argument arg at index i of a query with the given sql should be redacted.
+// i is zero based and does not count leading query options such as pgx.QueryExecMode, so i == 0 is the argument
+// referenced by $1.
+//
+// The values of a pgx.NamedArgs argument are checked one at a time. For these i is -1 and arg is a NamedArg.
+type RedactionRule func(sql string, i int, arg any) bool
+
+// NamedArg is the arg passed to a RedactionRule for a value of a pgx.NamedArgs argument.
+type NamedArg struct {
+	Name  string
+	Value any
+}
+
+// RedactArgs returns a RedactionRule that redacts the arguments at the given zero based indexes of every query.
+func RedactArgs(indexes ...int) RedactionRule {
+	return func(sql string, i int, arg any) bool {
+		for _, idx := range indexes {
+			if i == idx {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RedactNamedArgs returns a RedactionRule that redacts the values of pgx.NamedArgs with the given names.
+func RedactNamedArgs(names ...string) RedactionRule {
+	return func(sql string, i int, arg any) bool {
+		namedArg, ok := arg.(NamedArg)
+		if !ok {
+			return false
+		}
+		for _, name := range names {
+			if namedArg.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// RedactArgsFunc returns a RedactionRule that redacts every argument for which fn returns true. fn is called with the
+// value of each pgx.NamedArgs entry rather than with a NamedArg.
+func RedactArgsFunc(fn func(arg any) bool) RedactionRule {
+	return func(sql string, i int, arg any) bool {
+		if namedArg, ok := arg.(NamedArg); ok {
+			arg = namedArg.Value
+		}
+		return fn(arg)
+	}
+}
+
+// RedactingTracer wraps another tracer and replaces query arguments matched by any of Rules with RedactedValue before
+// they are passed to Tracer. The arguments sent to the server are not modified. Tracer should implement
+// pgx.QueryTracer and may implement pgx.BatchTracer, pgx.CopyFromTracer, pgx.PrepareTracer, and pgx.ConnectTracer. Calls
+// for interfaces that Tracer does not implement are ignored.
+//
+//	config.Tracer = &tracelog.RedactingTracer{
+//		Tracer: &tracelog.TraceLog{Logger: logger, LogLevel: tracelog.LogLevelInfo},
+//		Rules:  []tracelog.RedactionRule{tracelog.RedactArgs(1)},
+//	}
+type RedactingTracer struct {
+	Tracer any
+	Rules  []RedactionRule
+}
+
+func (rt *RedactingTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if t, ok := rt.Tracer.(pgx.QueryTracer); ok {
+		data.Args = rt.redact(data.SQL, data.Args)
+		return t.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (rt *RedactingTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if t, ok := rt.Tracer.(pgx.QueryTracer); ok {
+		t.TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+func (rt *RedactingTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	if t, ok := rt.Tracer.(pgx.BatchTracer); ok {
+		return t.TraceBatchStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (rt *RedactingTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	if t, ok := rt.Tracer.(pgx.BatchTracer); ok {
+		data.Args = rt.redact(data.SQL, data.Args)
+		t.TraceBatchQuery(ctx, conn, data)
+	}
+}
+
+func (rt *RedactingTracer) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchEndData) {
+	if t, ok := rt.Tracer.(pgx.BatchTracer); ok {
+		t.TraceBatchEnd(ctx, conn, data)
+	}
+}
+
+func (rt *RedactingTracer) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	if t, ok := rt.Tracer.(pgx.CopyFromTracer); ok {
+		return t.TraceCopyFromStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (rt *RedactingTracer) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	if t, ok := rt.Tracer.(pgx.CopyFromTracer); ok {
+		t.TraceCopyFromEnd(ctx, conn, data)
+	}
+}
+
+func (rt *RedactingTracer) TracePrepareStart(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareStartData) context.Context {
+	if t, ok := rt.Tracer.(pgx.PrepareTracer); ok {
+		return t.TracePrepareStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+func (rt *RedactingTracer) TracePrepareEnd(ctx context.Context, conn *pgx.Conn, data pgx.TracePrepareEndData) {
+	if t, ok := rt.Tracer.(pgx.PrepareTracer); ok {
+		t.TracePrepareEnd(ctx, conn, data)
+	}
+}
+
+func (rt *RedactingTracer) TraceConnectStart(ctx context.Context, data pgx.TraceConnectStartData) context.Context {
+	if t, ok := rt.Tracer.(pgx.ConnectTracer); ok {
+		return t.TraceConnectStart(ctx, data)
+	}
+	return ctx
+}
+
+func (rt *RedactingTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEndData) {
+	if t, ok := rt.Tracer.(pgx.ConnectTracer); ok {
+		t.TraceConnectEnd(ctx, data)
+	}
+}
+
+// redact returns a copy of args with the arguments matched by rt.Rules replaced. args itself is never modified as it
+// is still used to execute the query.
+func (rt *RedactingTracer) redact(sql string, args []any) []any {
+	if len(rt.Rules) == 0 || len(args) == 0 {
+		return args
+	}
+
+	redacted := make([]any, len(args))
+	copy(redacted, args)
+
+	optionCount := 0
+optionLoop:
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case pgx.NamedArgs:
+			redacted[i] = rt.redactNamedArgs(sql, arg)
+			optionCount++
+		case pgx.QueryExecMode, pgx.QueryRewriter, pgx.QueryResultFormats, pgx.QueryResultFormatsByOID, pgx.QueryStatementTimeout:
+			optionCount++
+		default:
+			break optionLoop
+		}
+	}
+
+	for i := optionCount; i < len(redacted); i++ {
+		if rt.matches(sql, i-optionCount, redacted[i]) {
+			redacted[i] = RedactedValue
+		}
+	}
+
+	return redacted
+}
+
+// redactNamedArgs returns a copy of args with the values matched by rt.Rules replaced.
+func (rt *RedactingTracer) redactNamedArgs(sql string, args pgx.NamedArgs) pgx.NamedArgs {
+	redacted := make(pgx.NamedArgs, len(args))
+	for name, value := range args {
+		if rt.matches(sql, -1, NamedArg{Name: name, Value: value}) {
+			value = RedactedValue
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+func (rt *RedactingTracer) matches(sql string, i int, arg any) bool {
+	for _, rule := range rt.Rules {
+		if rule(sql, i, arg) {
+			return true
+		}
+	}
+	return false
+}
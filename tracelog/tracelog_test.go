@@ -457,3 +457,133 @@ func TestLogPrepare(t *testing.T) {
 		require.Equal(t, err, logger.logs[0].data["err"])
 	})
 }
+
+type argsCapturingTracer struct {
+	args [][]any
+}
+
+func (ct *argsCapturingTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ct.args = append(ct.args, data.Args)
+	return ctx
+}
+
+func (ct *argsCapturingTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+}
+
+func TestRedactingTracerRedactsArgs(t *testing.T) {
+	t.Parallel()
+
+	capturing := &argsCapturingTracer{}
+	tracer := &tracelog.RedactingTracer{
+		Tracer: capturing,
+		Rules: []tracelog.RedactionRule{
+			tracelog.RedactArgs(0),
+			tracelog.RedactArgsFunc(func(arg any) bool {
+				s, ok := arg.(string)
+				return ok && strings.HasPrefix(s, "token-")
+			}),
+		},
+	}
+
+	args := []any{pgx.QueryExecModeExec, "secret", 42, "token-abc", "public"}
+	tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "select $1, $2, $3, $4", Args: args})
+
+	require.Len(t, capturing.args, 1)
+	require.Equal(t, []any{pgx.QueryExecModeExec, tracelog.RedactedValue, 42, tracelog.RedactedValue, "public"}, capturing.args[0])
+	require.Equal(t, []any{pgx.QueryExecModeExec, "secret", 42, "token-abc", "public"}, args, "original args must not be modified")
+
+	namedArgs := pgx.NamedArgs{"name": "secret", "id": 42, "key": "token-abc"}
+	tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "select @name, @id, @key", Args: []any{namedArgs}})
+	require.Len(t, capturing.args, 2)
+	require.Equal(t, []any{pgx.NamedArgs{"name": "secret", "id": 42, "key": tracelog.RedactedValue}}, capturing.args[1])
+	require.Equal(t, pgx.NamedArgs{"name": "secret", "id": 42, "key": "token-abc"}, namedArgs, "original args must not be modified")
+
+	timeoutArgs := []any{pgx.QueryStatementTimeout(time.Second), "secret"}
+	tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "select $1", Args: timeoutArgs})
+	require.Len(t, capturing.args, 3)
+	require.Equal(t, []any{pgx.QueryStatementTimeout(time.Second), tracelog.RedactedValue}, capturing.args[2])
+
+	// Interfaces not implemented by the wrapped tracer are ignored.
+	tracer.TraceBatchQuery(context.Background(), nil, pgx.TraceBatchQueryData{SQL: "select $1", Args: []any{"secret"}})
+	tracer.TracePrepareEnd(context.Background(), nil, pgx.TracePrepareEndData{})
+}
+
+func TestRedactingTracerRedactsNamedArgs(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	logger := &testLogger{}
+	tracer := &tracelog.RedactingTracer{
+		Tracer: &tracelog.TraceLog{
+			Logger:   logger,
+			LogLevel: tracelog.LogLevelTrace,
+		},
+		Rules: []tracelog.RedactionRule{
+			tracelog.RedactNamedArgs("password"),
+			tracelog.RedactArgsFunc(func(arg any) bool {
+				s, ok := arg.(string)
+				return ok && strings.HasPrefix(s, "token-")
+			}),
+		},
+	}
+
+	ctr := defaultConnTestRunner
+	ctr.CreateConfig = func(ctx context.Context, t testing.TB) *pgx.ConnConfig {
+		config := defaultConnTestRunner.CreateConfig(ctx, t)
+		config.Tracer = tracer
+		return config
+	}
+
+	pgxtest.RunWithQueryExecModes(ctx, t, ctr, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		logger.Clear() // Clear any logs written when establishing connection
+
+		args := pgx.NamedArgs{"user": "user", "password": "password", "token": "token-abc"}
+		var s string
+		err := conn.QueryRow(ctx, `select @user::text || @password::text || @token::text`, args).Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "userpasswordtoken-abc", s)
+		require.Equal(t, pgx.NamedArgs{"user": "user", "password": "password", "token": "token-abc"}, args, "original args must not be modified")
+
+		logs := logger.FilterByMsg("Query")
+		require.Len(t, logs, 1)
+		require.Equal(t, []any{pgx.NamedArgs{"user": "user", "password": tracelog.RedactedValue, "token": tracelog.RedactedValue}}, logs[0].data["args"])
+	})
+}
+
+func TestRedactingTracerWithTraceLog(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	logger := &testLogger{}
+	tracer := &tracelog.RedactingTracer{
+		Tracer: &tracelog.TraceLog{
+			Logger:   logger,
+			LogLevel: tracelog.LogLevelTrace,
+		},
+		Rules: []tracelog.RedactionRule{tracelog.RedactArgs(1)},
+	}
+
+	ctr := defaultConnTestRunner
+	ctr.CreateConfig = func(ctx context.Context, t testing.TB) *pgx.ConnConfig {
+		config := defaultConnTestRunner.CreateConfig(ctx, t)
+		config.Tracer = tracer
+		return config
+	}
+
+	pgxtest.RunWithQueryExecModes(ctx, t, ctr, nil, func(ctx context.Context, t testing.TB, conn *pgx.Conn) {
+		logger.Clear() // Clear any logs written when establishing connection
+
+		var s string
+		err := conn.QueryRow(ctx, `select $1::text || $2::text`, "user", "password").Scan(&s)
+		require.NoError(t, err)
+		require.Equal(t, "userpassword", s)
+
+		logs := logger.FilterByMsg("Query")
+		require.Len(t, logs, 1)
+		require.Equal(t, []any{"user", tracelog.RedactedValue}, logs[0].data["args"])
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 )
@@ -97,7 +99,9 @@ func (c *Conn) Begin(ctx context.Context) (Tx, error) {
 // BeginTx starts a transaction with txOptions determining the transaction mode. Unlike database/sql, the context only
 // affects the begin command. i.e. there is no auto-rollback on context cancellation.
 func (c *Conn) BeginTx(ctx context.Context, txOptions TxOptions) (Tx, error) {
+	restore := c.internalQuery()
 	_, err := c.Exec(ctx, txOptions.beginSQL())
+	restore()
 	if err != nil {
 		// begin should never fail unless there is an underlying connection issue or
 		// a context timeout. In either case, the connection is possibly broken.
@@ -163,7 +167,9 @@ func (tx *dbTx) Begin(ctx context.Context) (Tx, error) {
 	}
 
 	tx.savepointNum++
+	restore := tx.conn.internalQuery()
 	_, err := tx.conn.Exec(ctx, "savepoint sp_"+strconv.FormatInt(tx.savepointNum, 10))
+	restore()
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +183,9 @@ func (tx *dbTx) Commit(ctx context.Context) error {
 		return ErrTxClosed
 	}
 
+	restore := tx.conn.internalQuery()
 	commandTag, err := tx.conn.Exec(ctx, "commit")
+	restore()
 	tx.closed = true
 	if err != nil {
 		if tx.conn.PgConn().TxStatus() != 'I' {
@@ -201,7 +209,9 @@ func (tx *dbTx) Rollback(ctx context.Context) error {
 		return ErrTxClosed
 	}
 
+	restore := tx.conn.internalQuery()
 	_, err := tx.conn.Exec(ctx, "rollback")
+	restore()
 	tx.closed = true
 	if err != nil {
 		// A rollback failure leaves the connection in an undefined state
@@ -296,7 +306,9 @@ func (sp *dbSimulatedNestedTx) Commit(ctx context.Context) error {
 		return ErrTxClosed
 	}
 
+	restore := sp.tx.Conn().internalQuery()
 	_, err := sp.Exec(ctx, "release savepoint sp_"+strconv.FormatInt(sp.savepointNum, 10))
+	restore()
 	sp.closed = true
 	return err
 }
@@ -309,7 +321,9 @@ func (sp *dbSimulatedNestedTx) Rollback(ctx context.Context) error {
 		return ErrTxClosed
 	}
 
+	restore := sp.tx.Conn().internalQuery()
 	_, err := sp.Exec(ctx, "rollback to savepoint sp_"+strconv.FormatInt(sp.savepointNum, 10))
+	restore()
 	sp.closed = true
 	return err
 }
@@ -414,6 +428,79 @@ func BeginTxFunc(
 	return beginFuncExec(ctx, tx, fn)
 }
 
+// RetryOptions configures Retry.
+type RetryOptions struct {
+	// TxOptions are the options used to begin each attempt's transaction.
+	TxOptions TxOptions
+
+	// MaxAttempts is the maximum number of times the transaction is attempted. If it is 0 or less a default of 3 is
+	// used.
+	MaxAttempts int
+
+	// Backoff returns how long to wait before the given retry. attempt is 1 before the first retry. If nil, the delay
+	// starts at 10ms and doubles with each retry up to 1s with random jitter.
+	Backoff func(attempt int) time.Duration
+}
+
+const defaultRetryMaxAttempts = 3
+
+// Retry calls BeginTxFunc with opts.TxOptions and fn and retries it when it fails with a serialization failure
+// (SQLSTATE 40001) or a deadlock (SQLSTATE 40P01). These errors are expected under the serializable isolation level
+// and the transaction usually succeeds when it is rerun. The transaction of the failed attempt is rolled back before
+// the retry so fn must not retain any state from a previous attempt. Any other error is returned immediately. If all
+// attempts fail the error of the last attempt is returned.
+//
+// ctx is used for the transaction control statements and while waiting between attempts.
+func Retry(
+	ctx context.Context,
+	db interface {
+		BeginTx(ctx context.Context, txOptions TxOptions) (Tx, error)
+	},
+	opts RetryOptions,
+	fn func(Tx) error,
+) error {
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRetryMaxAttempts
+	}
+
+	backoff := opts.Backoff
+	if backoff == nil {
+		backoff = defaultRetryBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := BeginTxFunc(ctx, db, opts.TxOptions, fn)
+		if err == nil || attempt >= maxAttempts || !isRetryableTxError(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func isRetryableTxError(err error) bool {
+	return IsSerializationFailure(err) || IsDeadlockDetected(err)
+}
+
+func defaultRetryBackoff(attempt int) time.Duration {
+	d := time.Second
+	if attempt <= 7 {
+		d = 10 * time.Millisecond << (attempt - 1)
+		if d > time.Second {
+			d = time.Second
+		}
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)))
+}
+
 func beginFuncExec(ctx context.Context, tx Tx, fn func(Tx) error) (err error) {
 	defer func() {
 		rollbackErr := tx.Rollback(ctx)
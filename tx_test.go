@@ -642,3 +642,80 @@ func TestTxSendBatchClosed(t *testing.T) {
 	_, err = br.Query()
 	require.Error(t, err)
 }
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	_, err := conn.Exec(context.Background(), "create temporary table foo(id integer)")
+	require.NoError(t, err)
+
+	opts := pgx.RetryOptions{
+		TxOptions: pgx.TxOptions{IsoLevel: pgx.Serializable},
+		Backoff:   func(int) time.Duration { return 0 },
+	}
+
+	attempts := 0
+	err = pgx.Retry(context.Background(), conn, opts, func(tx pgx.Tx) error {
+		attempts++
+		_, err := tx.Exec(context.Background(), "insert into foo(id) values (1)")
+		require.NoError(t, err)
+		if attempts < 3 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	var n int64
+	err = conn.QueryRow(context.Background(), "select count(*) from foo").Scan(&n)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+}
+
+func TestRetryDoesNotRetryOtherErrors(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	attempts := 0
+	err := pgx.Retry(context.Background(), conn, pgx.RetryOptions{}, func(tx pgx.Tx) error {
+		attempts++
+		_, err := tx.Exec(context.Background(), "select 1/0")
+		return err
+	})
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "22012", pgErr.Code)
+	require.Equal(t, 1, attempts)
+
+	ensureConnValid(t, conn)
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	conn := mustConnectString(t, os.Getenv("PGX_TEST_DATABASE"))
+	defer closeConn(t, conn)
+
+	opts := pgx.RetryOptions{
+		MaxAttempts: 2,
+		Backoff:     func(int) time.Duration { return time.Millisecond },
+	}
+
+	attempts := 0
+	err := pgx.Retry(context.Background(), conn, opts, func(tx pgx.Tx) error {
+		attempts++
+		return &pgconn.PgError{Code: "40P01"}
+	})
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "40P01", pgErr.Code)
+	require.Equal(t, 2, attempts)
+
+	ensureConnValid(t, conn)
+}